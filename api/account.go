@@ -24,20 +24,28 @@ func (server *Server) createAccount(c *gin.Context) {
 
 	authPayload := c.MustGet(authorizationPaylaodKey).(*token.Payload)
 
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: req.Currency,
-		Balance:  0,
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Currency: req.Currency,
+			Balance:  0,
+		},
+		IdempotencyKey: c.GetHeader(idempotencyKeyHeader),
+		Actor:          auditActor(c, authPayload.Username),
 	}
 
-	account, err := server.store.CreateAccount(c, arg)
+	result, err := server.store.CreateAccountTx(c, arg)
 
 	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, account)
+	c.JSON(http.StatusOK, result.Account)
 }
 
 type getAccountRequest struct {
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	mockdb "github.com/starjardin/simplebank/db/mock"
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/utils"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
@@ -17,6 +19,10 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		AccessTokenDuration: time.Minute,
 	}
 
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		allowAllTokens(mockStore)
+	}
+
 	server, err := NewServer(config, store)
 
 	require.NoError(t, err)
@@ -28,3 +34,11 @@ func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
 }
+
+// allowAllTokens treats every token as not revoked. Expectations set before
+// it is called take precedence.
+func allowAllTokens(store *mockdb.MockStore) {
+	store.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+	store.EXPECT().IsSessionFamilyBlocked(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+	store.EXPECT().GetUserPasswordChangeAt(gomock.Any(), gomock.Any()).AnyTimes().Return(time.Time{}, nil)
+}
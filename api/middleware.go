@@ -13,9 +13,10 @@ const (
 	authorizationHeaderKey  = "authorization"
 	authorizationTypeBearer = "bearer"
 	authorizationPaylaodKey = "authorization_payload"
+	idempotencyKeyHeader    = "Idempotency-Key"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, revocations *token.RevocationList) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if authorizationHeader == "" {
@@ -50,6 +51,20 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			ctx.Abort()
+			return
+		}
+
+		if payload.Type != token.TypeAccess {
+			err := errors.New("not an access token")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.Abort()
+			return
+		}
+
+		if err := revocations.Check(ctx, payload); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.Abort()
+			return
 		}
 
 		ctx.Set(authorizationPaylaodKey, payload)
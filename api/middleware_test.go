@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	mockdb "github.com/starjardin/simplebank/db/mock"
 	"github.com/starjardin/simplebank/token"
 	"github.com/starjardin/simplebank/utils"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func AddAuthorization(
@@ -21,7 +23,11 @@ func AddAuthorization(
 	username string,
 	duration time.Duration,
 ) {
-	token, payload, err := tokenMaker.CreateToken(username, utils.DepositorRole, duration)
+	token, payload, err := tokenMaker.CreateToken(token.Claims{
+		Username: username,
+		Role:     utils.DepositorRole,
+		Type:     token.TypeAccess,
+	}, duration)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
@@ -34,6 +40,7 @@ func TestMiddleWare(t *testing.T) {
 	testCases := []struct {
 		name          string
 		setupAuth     func(t *testing.T, request http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -41,6 +48,8 @@ func TestMiddleWare(t *testing.T) {
 			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
@@ -49,6 +58,8 @@ func TestMiddleWare(t *testing.T) {
 			name: "NoAuthorization",
 			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -58,6 +69,8 @@ func TestMiddleWare(t *testing.T) {
 			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, "unsupported", "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -67,6 +80,8 @@ func TestMiddleWare(t *testing.T) {
 			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, "", "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -76,6 +91,54 @@ func TestMiddleWare(t *testing.T) {
 			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", -time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RefreshToken",
+			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
+				refreshToken, _, err := tokenMaker.CreateToken(token.Claims{
+					Username: "user",
+					Role:     utils.DepositorRole,
+					Type:     token.TypeRefresh,
+				}, time.Minute)
+				require.NoError(t, err)
+
+				request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, refreshToken))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RevokedToken",
+			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
+				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "IssuedBeforePasswordChange",
+			setupAuth: func(t *testing.T, request http.Request, tokenMaker token.Maker) {
+				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().
+					GetUserPasswordChangeAt(gomock.Any(), gomock.Eq("user")).
+					Times(1).
+					Return(time.Now().Add(time.Minute), nil)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
@@ -86,11 +149,17 @@ func TestMiddleWare(t *testing.T) {
 		tc := testCases[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
 
 			authPath := "/auth"
 
-			server.router.GET(authPath, authMiddleware(server.tokenMaker), func(ctx *gin.Context) {
+			server.router.GET(authPath, authMiddleware(server.tokenMaker, server.revocations), func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{})
 			})
 			recorder := httptest.NewRecorder()
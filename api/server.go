@@ -9,27 +9,48 @@ import (
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/token"
 	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
 )
 
 type Server struct {
-	store      db.Store
-	router     *gin.Engine
-	tokenMaker token.Maker
-	config     utils.Config
+	store          db.Store
+	router         *gin.Engine
+	tokenMaker     token.Maker
+	config         utils.Config
+	revocations    *token.RevocationList
+	passwordPolicy val.PasswordPolicy
+	passwordHasher utils.PasswordHasher
 }
 
 func NewServer(config utils.Config, store db.Store) (*Server, error) {
 
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymetricKey)
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	breachedPasswords, err := val.LoadBreachedPasswords(config.BreachedPasswordsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load breached passwords: %w", err)
+	}
+
+	passwordHasher, err := utils.NewPasswordHasher(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create password hasher: %w", err)
+	}
+
 	server := &Server{
-		store:      store,
-		router:     gin.Default(),
-		config:     config,
-		tokenMaker: tokenMaker,
+		store:       store,
+		router:      gin.Default(),
+		config:      config,
+		tokenMaker:  tokenMaker,
+		revocations: token.NewRevocationList(store, config.RevocationCacheDuration),
+		passwordPolicy: val.PasswordPolicy{
+			MinLength:           config.PasswordMinLength,
+			MinCharacterClasses: config.PasswordMinCharacterClasses,
+			Breached:            breachedPasswords,
+		},
+		passwordHasher: passwordHasher,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -40,7 +61,7 @@ func NewServer(config utils.Config, store db.Store) (*Server, error) {
 	server.router.POST("/users/login", server.loginUser)
 	server.router.POST("/tokens/renew_access", server.renewAccessToken)
 
-	authRoutes := server.router.Group("/").Use(authMiddleware(tokenMaker))
+	authRoutes := server.router.Group("/").Use(authMiddleware(tokenMaker, server.revocations))
 
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
@@ -55,6 +76,16 @@ func (server *Server) Start(address string) error {
 	return server.router.Run(address)
 }
 
+// auditActor returns who is making the request and from where, for the audit
+// log.
+func auditActor(c *gin.Context, username string) db.AuditActor {
+	return db.AuditActor{
+		Username:  username,
+		ClientIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 
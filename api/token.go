@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/token"
 )
 
 type renewAccessTokenRequest struct {
@@ -33,6 +34,17 @@ func (server *Server) renewAccessToken(c *gin.Context) {
 		return
 	}
 
+	if refreshPayload.Type != token.TypeRefresh {
+		err := fmt.Errorf("not a refresh token")
+		c.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if err := server.revocations.Check(c, refreshPayload); err != nil {
+		c.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
 	session, err := server.store.GetSession(c, refreshPayload.ID)
 
 	if err != nil {
@@ -50,6 +62,12 @@ func (server *Server) renewAccessToken(c *gin.Context) {
 		return
 	}
 
+	if session.IsUsed {
+		err := fmt.Errorf("session has been rotated")
+		c.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
 	if session.Username != refreshPayload.Username {
 		err := fmt.Errorf("incorrect session user")
 		c.JSON(http.StatusUnauthorized, errorResponse(err))
@@ -65,13 +83,15 @@ func (server *Server) renewAccessToken(c *gin.Context) {
 	if time.Now().After(session.ExpiresAt) {
 		err := fmt.Errorf("session expired")
 		c.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		refreshPayload.Username,
-		refreshPayload.Role,
-		server.config.AccessTokenDuration,
-	)
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username:  refreshPayload.Username,
+		Role:      refreshPayload.Role,
+		Type:      token.TypeAccess,
+		SessionID: session.FamilyID,
+	}, server.config.AccessTokenDuration)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
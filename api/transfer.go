@@ -41,22 +41,48 @@ func (server *Server) createTransfer(c *gin.Context) {
 		return
 	}
 
-	_, valid = server.validAccount(c, req.FromAccountID, req.Currency)
+	_, valid = server.validAccount(c, req.ToAccountID, req.Currency)
 
 	if !valid {
 		return
 	}
 
+	threshold := server.config.TwoFactorTransferThreshold
+
+	if threshold > 0 && req.Amount > threshold {
+		err := fmt.Errorf("amounts above %d need a two-factor code, use /v1/create_transfer", threshold)
+		c.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
 	arg := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		Owner:          authPayload.Username,
+		IdempotencyKey: c.GetHeader(idempotencyKeyHeader),
+		Actor:          auditActor(c, authPayload.Username),
 	}
 
 	result, err := server.store.TransferTx(c, arg)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch {
+		case errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrCurrencyMismatch),
+			errors.Is(err, db.ErrSameAccount):
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrAccountOwnerMismatch):
+			c.JSON(http.StatusUnauthorized, errorResponse(err))
+		case errors.Is(err, db.ErrAccountUnavailable):
+			c.JSON(http.StatusForbidden, errorResponse(err))
+		case errors.Is(err, db.ErrTransferLimitExceeded):
+			c.JSON(http.StatusTooManyRequests, errorResponse(err))
+		case errors.Is(err, db.ErrIdempotencyKeyConflict):
+			c.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 
@@ -24,10 +24,11 @@ type eqCreateUserParamsMatcher struct {
 }
 
 func (e eqCreateUserParamsMatcher) Matches(x interface{}) bool {
-	arg, ok := x.(db.CreateUserParams)
+	txArg, ok := x.(db.CreateUserTxParams)
 	if !ok {
 		return false
 	}
+	arg := txArg.CreateUserParams
 
 	err := utils.CheckPassword(e.password, arg.HashedPassword)
 	if err != nil {
@@ -35,7 +36,7 @@ func (e eqCreateUserParamsMatcher) Matches(x interface{}) bool {
 	}
 
 	e.arg.HashedPassword = arg.HashedPassword
-	return reflect.DeepEqual(arg, arg)
+	return reflect.DeepEqual(e.arg, arg)
 }
 
 func (e eqCreateUserParamsMatcher) String() string {
@@ -72,9 +73,9 @@ func TestCreateUserAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					CreateUser(gomock.Any(), EqCreateUserParams(arg, password)).
+					CreateUserTx(gomock.Any(), EqCreateUserParams(arg, password)).
 					Times(1).
-					Return(user, nil)
+					Return(db.CreateUserTxResult{User: user}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/token"
 	"github.com/starjardin/simplebank/utils"
 )
 
@@ -46,21 +47,29 @@ func (server *Server) createUser(c *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := utils.HashedPassword(req.Password)
+	if err := server.passwordPolicy.Validate(req.Password, req.Username, req.Email); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("password %w", err)))
+		return
+	}
+
+	hashedPassword, err := server.passwordHasher.Hash(req.Password)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	arg := db.CreateUserParams{
-		Username:       req.Username,
-		FullName:       req.FullName,
-		Email:          req.Email,
-		HashedPassword: hashedPassword,
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       req.Username,
+			FullName:       req.FullName,
+			Email:          req.Email,
+			HashedPassword: hashedPassword,
+		},
+		Actor: auditActor(c, ""),
 	}
 
-	user, err := server.store.CreateUser(c, arg)
+	txResult, err := server.store.CreateUserTx(c, arg)
 
 	if err != nil {
 		if db.ErrorCode(err) == db.UniqueViolation {
@@ -71,7 +80,7 @@ func (server *Server) createUser(c *gin.Context) {
 		return
 	}
 
-	resp := newUserResponse(user)
+	resp := newUserResponse(txResult.User)
 
 	c.JSON(http.StatusOK, resp)
 }
@@ -115,36 +124,62 @@ func (server *Server) loginUser(c *gin.Context) {
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		user.Username,
-		user.Role,
-		server.config.AccessTokenDuration,
-	)
+	if user.FrozenAt.Valid {
+		err := errors.New("user is frozen")
+		c.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	// This API has no second login step, so users with two-factor
+	// authentication must log in through the gRPC gateway.
+	credential, err := server.store.GetTOTPCredential(c, user.Username)
+
+	if err != nil && !errors.Is(err, db.ErrorRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err == nil && credential.ConfirmedAt.Valid {
+		err := errors.New("two-factor authentication is enabled, log in with /v1/login_user")
+		c.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username: user.Username,
+		Role:     user.Role,
+		Type:     token.TypeRefresh,
+	}, server.config.RefreshTokenDuration)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
-		user.Username,
-		user.Role,
-		server.config.RefreshTokenDuration,
-	)
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username:  user.Username,
+		Role:      user.Role,
+		Type:      token.TypeAccess,
+		SessionID: refreshPayload.ID,
+	}, server.config.AccessTokenDuration)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	session, err := server.store.CreateSession(c, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    c.Request.UserAgent(),
-		ClientIp:     c.ClientIP(),
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiredAt,
+	txResult, err := server.store.CreateSessionTx(c, db.CreateSessionTxParams{
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           refreshPayload.ID,
+			Username:     user.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    c.Request.UserAgent(),
+			ClientIp:     c.ClientIP(),
+			IsBlocked:    false,
+			ExpiresAt:    refreshPayload.ExpiredAt,
+			FamilyID:     refreshPayload.ID,
+		},
+		Actor: auditActor(c, user.Username),
 	})
 
 	if err != nil {
@@ -155,7 +190,7 @@ func (server *Server) loginUser(c *gin.Context) {
 	resp := loginUserResponse{
 		AccessToken:           accessToken,
 		User:                  newUserResponse(user),
-		SessionID:             session.ID,
+		SessionID:             txResult.Session.ID,
 		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
 		RefreshToken:          refreshToken,
 		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
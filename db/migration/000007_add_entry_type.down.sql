@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "entries_type_external_reference_key";
+
+ALTER TABLE "entries" DROP COLUMN "external_reference";
+ALTER TABLE "entries" DROP COLUMN "type";
@@ -0,0 +1,5 @@
+ALTER TABLE "entries" ADD COLUMN "type" varchar NOT NULL DEFAULT 'transfer';
+ALTER TABLE "entries" ADD COLUMN "external_reference" varchar;
+
+CREATE UNIQUE INDEX "entries_type_external_reference_key" ON "entries" ("type", "external_reference")
+WHERE "external_reference" IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "postings";
+DROP TABLE IF EXISTS "journals";
+DROP TABLE IF EXISTS "system_accounts";
+
+DROP FUNCTION IF EXISTS reject_ledger_change();
+DROP FUNCTION IF EXISTS check_journal_balanced();
@@ -0,0 +1,106 @@
+CREATE TABLE "system_accounts" (
+    "id" bigserial PRIMARY KEY,
+    "code" varchar NOT NULL,
+    "currency" varchar(10) NOT NULL,
+    "balance" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "system_accounts_code_currency_key" UNIQUE ("code", "currency")
+);
+
+CREATE TABLE "journals" (
+    "id" bigserial PRIMARY KEY,
+    "type" varchar NOT NULL,
+    "transfer_id" bigint,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "journals_transfer_id_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id")
+);
+
+CREATE TABLE "postings" (
+    "id" bigserial PRIMARY KEY,
+    "journal_id" bigint NOT NULL,
+    "account_id" bigint,
+    "system_account_id" bigint,
+    "entry_id" bigint,
+    "amount" bigint NOT NULL,
+    "currency" varchar(10) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "postings_journal_id_fk" FOREIGN KEY ("journal_id") REFERENCES "journals" ("id"),
+    CONSTRAINT "postings_account_id_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id"),
+    CONSTRAINT "postings_system_account_id_fk" FOREIGN KEY ("system_account_id") REFERENCES "system_accounts" ("id"),
+    CONSTRAINT "postings_entry_id_fk" FOREIGN KEY ("entry_id") REFERENCES "entries" ("id"),
+    CONSTRAINT "postings_single_account_check" CHECK (("account_id" IS NULL) <> ("system_account_id" IS NULL))
+);
+
+CREATE INDEX ON "postings" ("journal_id");
+
+CREATE INDEX ON "postings" ("account_id");
+
+CREATE INDEX ON "postings" ("system_account_id");
+
+-- Every journal must balance per currency once its transaction commits.
+CREATE FUNCTION check_journal_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings
+        WHERE journal_id = NEW.journal_id
+        GROUP BY currency
+        HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal % is not balanced', NEW.journal_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "postings_balanced_trigger"
+AFTER INSERT ON "postings"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION check_journal_balanced();
+
+-- Journals and postings are append-only.
+CREATE FUNCTION reject_ledger_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME
+        USING ERRCODE = 'restrict_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "journals_append_only_trigger"
+BEFORE UPDATE OR DELETE ON "journals"
+FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "postings_append_only_trigger"
+BEFORE UPDATE OR DELETE ON "postings"
+FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+-- Book the balances that existed before the ledger against suspense so that
+-- every account starts out reconciled.
+INSERT INTO "system_accounts" ("code", "currency")
+SELECT DISTINCT 'suspense', "currency" FROM "accounts";
+
+WITH opening AS (
+    INSERT INTO "journals" ("type")
+    SELECT 'opening_balance' FROM "accounts" WHERE "balance" <> 0
+    RETURNING "id"
+), numbered_journals AS (
+    SELECT "id", row_number() OVER (ORDER BY "id") AS n FROM opening
+), numbered_accounts AS (
+    SELECT "id", "balance", "currency", row_number() OVER (ORDER BY "id") AS n
+    FROM "accounts" WHERE "balance" <> 0
+)
+INSERT INTO "postings" ("journal_id", "account_id", "system_account_id", "amount", "currency")
+SELECT j."id", a."id", NULL, a."balance", a."currency"
+FROM numbered_journals j JOIN numbered_accounts a ON a.n = j.n
+UNION ALL
+SELECT j."id", NULL, s."id", -a."balance", a."currency"
+FROM numbered_journals j
+JOIN numbered_accounts a ON a.n = j.n
+JOIN "system_accounts" s ON s."code" = 'suspense' AND s."currency" = a."currency";
+
+UPDATE "system_accounts" s
+SET "balance" = -totals."balance"
+FROM (
+    SELECT "currency", SUM("balance") AS "balance" FROM "accounts" GROUP BY "currency"
+) totals
+WHERE s."code" = 'suspense' AND s."currency" = totals."currency";
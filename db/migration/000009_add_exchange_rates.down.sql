@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS "transfers" DROP CONSTRAINT IF EXISTS "transfers_quote_id_fk";
+
+ALTER TABLE "transfers" DROP COLUMN "quote_id";
+ALTER TABLE "transfers" DROP COLUMN "exchange_rate";
+ALTER TABLE "transfers" DROP COLUMN "to_amount";
+
+DROP TABLE IF EXISTS "transfer_quotes";
+DROP TABLE IF EXISTS "exchange_rates";
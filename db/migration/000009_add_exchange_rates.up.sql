@@ -0,0 +1,39 @@
+CREATE TABLE "exchange_rates" (
+    "id" bigserial PRIMARY KEY,
+    "base_currency" varchar(10) NOT NULL,
+    "quote_currency" varchar(10) NOT NULL,
+    "rate" numeric(20, 8) NOT NULL,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "exchange_rates_created_by_fk" FOREIGN KEY ("created_by") REFERENCES "users" ("username"),
+    CONSTRAINT "exchange_rates_rate_check" CHECK ("rate" > 0),
+    CONSTRAINT "exchange_rates_currency_check" CHECK ("base_currency" <> "quote_currency")
+);
+
+CREATE INDEX ON "exchange_rates" ("base_currency", "quote_currency", "created_at");
+
+CREATE TABLE "transfer_quotes" (
+    "id" uuid PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "from_account_id" bigint NOT NULL,
+    "to_account_id" bigint NOT NULL,
+    "from_amount" bigint NOT NULL,
+    "from_currency" varchar(10) NOT NULL,
+    "to_amount" bigint NOT NULL,
+    "to_currency" varchar(10) NOT NULL,
+    "exchange_rate_id" bigint NOT NULL,
+    "rate" numeric(20, 8) NOT NULL,
+    "is_used" boolean NOT NULL DEFAULT false,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "transfer_quotes_username_fk" FOREIGN KEY ("username") REFERENCES "users" ("username"),
+    CONSTRAINT "transfer_quotes_from_account_fk" FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id"),
+    CONSTRAINT "transfer_quotes_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id"),
+    CONSTRAINT "transfer_quotes_exchange_rate_fk" FOREIGN KEY ("exchange_rate_id") REFERENCES "exchange_rates" ("id")
+);
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric(20, 8);
+ALTER TABLE "transfers" ADD COLUMN "quote_id" uuid;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_quote_id_fk" FOREIGN KEY ("quote_id") REFERENCES "transfer_quotes" ("id");
@@ -0,0 +1,38 @@
+CREATE TABLE "scheduled_transfers" (
+    "id" bigserial PRIMARY KEY,
+    "owner" varchar NOT NULL,
+    "from_account_id" bigint NOT NULL,
+    "to_account_id" bigint NOT NULL,
+    "amount" bigint NOT NULL,
+    "cron_expression" varchar,
+    "interval_seconds" bigint,
+    "next_run_at" timestamptz NOT NULL,
+    "is_active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "scheduled_transfers_owner_fk" FOREIGN KEY ("owner") REFERENCES "users" ("username"),
+    CONSTRAINT "scheduled_transfers_from_account_fk" FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id"),
+    CONSTRAINT "scheduled_transfers_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id"),
+    CONSTRAINT "scheduled_transfers_amount_check" CHECK ("amount" > 0),
+    CONSTRAINT "scheduled_transfers_account_check" CHECK ("from_account_id" <> "to_account_id"),
+    CONSTRAINT "scheduled_transfers_schedule_check" CHECK (("cron_expression" IS NULL) <> ("interval_seconds" IS NULL)),
+    CONSTRAINT "scheduled_transfers_interval_check" CHECK ("interval_seconds" IS NULL OR "interval_seconds" >= 60)
+);
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+CREATE INDEX ON "scheduled_transfers" ("next_run_at") WHERE "is_active";
+
+CREATE TABLE "scheduled_transfer_runs" (
+    "id" bigserial PRIMARY KEY,
+    "scheduled_transfer_id" bigint NOT NULL,
+    "scheduled_at" timestamptz NOT NULL,
+    "attempt" integer NOT NULL,
+    "status" varchar NOT NULL,
+    "transfer_id" bigint,
+    "error" varchar,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "scheduled_transfer_runs_scheduled_transfer_fk" FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id") ON DELETE CASCADE,
+    CONSTRAINT "scheduled_transfer_runs_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id")
+);
+
+CREATE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id", "created_at");
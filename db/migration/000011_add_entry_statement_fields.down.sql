@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "entries_transfer_id_idx";
+DROP INDEX IF EXISTS "entries_account_id_created_at_id_idx";
+
+ALTER TABLE IF EXISTS "entries" DROP CONSTRAINT IF EXISTS "entries_transfer_id_fk";
+
+ALTER TABLE "entries" DROP COLUMN "balance_after";
+ALTER TABLE "entries" DROP COLUMN "transfer_id";
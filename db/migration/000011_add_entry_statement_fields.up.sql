@@ -0,0 +1,37 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+ALTER TABLE "entries" ADD COLUMN "balance_after" bigint;
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_transfer_id_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+-- Transfer entries were written in the same transaction as their transfer, so
+-- they share its creation time.
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE e."type" IN ('transfer', 'exchange')
+    AND e."created_at" = t."created_at"
+    AND (
+        (e."account_id" = t."from_account_id" AND e."amount" = -t."amount") OR
+        (e."account_id" = t."to_account_id" AND e."amount" = COALESCE(t."to_amount", t."amount"))
+    );
+
+-- Work backwards from the current balance of each account.
+UPDATE "entries" e
+SET "balance_after" = b."balance_after"
+FROM (
+    SELECT
+        e."id",
+        a."balance" - COALESCE(SUM(e."amount") OVER (
+            PARTITION BY e."account_id"
+            ORDER BY e."created_at" DESC, e."id" DESC
+            ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+        ), 0) AS "balance_after"
+    FROM "entries" e
+    JOIN "accounts" a ON a."id" = e."account_id"
+) b
+WHERE e."id" = b."id";
+
+ALTER TABLE "entries" ALTER COLUMN "balance_after" SET NOT NULL;
+
+CREATE INDEX ON "entries" ("account_id", "created_at", "id");
+CREATE INDEX ON "entries" ("transfer_id");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "sessions_family_id_idx";
+
+ALTER TABLE "sessions" DROP COLUMN "is_used";
+ALTER TABLE "sessions" DROP COLUMN "family_id";
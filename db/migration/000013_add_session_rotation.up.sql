@@ -0,0 +1,8 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+ALTER TABLE "sessions" ADD COLUMN "is_used" boolean NOT NULL DEFAULT false;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+CREATE INDEX ON "sessions" ("family_id");
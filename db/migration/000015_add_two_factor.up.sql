@@ -0,0 +1,21 @@
+CREATE TABLE "totp_credentials" (
+  "username" varchar PRIMARY KEY,
+  "secret" varchar NOT NULL,
+  "confirmed_at" timestamptz,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "totp_credentials" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX ON "recovery_codes" ("username", "code_hash");
@@ -0,0 +1,10 @@
+CREATE TABLE "login_failures" (
+  "scope" varchar NOT NULL,
+  "key" varchar NOT NULL,
+  "failed_count" integer NOT NULL DEFAULT 0,
+  "last_failed_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  PRIMARY KEY ("scope", "key")
+);
+
+COMMENT ON COLUMN "login_failures"."scope" IS 'username or client_ip';
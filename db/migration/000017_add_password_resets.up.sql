@@ -0,0 +1,13 @@
+CREATE TABLE "password_resets" (
+    "id" bigserial PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "email" varchar NOT NULL,
+    "secret_code" varchar NOT NULL,
+    "is_used" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+ALTER TABLE "password_resets" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "password_resets" ("username");
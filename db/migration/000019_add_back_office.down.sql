@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "audit_events" CASCADE;
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "frozen_at";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "frozen_at";
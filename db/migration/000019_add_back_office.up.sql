@@ -0,0 +1,24 @@
+ALTER TABLE "users" ADD COLUMN "frozen_at" timestamptz;
+ALTER TABLE "accounts" ADD COLUMN "frozen_at" timestamptz;
+
+CREATE TABLE "audit_events" (
+    "id" bigserial PRIMARY KEY,
+    "actor" varchar NOT NULL,
+    "action" varchar NOT NULL,
+    "target_type" varchar NOT NULL,
+    "target_id" varchar NOT NULL,
+    "reason" text NOT NULL DEFAULT '',
+    "before" jsonb,
+    "after" jsonb,
+    "client_ip" varchar NOT NULL DEFAULT '',
+    "user_agent" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "audit_events" ADD FOREIGN KEY ("actor") REFERENCES "users" ("username");
+
+CREATE INDEX ON "audit_events" ("target_type", "target_id", "created_at");
+CREATE INDEX ON "audit_events" ("actor", "created_at");
+
+COMMENT ON COLUMN "users"."frozen_at" IS 'frozen users cannot log in, null when not frozen';
+COMMENT ON COLUMN "accounts"."frozen_at" IS 'frozen accounts cannot send or receive transfers, null when not frozen';
@@ -0,0 +1,20 @@
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_to_account_fk";
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_from_account_fk";
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_from_account_fk" FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "entries" DROP CONSTRAINT "entries_account_id_fk";
+ALTER TABLE "entries" ADD CONSTRAINT "entries_account_id_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "accounts" ADD COLUMN "frozen_at" timestamptz;
+
+UPDATE "accounts" SET "frozen_at" = "status_changed_at"
+WHERE "status" = 'frozen';
+
+DROP INDEX IF EXISTS "accounts_owner_currency_key";
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_status_check";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status_changed_at";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,28 @@
+ALTER TABLE "accounts" ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+ALTER TABLE "accounts" ADD COLUMN "status_changed_at" timestamptz NOT NULL DEFAULT (now());
+
+UPDATE "accounts" SET "status" = 'frozen', "status_changed_at" = "frozen_at"
+WHERE "frozen_at" IS NOT NULL;
+
+ALTER TABLE "accounts" DROP COLUMN "frozen_at";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_status_check"
+CHECK ("status" IN ('active', 'frozen', 'dormant', 'closed'));
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen, dormant or closed';
+
+-- A closed account must not keep its owner from opening a new one in the
+-- same currency.
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_key";
+CREATE UNIQUE INDEX "accounts_owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed';
+
+-- Accounts are closed instead of deleted, so deleting one must never take
+-- its financial history with it.
+ALTER TABLE "entries" DROP CONSTRAINT "entries_account_id_fk";
+ALTER TABLE "entries" ADD CONSTRAINT "entries_account_id_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_from_account_fk";
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_from_account_fk" FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfers" DROP CONSTRAINT "transfers_to_account_fk";
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS "audit_events_no_truncate" ON "audit_events";
+DROP TRIGGER IF EXISTS "audit_events_append_only" ON "audit_events";
+DROP FUNCTION IF EXISTS "reject_audit_event_change"();
+
+DROP INDEX IF EXISTS "audit_events_action_created_at_idx";
+
+DELETE FROM "audit_events" WHERE "actor" IS NULL;
+ALTER TABLE "audit_events" ALTER COLUMN "actor" SET NOT NULL;
@@ -0,0 +1,19 @@
+ALTER TABLE "audit_events" ALTER COLUMN "actor" DROP NOT NULL;
+
+COMMENT ON COLUMN "audit_events"."actor" IS 'null for changes made by the system';
+
+CREATE INDEX ON "audit_events" ("action", "created_at");
+
+CREATE FUNCTION "reject_audit_event_change"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events cannot be changed or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_events_append_only"
+BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW EXECUTE FUNCTION "reject_audit_event_change"();
+
+CREATE TRIGGER "audit_events_no_truncate"
+BEFORE TRUNCATE ON "audit_events"
+FOR EACH STATEMENT EXECUTE FUNCTION "reject_audit_event_change"();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "entries_account_id_id_idx";
+
+DROP FUNCTION IF EXISTS "entry_hash";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "hash";
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "prev_hash";
@@ -0,0 +1,58 @@
+ALTER TABLE "entries" ADD COLUMN "prev_hash" bytea;
+ALTER TABLE "entries" ADD COLUMN "hash" bytea;
+
+COMMENT ON COLUMN "entries"."prev_hash" IS 'hash of the previous entry of the account, null for its first entry';
+COMMENT ON COLUMN "entries"."hash" IS 'sha256 of prev_hash and the content of the entry';
+
+-- entry_hash must stay in step with entryHash in db/sqlc/ledger_integrity.go,
+-- which verifies the chain without relying on this function.
+CREATE FUNCTION "entry_hash"(
+    "prev_hash" bytea,
+    "account_id" bigint,
+    "amount" bigint,
+    "type" varchar,
+    "transfer_id" bigint,
+    "balance_after" bigint,
+    "created_at" timestamp,
+    "external_reference" varchar
+) RETURNS bytea AS $$
+    SELECT sha256(
+        COALESCE("prev_hash", ''::bytea) ||
+        convert_to(concat_ws('|',
+            "account_id",
+            "amount",
+            "type",
+            COALESCE("transfer_id"::text, ''),
+            "balance_after",
+            to_char("created_at", 'YYYY-MM-DD"T"HH24:MI:SS.US'),
+            COALESCE("external_reference", '')
+        ), 'UTF8')
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Chain the existing entries of each account in the order they were made.
+DO $$
+DECLARE
+    e record;
+    previous bytea;
+    current_account bigint;
+BEGIN
+    FOR e IN SELECT * FROM "entries" ORDER BY "account_id", "id" LOOP
+        IF current_account IS DISTINCT FROM e."account_id" THEN
+            current_account := e."account_id";
+            previous := NULL;
+        END IF;
+
+        UPDATE "entries"
+        SET
+            "prev_hash" = previous,
+            "hash" = entry_hash(previous, e."account_id", e."amount", e."type", e."transfer_id", e."balance_after", e."created_at", e."external_reference")
+        WHERE "id" = e."id"
+        RETURNING "hash" INTO previous;
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE "entries" ALTER COLUMN "hash" SET NOT NULL;
+
+CREATE INDEX ON "entries" ("account_id", "id");
@@ -0,0 +1,22 @@
+CREATE TABLE "transfer_limits" (
+    "scope" varchar NOT NULL,
+    "key" varchar NOT NULL,
+    "per_transfer" bigint,
+    "daily_amount" bigint,
+    "monthly_amount" bigint,
+    "hourly_count" bigint,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("scope", "key")
+);
+
+COMMENT ON COLUMN "transfer_limits"."scope" IS 'role or account';
+COMMENT ON COLUMN "transfer_limits"."key" IS 'role name or account id';
+COMMENT ON TABLE "transfer_limits" IS 'a null limit is unlimited for a role and falls back to the role for an account';
+
+INSERT INTO "transfer_limits" ("scope", "key", "per_transfer", "daily_amount", "monthly_amount", "hourly_count")
+VALUES
+    ('role', 'depositor', 1000000, 5000000, 20000000, 20),
+    ('role', 'banker', 1000000, 5000000, 20000000, 20),
+    ('role', 'admin', 1000000, 5000000, 20000000, 20);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
@@ -0,0 +1 @@
+COMMENT ON COLUMN "login_failures"."scope" IS 'username or client_ip';
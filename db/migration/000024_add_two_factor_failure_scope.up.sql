@@ -0,0 +1 @@
+COMMENT ON COLUMN "login_failures"."scope" IS 'username, client_ip or two_factor';
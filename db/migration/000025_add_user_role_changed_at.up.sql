@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN "role_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z';
+
+COMMENT ON COLUMN "users"."role_changed_at" IS 'tokens issued before are rejected since they carry the old role';
@@ -0,0 +1,69 @@
+CREATE TABLE "roles" (
+    "name" varchar PRIMARY KEY,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+    "role" varchar NOT NULL,
+    "permission" varchar NOT NULL,
+    PRIMARY KEY ("role", "permission")
+);
+
+ALTER TABLE "role_permissions" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE;
+
+COMMENT ON COLUMN "role_permissions"."permission" IS 'resource:action:scope, e.g. accounts:read:any';
+
+INSERT INTO "roles" ("name") VALUES ('depositor'), ('banker'), ('admin');
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+    ('admin', 'accounts:create:own'),
+    ('admin', 'accounts:read:own'),
+    ('admin', 'accounts:close:own'),
+    ('admin', 'transfers:create:own'),
+    ('admin', 'transfers:read:own'),
+    ('admin', 'scheduled_transfers:manage:own'),
+    ('admin', 'statements:read:own'),
+    ('admin', 'sessions:manage:own'),
+    ('admin', 'two_factor:manage:own'),
+    ('admin', 'users:update:own'),
+    ('admin', 'roles:read:any'),
+    ('admin', 'roles:assign:any'),
+    ('banker', 'accounts:create:own'),
+    ('banker', 'accounts:read:own'),
+    ('banker', 'accounts:close:own'),
+    ('banker', 'transfers:create:own'),
+    ('banker', 'transfers:read:own'),
+    ('banker', 'scheduled_transfers:manage:own'),
+    ('banker', 'statements:read:own'),
+    ('banker', 'sessions:manage:own'),
+    ('banker', 'two_factor:manage:own'),
+    ('banker', 'users:update:own'),
+    ('banker', 'accounts:read:any'),
+    ('banker', 'accounts:deposit:any'),
+    ('banker', 'accounts:withdraw:any'),
+    ('banker', 'transfers:read:any'),
+    ('banker', 'statements:read:any'),
+    ('banker', 'exchange_rates:write:any'),
+    ('banker', 'users:update:any'),
+    ('banker', 'users:read:any'),
+    ('banker', 'users:freeze:any'),
+    ('banker', 'accounts:freeze:any'),
+    ('banker', 'accounts:reactivate:any'),
+    ('banker', 'accounts:close:any'),
+    ('banker', 'accounts:adjust:any'),
+    ('banker', 'audit_events:read:any'),
+    ('banker', 'ledger:verify:any'),
+    ('banker', 'transfer_limits:read:any'),
+    ('banker', 'transfer_limits:write:any'),
+    ('depositor', 'accounts:create:own'),
+    ('depositor', 'accounts:read:own'),
+    ('depositor', 'accounts:close:own'),
+    ('depositor', 'transfers:create:own'),
+    ('depositor', 'transfers:read:own'),
+    ('depositor', 'scheduled_transfers:manage:own'),
+    ('depositor', 'statements:read:own'),
+    ('depositor', 'sessions:manage:own'),
+    ('depositor', 'two_factor:manage:own'),
+    ('depositor', 'users:update:own');
+
+ALTER TABLE "users" ADD FOREIGN KEY ("role") REFERENCES "roles" ("name");
@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN "unfrozen_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z';
+
+COMMENT ON COLUMN "users"."unfrozen_at" IS 'tokens issued before are rejected since frozen_at is cleared on unfreeze';
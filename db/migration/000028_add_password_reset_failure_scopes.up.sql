@@ -0,0 +1 @@
+COMMENT ON COLUMN "login_failures"."scope" IS 'username, client_ip, two_factor, password_reset_email or password_reset_client_ip';
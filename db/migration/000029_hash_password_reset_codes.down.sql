@@ -0,0 +1,6 @@
+-- Codes cannot be recovered from their hashes, so pending resets are voided.
+UPDATE "password_resets" SET "is_used" = true WHERE "is_used" = false;
+
+ALTER TABLE "password_resets" RENAME COLUMN "secret_code_hash" TO "secret_code";
+
+COMMENT ON COLUMN "password_resets"."secret_code" IS NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE "password_resets" RENAME COLUMN "secret_code" TO "secret_code_hash";
+
+UPDATE "password_resets" SET "secret_code_hash" = encode(sha256(convert_to("secret_code_hash", 'UTF8')), 'hex');
+
+COMMENT ON COLUMN "password_resets"."secret_code_hash" IS 'sha256 of the code, which is only sent by email';
@@ -0,0 +1 @@
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_amount_check";
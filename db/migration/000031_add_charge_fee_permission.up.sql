@@ -0,0 +1,2 @@
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+    ('banker', 'accounts:charge_fee:any');
@@ -12,8 +12,10 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	db "github.com/starjardin/simplebank/db/sqlc"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -57,258 +59,1664 @@ func (mr *MockStoreMockRecorder) AddAccountBalance(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), ctx, arg)
 }
 
+// AddSystemAccountBalance mocks base method.
+func (m *MockStore) AddSystemAccountBalance(ctx context.Context, arg db.AddSystemAccountBalanceParams) (db.SystemAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSystemAccountBalance", ctx, arg)
+	ret0, _ := ret[0].(db.SystemAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSystemAccountBalance indicates an expected call of AddSystemAccountBalance.
+func (mr *MockStoreMockRecorder) AddSystemAccountBalance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSystemAccountBalance", reflect.TypeOf((*MockStore)(nil).AddSystemAccountBalance), ctx, arg)
+}
+
+// AdjustBalanceTx mocks base method.
+func (m *MockStore) AdjustBalanceTx(ctx context.Context, arg db.AdjustBalanceTxParams) (db.AdjustBalanceTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBalanceTx", ctx, arg)
+	ret0, _ := ret[0].(db.AdjustBalanceTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustBalanceTx indicates an expected call of AdjustBalanceTx.
+func (mr *MockStoreMockRecorder) AdjustBalanceTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBalanceTx", reflect.TypeOf((*MockStore)(nil).AdjustBalanceTx), ctx, arg)
+}
+
+// AssignRoleTx mocks base method.
+func (m *MockStore) AssignRoleTx(ctx context.Context, arg db.AssignRoleTxParams) (db.AssignRoleTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRoleTx", ctx, arg)
+	ret0, _ := ret[0].(db.AssignRoleTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignRoleTx indicates an expected call of AssignRoleTx.
+func (mr *MockStoreMockRecorder) AssignRoleTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleTx", reflect.TypeOf((*MockStore)(nil).AssignRoleTx), ctx, arg)
+}
+
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSession", ctx, id)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), ctx, id)
+}
+
+// BlockSessionFamily mocks base method.
+func (m *MockStore) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionFamily", ctx, familyID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSessionFamily indicates an expected call of BlockSessionFamily.
+func (mr *MockStoreMockRecorder) BlockSessionFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionFamily", reflect.TypeOf((*MockStore)(nil).BlockSessionFamily), ctx, familyID)
+}
+
+// BlockUserSessionFamilies mocks base method.
+func (m *MockStore) BlockUserSessionFamilies(ctx context.Context, username string) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSessionFamilies", ctx, username)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockUserSessionFamilies indicates an expected call of BlockUserSessionFamilies.
+func (mr *MockStoreMockRecorder) BlockUserSessionFamilies(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessionFamilies", reflect.TypeOf((*MockStore)(nil).BlockUserSessionFamilies), ctx, username)
+}
+
+// BlockUserSessions mocks base method.
+func (m *MockStore) BlockUserSessions(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSessions", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockStoreMockRecorder) BlockUserSessions(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockStore)(nil).BlockUserSessions), ctx, username)
+}
+
+// ChargeFeeTx mocks base method.
+func (m *MockStore) ChargeFeeTx(ctx context.Context, arg db.ChargeFeeTxParams) (db.ChargeFeeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeFeeTx", ctx, arg)
+	ret0, _ := ret[0].(db.ChargeFeeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeFeeTx indicates an expected call of ChargeFeeTx.
+func (mr *MockStoreMockRecorder) ChargeFeeTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeFeeTx", reflect.TypeOf((*MockStore)(nil).ChargeFeeTx), ctx, arg)
+}
+
+// ConfirmTOTPCredential mocks base method.
+func (m *MockStore) ConfirmTOTPCredential(ctx context.Context, username string) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPCredential", ctx, username)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPCredential indicates an expected call of ConfirmTOTPCredential.
+func (mr *MockStoreMockRecorder) ConfirmTOTPCredential(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPCredential", reflect.TypeOf((*MockStore)(nil).ConfirmTOTPCredential), ctx, username)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
+}
+
+// CreateAccountTx mocks base method.
+func (m *MockStore) CreateAccountTx(ctx context.Context, arg db.CreateAccountTxParams) (db.CreateAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountTx indicates an expected call of CreateAccountTx.
+func (mr *MockStoreMockRecorder) CreateAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountTx", reflect.TypeOf((*MockStore)(nil).CreateAccountTx), ctx, arg)
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockStore) CreateAuditEvent(ctx context.Context, arg db.CreateAuditEventParams) (db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", ctx, arg)
+	ret0, _ := ret[0].(db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockStoreMockRecorder) CreateAuditEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockStore)(nil).CreateAuditEvent), ctx, arg)
+}
+
+// CreateEntry mocks base method.
+func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+}
+
+// CreateExchangeRate mocks base method.
+func (m *MockStore) CreateExchangeRate(ctx context.Context, arg db.CreateExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExchangeRate indicates an expected call of CreateExchangeRate.
+func (mr *MockStoreMockRecorder) CreateExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRate", reflect.TypeOf((*MockStore)(nil).CreateExchangeRate), ctx, arg)
+}
+
+// CreateExchangeRatesTx mocks base method.
+func (m *MockStore) CreateExchangeRatesTx(ctx context.Context, arg db.CreateExchangeRatesTxParams) (db.CreateExchangeRatesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchangeRatesTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateExchangeRatesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExchangeRatesTx indicates an expected call of CreateExchangeRatesTx.
+func (mr *MockStoreMockRecorder) CreateExchangeRatesTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRatesTx", reflect.TypeOf((*MockStore)(nil).CreateExchangeRatesTx), ctx, arg)
+}
+
+// CreateExchangeTransfer mocks base method.
+func (m *MockStore) CreateExchangeTransfer(ctx context.Context, arg db.CreateExchangeTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchangeTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExchangeTransfer indicates an expected call of CreateExchangeTransfer.
+func (mr *MockStoreMockRecorder) CreateExchangeTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeTransfer", reflect.TypeOf((*MockStore)(nil).CreateExchangeTransfer), ctx, arg)
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockStore) CreateIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockStoreMockRecorder) CreateIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockStore)(nil).CreateIdempotencyKey), ctx, arg)
+}
+
+// CreateJournal mocks base method.
+func (m *MockStore) CreateJournal(ctx context.Context, arg db.CreateJournalParams) (db.Journal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournal", ctx, arg)
+	ret0, _ := ret[0].(db.Journal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJournal indicates an expected call of CreateJournal.
+func (mr *MockStoreMockRecorder) CreateJournal(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournal", reflect.TypeOf((*MockStore)(nil).CreateJournal), ctx, arg)
+}
+
+// CreatePasswordHistory mocks base method.
+func (m *MockStore) CreatePasswordHistory(ctx context.Context, arg db.CreatePasswordHistoryParams) (db.PasswordHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordHistory", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordHistory indicates an expected call of CreatePasswordHistory.
+func (mr *MockStoreMockRecorder) CreatePasswordHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordHistory", reflect.TypeOf((*MockStore)(nil).CreatePasswordHistory), ctx, arg)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockStore) CreatePasswordReset(ctx context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockStoreMockRecorder) CreatePasswordReset(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockStore)(nil).CreatePasswordReset), ctx, arg)
+}
+
+// CreatePosting mocks base method.
+func (m *MockStore) CreatePosting(ctx context.Context, arg db.CreatePostingParams) (db.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePosting", ctx, arg)
+	ret0, _ := ret[0].(db.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePosting indicates an expected call of CreatePosting.
+func (mr *MockStoreMockRecorder) CreatePosting(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePosting", reflect.TypeOf((*MockStore)(nil).CreatePosting), ctx, arg)
+}
+
+// CreateRecoveryCode mocks base method.
+func (m *MockStore) CreateRecoveryCode(ctx context.Context, arg db.CreateRecoveryCodeParams) (db.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecoveryCode indicates an expected call of CreateRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateRecoveryCode), ctx, arg)
+}
+
+// CreateScheduledTransfer mocks base method.
+func (m *MockStore) CreateScheduledTransfer(ctx context.Context, arg db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransfer indicates an expected call of CreateScheduledTransfer.
+func (mr *MockStoreMockRecorder) CreateScheduledTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransfer), ctx, arg)
+}
+
+// CreateScheduledTransferRun mocks base method.
+func (m *MockStore) CreateScheduledTransferRun(ctx context.Context, arg db.CreateScheduledTransferRunParams) (db.ScheduledTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransferRun", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransferRun indicates an expected call of CreateScheduledTransferRun.
+func (mr *MockStoreMockRecorder) CreateScheduledTransferRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransferRun", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransferRun), ctx, arg)
+}
+
+// CreateScheduledTransferTx mocks base method.
+func (m *MockStore) CreateScheduledTransferTx(ctx context.Context, arg db.CreateScheduledTransferTxParams) (db.CreateScheduledTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateScheduledTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransferTx indicates an expected call of CreateScheduledTransferTx.
+func (mr *MockStoreMockRecorder) CreateScheduledTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransferTx", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransferTx), ctx, arg)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+}
+
+// CreateSessionTx mocks base method.
+func (m *MockStore) CreateSessionTx(ctx context.Context, arg db.CreateSessionTxParams) (db.CreateSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSessionTx indicates an expected call of CreateSessionTx.
+func (mr *MockStoreMockRecorder) CreateSessionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSessionTx", reflect.TypeOf((*MockStore)(nil).CreateSessionTx), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+}
+
+// CreateTransferQuote mocks base method.
+func (m *MockStore) CreateTransferQuote(ctx context.Context, arg db.CreateTransferQuoteParams) (db.TransferQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferQuote", ctx, arg)
+	ret0, _ := ret[0].(db.TransferQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferQuote indicates an expected call of CreateTransferQuote.
+func (mr *MockStoreMockRecorder) CreateTransferQuote(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferQuote", reflect.TypeOf((*MockStore)(nil).CreateTransferQuote), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
+}
+
+// CreateVerifyEmail mocks base method.
+func (m *MockStore) CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerifyEmail", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerifyEmail indicates an expected call of CreateVerifyEmail.
+func (mr *MockStoreMockRecorder) CreateVerifyEmail(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), ctx, arg)
+}
+
+// DeactivateAccountScheduledTransfers mocks base method.
+func (m *MockStore) DeactivateAccountScheduledTransfers(ctx context.Context, fromAccountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateAccountScheduledTransfers", ctx, fromAccountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateAccountScheduledTransfers indicates an expected call of DeactivateAccountScheduledTransfers.
+func (mr *MockStoreMockRecorder) DeactivateAccountScheduledTransfers(ctx, fromAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateAccountScheduledTransfers", reflect.TypeOf((*MockStore)(nil).DeactivateAccountScheduledTransfers), ctx, fromAccountID)
+}
+
+// DeactivateOwnerScheduledTransfers mocks base method.
+func (m *MockStore) DeactivateOwnerScheduledTransfers(ctx context.Context, owner string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateOwnerScheduledTransfers", ctx, owner)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateOwnerScheduledTransfers indicates an expected call of DeactivateOwnerScheduledTransfers.
+func (mr *MockStoreMockRecorder) DeactivateOwnerScheduledTransfers(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOwnerScheduledTransfers", reflect.TypeOf((*MockStore)(nil).DeactivateOwnerScheduledTransfers), ctx, owner)
+}
+
+// DeleteExpiredRevokedTokens mocks base method.
+func (m *MockStore) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRevokedTokens", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRevokedTokens indicates an expected call of DeleteExpiredRevokedTokens.
+func (mr *MockStoreMockRecorder) DeleteExpiredRevokedTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRevokedTokens", reflect.TypeOf((*MockStore)(nil).DeleteExpiredRevokedTokens), ctx)
+}
+
+// DeleteLoginFailure mocks base method.
+func (m *MockStore) DeleteLoginFailure(ctx context.Context, arg db.DeleteLoginFailureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginFailure", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginFailure indicates an expected call of DeleteLoginFailure.
+func (mr *MockStoreMockRecorder) DeleteLoginFailure(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginFailure", reflect.TypeOf((*MockStore)(nil).DeleteLoginFailure), ctx, arg)
+}
+
+// DeleteRecoveryCodes mocks base method.
+func (m *MockStore) DeleteRecoveryCodes(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteRecoveryCodes(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteRecoveryCodes), ctx, username)
+}
+
+// DeleteScheduledTransfer mocks base method.
+func (m *MockStore) DeleteScheduledTransfer(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledTransfer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledTransfer indicates an expected call of DeleteScheduledTransfer.
+func (mr *MockStoreMockRecorder) DeleteScheduledTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledTransfer", reflect.TypeOf((*MockStore)(nil).DeleteScheduledTransfer), ctx, id)
+}
+
+// DeleteScheduledTransferTx mocks base method.
+func (m *MockStore) DeleteScheduledTransferTx(ctx context.Context, arg db.DeleteScheduledTransferTxParams) (db.DeleteScheduledTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.DeleteScheduledTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteScheduledTransferTx indicates an expected call of DeleteScheduledTransferTx.
+func (mr *MockStoreMockRecorder) DeleteScheduledTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledTransferTx", reflect.TypeOf((*MockStore)(nil).DeleteScheduledTransferTx), ctx, arg)
+}
+
+// DeleteTOTPCredential mocks base method.
+func (m *MockStore) DeleteTOTPCredential(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTPCredential", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTPCredential indicates an expected call of DeleteTOTPCredential.
+func (mr *MockStoreMockRecorder) DeleteTOTPCredential(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTPCredential", reflect.TypeOf((*MockStore)(nil).DeleteTOTPCredential), ctx, username)
+}
+
+// DepositTx mocks base method.
+func (m *MockStore) DepositTx(ctx context.Context, arg db.DepositTxParams) (db.DepositTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepositTx", ctx, arg)
+	ret0, _ := ret[0].(db.DepositTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DepositTx indicates an expected call of DepositTx.
+func (mr *MockStoreMockRecorder) DepositTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositTx", reflect.TypeOf((*MockStore)(nil).DepositTx), ctx, arg)
+}
+
+// DisableTwoFactorTx mocks base method.
+func (m *MockStore) DisableTwoFactorTx(ctx context.Context, arg db.DisableTwoFactorTxParams) (db.DisableTwoFactorTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactorTx", ctx, arg)
+	ret0, _ := ret[0].(db.DisableTwoFactorTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableTwoFactorTx indicates an expected call of DisableTwoFactorTx.
+func (mr *MockStoreMockRecorder) DisableTwoFactorTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactorTx", reflect.TypeOf((*MockStore)(nil).DisableTwoFactorTx), ctx, arg)
+}
+
+// DispatchScheduledTransfersTx mocks base method.
+func (m *MockStore) DispatchScheduledTransfersTx(ctx context.Context, arg db.DispatchScheduledTransfersTxParams) (db.DispatchScheduledTransfersTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchScheduledTransfersTx", ctx, arg)
+	ret0, _ := ret[0].(db.DispatchScheduledTransfersTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchScheduledTransfersTx indicates an expected call of DispatchScheduledTransfersTx.
+func (mr *MockStoreMockRecorder) DispatchScheduledTransfersTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchScheduledTransfersTx", reflect.TypeOf((*MockStore)(nil).DispatchScheduledTransfersTx), ctx, arg)
+}
+
+// EnableTwoFactorTx mocks base method.
+func (m *MockStore) EnableTwoFactorTx(ctx context.Context, arg db.EnableTwoFactorTxParams) (db.EnableTwoFactorTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactorTx", ctx, arg)
+	ret0, _ := ret[0].(db.EnableTwoFactorTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTwoFactorTx indicates an expected call of EnableTwoFactorTx.
+func (mr *MockStoreMockRecorder) EnableTwoFactorTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactorTx", reflect.TypeOf((*MockStore)(nil).EnableTwoFactorTx), ctx, arg)
+}
+
+// ExchangeTransferTx mocks base method.
+func (m *MockStore) ExchangeTransferTx(ctx context.Context, arg db.ExchangeTransferTxParams) (db.ExchangeTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeTransferTx indicates an expected call of ExchangeTransferTx.
+func (mr *MockStoreMockRecorder) ExchangeTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeTransferTx", reflect.TypeOf((*MockStore)(nil).ExchangeTransferTx), ctx, arg)
+}
+
+// FreezeUserTx mocks base method.
+func (m *MockStore) FreezeUserTx(ctx context.Context, arg db.FreezeUserTxParams) (db.FreezeUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeUserTx", ctx, arg)
+	ret0, _ := ret[0].(db.FreezeUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeUserTx indicates an expected call of FreezeUserTx.
+func (mr *MockStoreMockRecorder) FreezeUserTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeUserTx", reflect.TypeOf((*MockStore)(nil).FreezeUserTx), ctx, arg)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+}
+
+// GetAccountBalanceAt mocks base method.
+func (m *MockStore) GetAccountBalanceAt(ctx context.Context, arg db.GetAccountBalanceAtParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalanceAt", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalanceAt indicates an expected call of GetAccountBalanceAt.
+func (mr *MockStoreMockRecorder) GetAccountBalanceAt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceAt", reflect.TypeOf((*MockStore)(nil).GetAccountBalanceAt), ctx, arg)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+}
+
+// GetAccountTransferLimits mocks base method.
+func (m *MockStore) GetAccountTransferLimits(ctx context.Context, account db.Account) (db.AccountTransferLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransferLimits", ctx, account)
+	ret0, _ := ret[0].(db.AccountTransferLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransferLimits indicates an expected call of GetAccountTransferLimits.
+func (mr *MockStoreMockRecorder) GetAccountTransferLimits(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransferLimits", reflect.TypeOf((*MockStore)(nil).GetAccountTransferLimits), ctx, account)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockStore) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockStoreMockRecorder) GetIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetIdempotencyKey), ctx, arg)
+}
+
+// GetJournal mocks base method.
+func (m *MockStore) GetJournal(ctx context.Context, id int64) (db.Journal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournal", ctx, id)
+	ret0, _ := ret[0].(db.Journal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournal indicates an expected call of GetJournal.
+func (mr *MockStoreMockRecorder) GetJournal(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournal", reflect.TypeOf((*MockStore)(nil).GetJournal), ctx, id)
+}
+
+// GetLatestExchangeRate mocks base method.
+func (m *MockStore) GetLatestExchangeRate(ctx context.Context, arg db.GetLatestExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestExchangeRate indicates an expected call of GetLatestExchangeRate.
+func (mr *MockStoreMockRecorder) GetLatestExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestExchangeRate", reflect.TypeOf((*MockStore)(nil).GetLatestExchangeRate), ctx, arg)
+}
+
+// GetLoginFailure mocks base method.
+func (m *MockStore) GetLoginFailure(ctx context.Context, arg db.GetLoginFailureParams) (db.LoginFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginFailure", ctx, arg)
+	ret0, _ := ret[0].(db.LoginFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginFailure indicates an expected call of GetLoginFailure.
+func (mr *MockStoreMockRecorder) GetLoginFailure(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginFailure", reflect.TypeOf((*MockStore)(nil).GetLoginFailure), ctx, arg)
+}
+
+// GetMismatchedAccountBalance mocks base method.
+func (m *MockStore) GetMismatchedAccountBalance(ctx context.Context, accountID pgtype.Int8) (db.GetMismatchedAccountBalanceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMismatchedAccountBalance", ctx, accountID)
+	ret0, _ := ret[0].(db.GetMismatchedAccountBalanceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMismatchedAccountBalance indicates an expected call of GetMismatchedAccountBalance.
+func (mr *MockStoreMockRecorder) GetMismatchedAccountBalance(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMismatchedAccountBalance", reflect.TypeOf((*MockStore)(nil).GetMismatchedAccountBalance), ctx, accountID)
+}
+
+// GetMismatchedTransferEntry mocks base method.
+func (m *MockStore) GetMismatchedTransferEntry(ctx context.Context, accountID pgtype.Int8) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMismatchedTransferEntry", ctx, accountID)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMismatchedTransferEntry indicates an expected call of GetMismatchedTransferEntry.
+func (mr *MockStoreMockRecorder) GetMismatchedTransferEntry(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMismatchedTransferEntry", reflect.TypeOf((*MockStore)(nil).GetMismatchedTransferEntry), ctx, accountID)
+}
+
+// GetOwnerTransferUsage mocks base method.
+func (m *MockStore) GetOwnerTransferUsage(ctx context.Context, owner string) (db.GetOwnerTransferUsageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerTransferUsage", ctx, owner)
+	ret0, _ := ret[0].(db.GetOwnerTransferUsageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerTransferUsage indicates an expected call of GetOwnerTransferUsage.
+func (mr *MockStoreMockRecorder) GetOwnerTransferUsage(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerTransferUsage", reflect.TypeOf((*MockStore)(nil).GetOwnerTransferUsage), ctx, owner)
+}
+
+// GetRolePermissions mocks base method.
+func (m *MockStore) GetRolePermissions(ctx context.Context) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", ctx)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockStoreMockRecorder) GetRolePermissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockStore)(nil).GetRolePermissions), ctx)
+}
+
+// GetScheduledTransfer mocks base method.
+func (m *MockStore) GetScheduledTransfer(ctx context.Context, id int64) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransfer", ctx, id)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransfer indicates an expected call of GetScheduledTransfer.
+func (mr *MockStoreMockRecorder) GetScheduledTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransfer", reflect.TypeOf((*MockStore)(nil).GetScheduledTransfer), ctx, id)
+}
+
+// GetScheduledTransferForUpdate mocks base method.
+func (m *MockStore) GetScheduledTransferForUpdate(ctx context.Context, id int64) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransferForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransferForUpdate indicates an expected call of GetScheduledTransferForUpdate.
+func (mr *MockStoreMockRecorder) GetScheduledTransferForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransferForUpdate", reflect.TypeOf((*MockStore)(nil).GetScheduledTransferForUpdate), ctx, id)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+}
+
+// GetSessionForUpdate mocks base method.
+func (m *MockStore) GetSessionForUpdate(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionForUpdate indicates an expected call of GetSessionForUpdate.
+func (mr *MockStoreMockRecorder) GetSessionForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionForUpdate", reflect.TypeOf((*MockStore)(nil).GetSessionForUpdate), ctx, id)
+}
+
+// GetSystemAccount mocks base method.
+func (m *MockStore) GetSystemAccount(ctx context.Context, arg db.GetSystemAccountParams) (db.SystemAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemAccount", ctx, arg)
+	ret0, _ := ret[0].(db.SystemAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemAccount indicates an expected call of GetSystemAccount.
+func (mr *MockStoreMockRecorder) GetSystemAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAccount", reflect.TypeOf((*MockStore)(nil).GetSystemAccount), ctx, arg)
+}
+
+// GetSystemAccountForUpdate mocks base method.
+func (m *MockStore) GetSystemAccountForUpdate(ctx context.Context, arg db.GetSystemAccountForUpdateParams) (db.SystemAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemAccountForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.SystemAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemAccountForUpdate indicates an expected call of GetSystemAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetSystemAccountForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetSystemAccountForUpdate), ctx, arg)
+}
+
+// GetTOTPCredential mocks base method.
+func (m *MockStore) GetTOTPCredential(ctx context.Context, username string) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTPCredential", ctx, username)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTPCredential indicates an expected call of GetTOTPCredential.
+func (mr *MockStoreMockRecorder) GetTOTPCredential(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTPCredential", reflect.TypeOf((*MockStore)(nil).GetTOTPCredential), ctx, username)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+}
+
+// GetTransferLimit mocks base method.
+func (m *MockStore) GetTransferLimit(ctx context.Context, arg db.GetTransferLimitParams) (db.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(db.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferLimit indicates an expected call of GetTransferLimit.
+func (mr *MockStoreMockRecorder) GetTransferLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferLimit", reflect.TypeOf((*MockStore)(nil).GetTransferLimit), ctx, arg)
+}
+
+// GetTransferLimitForUpdate mocks base method.
+func (m *MockStore) GetTransferLimitForUpdate(ctx context.Context, arg db.GetTransferLimitForUpdateParams) (db.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferLimitForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferLimitForUpdate indicates an expected call of GetTransferLimitForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferLimitForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferLimitForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferLimitForUpdate), ctx, arg)
+}
+
+// GetTransferQuote mocks base method.
+func (m *MockStore) GetTransferQuote(ctx context.Context, id uuid.UUID) (db.TransferQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferQuote", ctx, id)
+	ret0, _ := ret[0].(db.TransferQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferQuote indicates an expected call of GetTransferQuote.
+func (mr *MockStoreMockRecorder) GetTransferQuote(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferQuote", reflect.TypeOf((*MockStore)(nil).GetTransferQuote), ctx, id)
+}
+
+// GetTransferQuoteForUpdate mocks base method.
+func (m *MockStore) GetTransferQuoteForUpdate(ctx context.Context, id uuid.UUID) (db.TransferQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferQuoteForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.TransferQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferQuoteForUpdate indicates an expected call of GetTransferQuoteForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferQuoteForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferQuoteForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferQuoteForUpdate), ctx, id)
+}
+
+// GetTransferUsage mocks base method.
+func (m *MockStore) GetTransferUsage(ctx context.Context, fromAccountID int64) (db.GetTransferUsageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferUsage", ctx, fromAccountID)
+	ret0, _ := ret[0].(db.GetTransferUsageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferUsage indicates an expected call of GetTransferUsage.
+func (mr *MockStoreMockRecorder) GetTransferUsage(ctx, fromAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferUsage", reflect.TypeOf((*MockStore)(nil).GetTransferUsage), ctx, fromAccountID)
+}
+
+// GetTransferWithoutEntries mocks base method.
+func (m *MockStore) GetTransferWithoutEntries(ctx context.Context, accountID pgtype.Int8) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferWithoutEntries", ctx, accountID)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferWithoutEntries indicates an expected call of GetTransferWithoutEntries.
+func (mr *MockStoreMockRecorder) GetTransferWithoutEntries(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferWithoutEntries", reflect.TypeOf((*MockStore)(nil).GetTransferWithoutEntries), ctx, accountID)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserForUpdate mocks base method.
+func (m *MockStore) GetUserForUpdate(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserForUpdate", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserForUpdate indicates an expected call of GetUserForUpdate.
+func (mr *MockStoreMockRecorder) GetUserForUpdate(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockStore)(nil).GetUserForUpdate), ctx, username)
+}
+
+// GetUserPasswordChangeAt mocks base method.
+func (m *MockStore) GetUserPasswordChangeAt(ctx context.Context, username string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPasswordChangeAt", ctx, username)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPasswordChangeAt indicates an expected call of GetUserPasswordChangeAt.
+func (mr *MockStoreMockRecorder) GetUserPasswordChangeAt(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasswordChangeAt", reflect.TypeOf((*MockStore)(nil).GetUserPasswordChangeAt), ctx, username)
+}
+
+// InvalidatePasswordResets mocks base method.
+func (m *MockStore) InvalidatePasswordResets(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResets", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResets indicates an expected call of InvalidatePasswordResets.
+func (mr *MockStoreMockRecorder) InvalidatePasswordResets(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResets", reflect.TypeOf((*MockStore)(nil).InvalidatePasswordResets), ctx, username)
+}
+
+// IsSessionFamilyBlocked mocks base method.
+func (m *MockStore) IsSessionFamilyBlocked(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionFamilyBlocked", ctx, familyID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionFamilyBlocked indicates an expected call of IsSessionFamilyBlocked.
+func (mr *MockStoreMockRecorder) IsSessionFamilyBlocked(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionFamilyBlocked", reflect.TypeOf((*MockStore)(nil).IsSessionFamilyBlocked), ctx, familyID)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockStore) IsTokenRevoked(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockStoreMockRecorder) IsTokenRevoked(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockStore)(nil).IsTokenRevoked), ctx, id)
+}
+
+// ListAccountLedgerMismatches mocks base method.
+func (m *MockStore) ListAccountLedgerMismatches(ctx context.Context) ([]db.ListAccountLedgerMismatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountLedgerMismatches", ctx)
+	ret0, _ := ret[0].([]db.ListAccountLedgerMismatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountLedgerMismatches indicates an expected call of ListAccountLedgerMismatches.
+func (mr *MockStoreMockRecorder) ListAccountLedgerMismatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountLedgerMismatches", reflect.TypeOf((*MockStore)(nil).ListAccountLedgerMismatches), ctx)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+}
+
+// ListActiveSessions mocks base method.
+func (m *MockStore) ListActiveSessions(ctx context.Context, username string) ([]db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", ctx, username)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockStoreMockRecorder) ListActiveSessions(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*MockStore)(nil).ListActiveSessions), ctx, username)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockStore) ListAuditEvents(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", ctx, arg)
+	ret0, _ := ret[0].([]db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockStoreMockRecorder) ListAuditEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockStore)(nil).ListAuditEvents), ctx, arg)
+}
+
+// ListDueScheduledTransfers mocks base method.
+func (m *MockStore) ListDueScheduledTransfers(ctx context.Context, arg db.ListDueScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduledTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduledTransfers indicates an expected call of ListDueScheduledTransfers.
+func (mr *MockStoreMockRecorder) ListDueScheduledTransfers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ListDueScheduledTransfers), ctx, arg)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+}
+
+// ListEntriesForVerification mocks base method.
+func (m *MockStore) ListEntriesForVerification(ctx context.Context, arg db.ListEntriesForVerificationParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntriesForVerification", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntriesForVerification indicates an expected call of ListEntriesForVerification.
+func (mr *MockStoreMockRecorder) ListEntriesForVerification(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntriesForVerification", reflect.TypeOf((*MockStore)(nil).ListEntriesForVerification), ctx, arg)
+}
+
+// ListJournalPostings mocks base method.
+func (m *MockStore) ListJournalPostings(ctx context.Context, journalID int64) ([]db.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJournalPostings", ctx, journalID)
+	ret0, _ := ret[0].([]db.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJournalPostings indicates an expected call of ListJournalPostings.
+func (mr *MockStoreMockRecorder) ListJournalPostings(ctx, journalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJournalPostings", reflect.TypeOf((*MockStore)(nil).ListJournalPostings), ctx, journalID)
+}
+
+// ListPasswordHistory mocks base method.
+func (m *MockStore) ListPasswordHistory(ctx context.Context, arg db.ListPasswordHistoryParams) ([]db.PasswordHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPasswordHistory", ctx, arg)
+	ret0, _ := ret[0].([]db.PasswordHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPasswordHistory indicates an expected call of ListPasswordHistory.
+func (mr *MockStoreMockRecorder) ListPasswordHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasswordHistory", reflect.TypeOf((*MockStore)(nil).ListPasswordHistory), ctx, arg)
+}
+
+// ListRolePermissions mocks base method.
+func (m *MockStore) ListRolePermissions(ctx context.Context) ([]db.ListRolePermissionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRolePermissions", ctx)
+	ret0, _ := ret[0].([]db.ListRolePermissionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRolePermissions indicates an expected call of ListRolePermissions.
+func (mr *MockStoreMockRecorder) ListRolePermissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockStore)(nil).ListRolePermissions), ctx)
+}
+
+// ListScheduledTransferRuns mocks base method.
+func (m *MockStore) ListScheduledTransferRuns(ctx context.Context, arg db.ListScheduledTransferRunsParams) ([]db.ScheduledTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransferRuns", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransferRuns indicates an expected call of ListScheduledTransferRuns.
+func (mr *MockStoreMockRecorder) ListScheduledTransferRuns(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransferRuns", reflect.TypeOf((*MockStore)(nil).ListScheduledTransferRuns), ctx, arg)
+}
+
+// ListScheduledTransfers mocks base method.
+func (m *MockStore) ListScheduledTransfers(ctx context.Context, arg db.ListScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransfers indicates an expected call of ListScheduledTransfers.
+func (mr *MockStoreMockRecorder) ListScheduledTransfers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ListScheduledTransfers), ctx, arg)
+}
+
+// ListStatementEntries mocks base method.
+func (m *MockStore) ListStatementEntries(ctx context.Context, arg db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatementEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.ListStatementEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatementEntries indicates an expected call of ListStatementEntries.
+func (mr *MockStoreMockRecorder) ListStatementEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementEntries", reflect.TypeOf((*MockStore)(nil).ListStatementEntries), ctx, arg)
+}
+
+// ListSystemAccountLedgerMismatches mocks base method.
+func (m *MockStore) ListSystemAccountLedgerMismatches(ctx context.Context) ([]db.ListSystemAccountLedgerMismatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSystemAccountLedgerMismatches", ctx)
+	ret0, _ := ret[0].([]db.ListSystemAccountLedgerMismatchesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
+// ListSystemAccountLedgerMismatches indicates an expected call of ListSystemAccountLedgerMismatches.
+func (mr *MockStoreMockRecorder) ListSystemAccountLedgerMismatches(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSystemAccountLedgerMismatches", reflect.TypeOf((*MockStore)(nil).ListSystemAccountLedgerMismatches), ctx)
 }
 
-// CreateEntry mocks base method.
-func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
+// ListSystemAccounts mocks base method.
+func (m *MockStore) ListSystemAccounts(ctx context.Context) ([]db.SystemAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "ListSystemAccounts", ctx)
+	ret0, _ := ret[0].([]db.SystemAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEntry indicates an expected call of CreateEntry.
-func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
+// ListSystemAccounts indicates an expected call of ListSystemAccounts.
+func (mr *MockStoreMockRecorder) ListSystemAccounts(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSystemAccounts", reflect.TypeOf((*MockStore)(nil).ListSystemAccounts), ctx)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
 }
 
-// CreateTransfer mocks base method.
-func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+// ListTransfersByOwner mocks base method.
+func (m *MockStore) ListTransfersByOwner(ctx context.Context, arg db.ListTransfersByOwnerParams) ([]db.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "ListTransfersByOwner", ctx, arg)
+	ret0, _ := ret[0].([]db.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransfer indicates an expected call of CreateTransfer.
-func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+// ListTransfersByOwner indicates an expected call of ListTransfersByOwner.
+func (mr *MockStoreMockRecorder) ListTransfersByOwner(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersByOwner", reflect.TypeOf((*MockStore)(nil).ListTransfersByOwner), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// LockLogin mocks base method.
+func (m *MockStore) LockLogin(ctx context.Context, arg db.LockLoginParams) (db.LoginFailure, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "LockLogin", ctx, arg)
+	ret0, _ := ret[0].(db.LoginFailure)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockStoreMockRecorder) LockLogin(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockStore)(nil).LockLogin), ctx, arg)
 }
 
-// CreateUserTx mocks base method.
-func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+// MarkDormantAccounts mocks base method.
+func (m *MockStore) MarkDormantAccounts(ctx context.Context, inactiveSince time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
-	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret := m.ctrl.Call(m, "MarkDormantAccounts", ctx, inactiveSince)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserTx indicates an expected call of CreateUserTx.
-func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg any) *gomock.Call {
+// MarkDormantAccounts indicates an expected call of MarkDormantAccounts.
+func (mr *MockStoreMockRecorder) MarkDormantAccounts(ctx, inactiveSince any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDormantAccounts", reflect.TypeOf((*MockStore)(nil).MarkDormantAccounts), ctx, inactiveSince)
 }
 
-// CreateVerifyEmail mocks base method.
-func (m *MockStore) CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+// MarkSessionUsed mocks base method.
+func (m *MockStore) MarkSessionUsed(ctx context.Context, id uuid.UUID) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVerifyEmail", ctx, arg)
-	ret0, _ := ret[0].(db.VerifyEmail)
+	ret := m.ctrl.Call(m, "MarkSessionUsed", ctx, id)
+	ret0, _ := ret[0].(db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateVerifyEmail indicates an expected call of CreateVerifyEmail.
-func (mr *MockStoreMockRecorder) CreateVerifyEmail(ctx, arg any) *gomock.Call {
+// MarkSessionUsed indicates an expected call of MarkSessionUsed.
+func (mr *MockStoreMockRecorder) MarkSessionUsed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSessionUsed", reflect.TypeOf((*MockStore)(nil).MarkSessionUsed), ctx, id)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+// MarkTransferQuoteUsed mocks base method.
+func (m *MockStore) MarkTransferQuoteUsed(ctx context.Context, id uuid.UUID) (db.TransferQuote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MarkTransferQuoteUsed", ctx, id)
+	ret0, _ := ret[0].(db.TransferQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+// MarkTransferQuoteUsed indicates an expected call of MarkTransferQuoteUsed.
+func (mr *MockStoreMockRecorder) MarkTransferQuoteUsed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTransferQuoteUsed", reflect.TypeOf((*MockStore)(nil).MarkTransferQuoteUsed), ctx, id)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+// RecordLoginFailure mocks base method.
+func (m *MockStore) RecordLoginFailure(ctx context.Context, arg db.RecordLoginFailureParams) (db.LoginFailure, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "RecordLoginFailure", ctx, arg)
+	ret0, _ := ret[0].(db.LoginFailure)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockStoreMockRecorder) RecordLoginFailure(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockStore)(nil).RecordLoginFailure), ctx, arg)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+// RehashUserPassword mocks base method.
+func (m *MockStore) RehashUserPassword(ctx context.Context, arg db.RehashUserPasswordParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "RehashUserPassword", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+// RehashUserPassword indicates an expected call of RehashUserPassword.
+func (mr *MockStoreMockRecorder) RehashUserPassword(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashUserPassword", reflect.TypeOf((*MockStore)(nil).RehashUserPassword), ctx, arg)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+// ReplayTransferTx mocks base method.
+func (m *MockStore) ReplayTransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
-	ret0, _ := ret[0].(db.Entry)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReplayTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+// ReplayTransferTx indicates an expected call of ReplayTransferTx.
+func (mr *MockStoreMockRecorder) ReplayTransferTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayTransferTx", reflect.TypeOf((*MockStore)(nil).ReplayTransferTx), ctx, arg)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+// ResetPasswordTx mocks base method.
+func (m *MockStore) ResetPasswordTx(ctx context.Context, arg db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", ctx, id)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ResetPasswordTx", ctx, arg)
+	ret0, _ := ret[0].(db.ResetPasswordTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockStoreMockRecorder) ResetPasswordTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetPasswordTx), ctx, arg)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+// RevokeSessionTx mocks base method.
+func (m *MockStore) RevokeSessionTx(ctx context.Context, arg db.RevokeSessionTxParams) (db.RevokeSessionTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "RevokeSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.RevokeSessionTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+// RevokeSessionTx indicates an expected call of RevokeSessionTx.
+func (mr *MockStoreMockRecorder) RevokeSessionTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionTx", reflect.TypeOf((*MockStore)(nil).RevokeSessionTx), ctx, arg)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(ctx context.Context, username string) (db.User, error) {
+// RevokeToken mocks base method.
+func (m *MockStore) RevokeToken(ctx context.Context, arg db.RevokeTokenParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, username)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockStoreMockRecorder) RevokeToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockStore)(nil).RevokeToken), ctx, arg)
+}
+
+// RevokeUserSessionsTx mocks base method.
+func (m *MockStore) RevokeUserSessionsTx(ctx context.Context, arg db.RevokeUserSessionsTxParams) (db.RevokeUserSessionsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessionsTx", ctx, arg)
+	ret0, _ := ret[0].(db.RevokeUserSessionsTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(ctx, username any) *gomock.Call {
+// RevokeUserSessionsTx indicates an expected call of RevokeUserSessionsTx.
+func (mr *MockStoreMockRecorder) RevokeUserSessionsTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessionsTx", reflect.TypeOf((*MockStore)(nil).RevokeUserSessionsTx), ctx, arg)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(ctx context.Context, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "RotateSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.RotateSessionTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), ctx, arg)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+// SearchUsers mocks base method.
+func (m *MockStore) SearchUsers(ctx context.Context, arg db.SearchUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, arg)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockStoreMockRecorder) SearchUsers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockStore)(nil).SearchUsers), ctx, arg)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
+// SetTransferLimitsTx mocks base method.
+func (m *MockStore) SetTransferLimitsTx(ctx context.Context, arg db.SetTransferLimitsTxParams) (db.SetTransferLimitsTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "SetTransferLimitsTx", ctx, arg)
+	ret0, _ := ret[0].(db.SetTransferLimitsTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
+// SetTransferLimitsTx indicates an expected call of SetTransferLimitsTx.
+func (mr *MockStoreMockRecorder) SetTransferLimitsTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransferLimitsTx", reflect.TypeOf((*MockStore)(nil).SetTransferLimitsTx), ctx, arg)
 }
 
 // TransferTx mocks base method.
@@ -326,19 +1734,94 @@ func (mr *MockStoreMockRecorder) TransferTx(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), ctx, arg)
 }
 
-// UpdateAccount mocks base method.
-func (m *MockStore) UpdateAccount(ctx context.Context, arg db.UpdateAccountParams) (db.Account, error) {
+// UpdateAccountStatus mocks base method.
+func (m *MockStore) UpdateAccountStatus(ctx context.Context, arg db.UpdateAccountStatusParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, arg)
 	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockStoreMockRecorder) UpdateAccount(ctx, arg any) *gomock.Call {
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockStoreMockRecorder) UpdateAccountStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatus), ctx, arg)
+}
+
+// UpdateAccountStatusTx mocks base method.
+func (m *MockStore) UpdateAccountStatusTx(ctx context.Context, arg db.UpdateAccountStatusTxParams) (db.UpdateAccountStatusTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatusTx", ctx, arg)
+	ret0, _ := ret[0].(db.UpdateAccountStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatusTx indicates an expected call of UpdateAccountStatusTx.
+func (mr *MockStoreMockRecorder) UpdateAccountStatusTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatusTx), ctx, arg)
+}
+
+// UpdateIdempotencyKeyResponse mocks base method.
+func (m *MockStore) UpdateIdempotencyKeyResponse(ctx context.Context, arg db.UpdateIdempotencyKeyResponseParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIdempotencyKeyResponse", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIdempotencyKeyResponse indicates an expected call of UpdateIdempotencyKeyResponse.
+func (mr *MockStoreMockRecorder) UpdateIdempotencyKeyResponse(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdempotencyKeyResponse", reflect.TypeOf((*MockStore)(nil).UpdateIdempotencyKeyResponse), ctx, arg)
+}
+
+// UpdateScheduledTransfer mocks base method.
+func (m *MockStore) UpdateScheduledTransfer(ctx context.Context, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledTransfer indicates an expected call of UpdateScheduledTransfer.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransfer), ctx, arg)
+}
+
+// UpdateScheduledTransferNextRun mocks base method.
+func (m *MockStore) UpdateScheduledTransferNextRun(ctx context.Context, arg db.UpdateScheduledTransferNextRunParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransferNextRun", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledTransferNextRun indicates an expected call of UpdateScheduledTransferNextRun.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransferNextRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransferNextRun", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransferNextRun), ctx, arg)
+}
+
+// UpdateScheduledTransferTx mocks base method.
+func (m *MockStore) UpdateScheduledTransferTx(ctx context.Context, arg db.UpdateScheduledTransferTxParams) (db.UpdateScheduledTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.UpdateScheduledTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledTransferTx indicates an expected call of UpdateScheduledTransferTx.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransferTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransferTx", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransferTx), ctx, arg)
 }
 
 // UpdateUser mocks base method.
@@ -356,6 +1839,51 @@ func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
 }
 
+// UpdateUserFrozenAt mocks base method.
+func (m *MockStore) UpdateUserFrozenAt(ctx context.Context, arg db.UpdateUserFrozenAtParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserFrozenAt", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserFrozenAt indicates an expected call of UpdateUserFrozenAt.
+func (mr *MockStoreMockRecorder) UpdateUserFrozenAt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserFrozenAt", reflect.TypeOf((*MockStore)(nil).UpdateUserFrozenAt), ctx, arg)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(ctx context.Context, arg db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), ctx, arg)
+}
+
+// UpdateUserTx mocks base method.
+func (m *MockStore) UpdateUserTx(ctx context.Context, arg db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTx", ctx, arg)
+	ret0, _ := ret[0].(db.UpdateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTx indicates an expected call of UpdateUserTx.
+func (mr *MockStoreMockRecorder) UpdateUserTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTx", reflect.TypeOf((*MockStore)(nil).UpdateUserTx), ctx, arg)
+}
+
 // UpdateVerifyEmail mocks base method.
 func (m *MockStore) UpdateVerifyEmail(ctx context.Context, arg db.UpdateVerifyEmailParams) (db.VerifyEmail, error) {
 	m.ctrl.T.Helper()
@@ -371,6 +1899,81 @@ func (mr *MockStoreMockRecorder) UpdateVerifyEmail(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerifyEmail", reflect.TypeOf((*MockStore)(nil).UpdateVerifyEmail), ctx, arg)
 }
 
+// UpsertTOTPCredential mocks base method.
+func (m *MockStore) UpsertTOTPCredential(ctx context.Context, arg db.UpsertTOTPCredentialParams) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTOTPCredential", ctx, arg)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTOTPCredential indicates an expected call of UpsertTOTPCredential.
+func (mr *MockStoreMockRecorder) UpsertTOTPCredential(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTOTPCredential", reflect.TypeOf((*MockStore)(nil).UpsertTOTPCredential), ctx, arg)
+}
+
+// UpsertTransferLimit mocks base method.
+func (m *MockStore) UpsertTransferLimit(ctx context.Context, arg db.UpsertTransferLimitParams) (db.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(db.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTransferLimit indicates an expected call of UpsertTransferLimit.
+func (mr *MockStoreMockRecorder) UpsertTransferLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTransferLimit", reflect.TypeOf((*MockStore)(nil).UpsertTransferLimit), ctx, arg)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockStore) UsePasswordReset(ctx context.Context, arg db.UsePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockStoreMockRecorder) UsePasswordReset(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockStore)(nil).UsePasswordReset), ctx, arg)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockStore) UseRecoveryCode(ctx context.Context, arg db.UseRecoveryCodeParams) (db.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockStoreMockRecorder) UseRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseRecoveryCode), ctx, arg)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockStore) UseTOTPStep(ctx context.Context, arg db.UseTOTPStepParams) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, arg)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockStoreMockRecorder) UseTOTPStep(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockStore)(nil).UseTOTPStep), ctx, arg)
+}
+
 // VerifyEmailTx mocks base method.
 func (m *MockStore) VerifyEmailTx(ctx context.Context, arg db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error) {
 	m.ctrl.T.Helper()
@@ -385,3 +1988,33 @@ func (mr *MockStoreMockRecorder) VerifyEmailTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailTx", reflect.TypeOf((*MockStore)(nil).VerifyEmailTx), ctx, arg)
 }
+
+// VerifyLedgerIntegrity mocks base method.
+func (m *MockStore) VerifyLedgerIntegrity(ctx context.Context, arg db.VerifyLedgerIntegrityParams) (db.VerifyLedgerIntegrityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLedgerIntegrity", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyLedgerIntegrityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLedgerIntegrity indicates an expected call of VerifyLedgerIntegrity.
+func (mr *MockStoreMockRecorder) VerifyLedgerIntegrity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLedgerIntegrity", reflect.TypeOf((*MockStore)(nil).VerifyLedgerIntegrity), ctx, arg)
+}
+
+// WithdrawTx mocks base method.
+func (m *MockStore) WithdrawTx(ctx context.Context, arg db.WithdrawTxParams) (db.WithdrawTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawTx", ctx, arg)
+	ret0, _ := ret[0].(db.WithdrawTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawTx indicates an expected call of WithdrawTx.
+func (mr *MockStoreMockRecorder) WithdrawTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawTx", reflect.TypeOf((*MockStore)(nil).WithdrawTx), ctx, arg)
+}
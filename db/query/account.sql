@@ -24,18 +24,35 @@ LIMIT $2
 OFFSET $3;
 
 
--- name: UpdateAccount :one
-UPDATE accounts
-SET balance = $2
-WHERE id = $1
-RETURNING *;
-
 -- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET
+    status = $2,
+    status_changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkDormantAccounts :execrows
+-- Fees and adjustments are not made by the customer, so they do not keep an
+-- account active.
+UPDATE accounts a
+SET
+    status = 'dormant',
+    status_changed_at = now()
+WHERE
+    a.status = 'active' AND
+    a.status_changed_at < sqlc.arg(inactive_since)::timestamptz AND
+    a.created_at < sqlc.arg(inactive_since)::timestamptz AND
+    NOT EXISTS (
+        SELECT 1 FROM entries e
+        WHERE
+            e.account_id = a.id AND
+            e.type IN ('transfer', 'deposit', 'withdrawal', 'exchange') AND
+            e.created_at >= sqlc.arg(inactive_since)::timestamptz
+    );
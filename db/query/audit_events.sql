@@ -0,0 +1,28 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    actor,
+    action,
+    target_type,
+    target_id,
+    reason,
+    before,
+    after,
+    client_ip,
+    user_agent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListAuditEvents :many
+-- Every filter is optional. Events are listed newest first.
+SELECT * FROM audit_events
+WHERE
+    (sqlc.narg(actor)::varchar IS NULL OR actor = sqlc.narg(actor)) AND
+    (sqlc.narg(action)::varchar IS NULL OR action = sqlc.narg(action)) AND
+    (sqlc.narg(target_type)::varchar IS NULL OR target_type = sqlc.narg(target_type)) AND
+    (sqlc.narg(target_id)::varchar IS NULL OR target_id = sqlc.narg(target_id)) AND
+    (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time)) AND
+    (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
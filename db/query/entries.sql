@@ -1,10 +1,53 @@
 -- name: CreateEntry :one
+-- Entries must be created while the account row is locked and before its
+-- balance is updated, so that balance_after is the balance once the entry is
+-- applied and the entry is chained to the last one of the account.
+-- created_at is taken once the lock is held rather than when the transaction
+-- started, so that entries of an account are in the same order by
+-- (created_at, id) as by id.
+WITH new_entry AS (
+    SELECT
+        (SELECT balance FROM accounts WHERE id = sqlc.arg(account_id)) + sqlc.arg(amount)::bigint AS balance_after,
+        clock_timestamp()::timestamp AS created_at,
+        (
+            SELECT hash FROM entries
+            WHERE account_id = sqlc.arg(account_id)
+            ORDER BY id DESC
+            LIMIT 1
+        ) AS prev_hash
+)
 INSERT INTO entries (
     account_id,
-    amount
-) VALUES (
-    $1, $2
-) RETURNING *;
+    amount,
+    type,
+    external_reference,
+    transfer_id,
+    balance_after,
+    created_at,
+    prev_hash,
+    hash
+)
+SELECT
+    sqlc.arg(account_id)::bigint,
+    sqlc.arg(amount)::bigint,
+    sqlc.arg(type)::varchar,
+    sqlc.narg(external_reference)::varchar,
+    sqlc.narg(transfer_id)::bigint,
+    n.balance_after,
+    n.created_at,
+    n.prev_hash,
+    entry_hash(
+        n.prev_hash,
+        sqlc.arg(account_id)::bigint,
+        sqlc.arg(amount)::bigint,
+        sqlc.arg(type)::varchar,
+        sqlc.narg(transfer_id)::bigint,
+        n.balance_after,
+        n.created_at,
+        sqlc.narg(external_reference)::varchar
+    )
+FROM new_entry n
+RETURNING *;
 
 -- name: GetEntry :one
 SELECT * FROM entries
@@ -16,3 +59,91 @@ WHERE account_id = $1
 ORDER BY id
 LIMIT $2
 OFFSET $3;
+
+-- name: ListStatementEntries :many
+SELECT
+    sqlc.embed(e),
+    COALESCE(CASE
+        WHEN t.from_account_id = e.account_id THEN t.to_account_id
+        ELSE t.from_account_id
+    END, 0)::bigint AS counterparty_account_id,
+    COALESCE(c.owner, '')::varchar AS counterparty_owner
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = CASE
+    WHEN t.from_account_id = e.account_id THEN t.to_account_id
+    ELSE t.from_account_id
+END
+WHERE e.account_id = sqlc.arg(account_id)
+    AND e.created_at >= sqlc.arg(start_time)
+    AND e.created_at < sqlc.arg(end_time)
+    AND (e.created_at, e.id) > (sqlc.arg(after_created_at)::timestamp, sqlc.arg(after_id)::bigint)
+ORDER BY e.created_at, e.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetAccountBalanceAt :one
+-- Returns the balance of an account just before the given time.
+SELECT COALESCE(
+    (
+        SELECT e.balance_after FROM entries e
+        WHERE e.account_id = a.id AND e.created_at < sqlc.arg(at)
+        ORDER BY e.created_at DESC, e.id DESC
+        LIMIT 1
+    ),
+    (
+        SELECT e.balance_after - e.amount FROM entries e
+        WHERE e.account_id = a.id
+        ORDER BY e.created_at, e.id
+        LIMIT 1
+    ),
+    a.balance
+)::bigint AS balance
+FROM accounts a
+WHERE a.id = sqlc.arg(account_id);
+
+-- name: ListEntriesForVerification :many
+-- Lists the entries in chain order, from the entry after (after_account_id,
+-- after_id). account_id limits the list to one account when set.
+SELECT * FROM entries
+WHERE (account_id, id) > (sqlc.arg(after_account_id)::bigint, sqlc.arg(after_id)::bigint)
+    AND (sqlc.narg(account_id)::bigint IS NULL OR account_id = sqlc.narg(account_id))
+ORDER BY account_id, id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetMismatchedTransferEntry :one
+-- Returns the first entry that does not match the transfer it belongs to.
+SELECT e.* FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+WHERE (sqlc.narg(account_id)::bigint IS NULL OR e.account_id = sqlc.narg(account_id))
+    AND NOT (
+        (e.account_id = t.from_account_id AND e.amount = -t.amount) OR
+        (e.account_id = t.to_account_id AND e.amount = COALESCE(t.to_amount, t.amount))
+    )
+ORDER BY e.account_id, e.id
+LIMIT 1;
+
+-- name: GetTransferWithoutEntries :one
+-- Returns the first transfer that misses the entry of one of its accounts.
+SELECT t.* FROM transfers t
+WHERE (sqlc.narg(account_id)::bigint IS NULL OR sqlc.narg(account_id) IN (t.from_account_id, t.to_account_id))
+    AND (
+        NOT EXISTS (SELECT 1 FROM entries e WHERE e.transfer_id = t.id AND e.account_id = t.from_account_id) OR
+        NOT EXISTS (SELECT 1 FROM entries e WHERE e.transfer_id = t.id AND e.account_id = t.to_account_id)
+    )
+ORDER BY t.id
+LIMIT 1;
+
+-- name: GetMismatchedAccountBalance :one
+-- Returns the first account whose balance differs from the balance after its
+-- last entry. Accounts without entries are skipped.
+SELECT a.id AS account_id, a.balance, e.id AS entry_id, e.balance_after FROM accounts a
+JOIN LATERAL (
+    SELECT id, balance_after FROM entries
+    WHERE account_id = a.id
+    ORDER BY id DESC
+    LIMIT 1
+) e ON true
+WHERE (sqlc.narg(account_id)::bigint IS NULL OR a.id = sqlc.narg(account_id))
+    AND a.balance <> e.balance_after
+ORDER BY a.id
+LIMIT 1;
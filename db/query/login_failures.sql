@@ -0,0 +1,29 @@
+-- name: GetLoginFailure :one
+SELECT * FROM login_failures
+WHERE scope = $1 AND key = $2 LIMIT 1;
+
+-- name: RecordLoginFailure :one
+-- The count restarts when the previous failure is older than reset_before.
+INSERT INTO login_failures (
+    scope,
+    key,
+    failed_count
+) VALUES (
+    sqlc.arg(scope), sqlc.arg(key), 1
+) ON CONFLICT (scope, key) DO UPDATE
+SET failed_count = CASE
+        WHEN login_failures.last_failed_at < sqlc.arg(reset_before) THEN 1
+        ELSE login_failures.failed_count + 1
+    END,
+    last_failed_at = now()
+RETURNING *;
+
+-- name: LockLogin :one
+UPDATE login_failures
+SET locked_until = sqlc.arg(locked_until)
+WHERE scope = sqlc.arg(scope) AND key = sqlc.arg(key)
+RETURNING *;
+
+-- name: DeleteLoginFailure :exec
+DELETE FROM login_failures
+WHERE scope = $1 AND key = $2;
@@ -0,0 +1,23 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (username, email, secret_code_hash)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UsePasswordReset :one
+UPDATE password_resets
+SET
+    is_used = true
+WHERE
+    id = @id
+    AND secret_code_hash = @secret_code_hash
+    AND expired_at > now()
+    AND is_used = false
+RETURNING *;
+
+-- name: InvalidatePasswordResets :exec
+UPDATE password_resets
+SET
+    is_used = true
+WHERE
+    username = @username
+    AND is_used = false;
@@ -0,0 +1,40 @@
+-- name: CreatePosting :one
+INSERT INTO postings (
+    journal_id,
+    account_id,
+    system_account_id,
+    entry_id,
+    amount,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListJournalPostings :many
+SELECT * FROM postings
+WHERE journal_id = $1
+ORDER BY id;
+
+-- name: ListAccountLedgerMismatches :many
+SELECT
+    a.id,
+    a.balance,
+    COALESCE(SUM(p.amount), 0)::bigint AS ledger_balance
+FROM accounts a
+LEFT JOIN postings p ON p.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY a.id;
+
+-- name: ListSystemAccountLedgerMismatches :many
+SELECT
+    s.id,
+    s.code,
+    s.currency,
+    s.balance,
+    COALESCE(SUM(p.amount), 0)::bigint AS ledger_balance
+FROM system_accounts s
+LEFT JOIN postings p ON p.system_account_id = s.id
+GROUP BY s.id
+HAVING s.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY s.id;
@@ -0,0 +1,5 @@
+-- name: ListRolePermissions :many
+-- Roles without permissions are listed with a null permission.
+SELECT roles.name AS role, role_permissions.permission FROM roles
+LEFT JOIN role_permissions ON role_permissions.role = roles.name
+ORDER BY roles.name, role_permissions.permission;
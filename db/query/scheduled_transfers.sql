@@ -0,0 +1,73 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    cron_expression,
+    interval_seconds,
+    next_run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+    amount = COALESCE(sqlc.narg(amount), amount),
+    cron_expression = CASE WHEN sqlc.arg(update_schedule)::boolean THEN sqlc.narg(cron_expression) ELSE cron_expression END,
+    interval_seconds = CASE WHEN sqlc.arg(update_schedule)::boolean THEN sqlc.narg(interval_seconds) ELSE interval_seconds END,
+    next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+    is_active = COALESCE(sqlc.narg(is_active), is_active),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteScheduledTransfer :exec
+DELETE FROM scheduled_transfers
+WHERE id = $1;
+
+-- name: DeactivateAccountScheduledTransfers :execrows
+UPDATE scheduled_transfers
+SET
+    is_active = false,
+    updated_at = now()
+WHERE is_active AND (from_account_id = $1 OR to_account_id = $1);
+
+-- name: DeactivateOwnerScheduledTransfers :execrows
+UPDATE scheduled_transfers
+SET
+    is_active = false,
+    updated_at = now()
+WHERE is_active AND owner = $1;
+
+-- name: ListDueScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE is_active AND next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at
+LIMIT sqlc.arg(limit_count)
+FOR NO KEY UPDATE SKIP LOCKED;
+
+-- name: UpdateScheduledTransferNextRun :one
+UPDATE scheduled_transfers
+SET
+    next_run_at = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
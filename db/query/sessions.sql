@@ -6,11 +6,61 @@ INSERT INTO sessions (
     user_agent,
     client_ip,
     is_blocked,
-    expires_at
+    expires_at,
+    family_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
-WHERE id = $1 LIMIT 1;
\ No newline at end of file
+WHERE id = $1 LIMIT 1;
+
+-- name: GetSessionForUpdate :one
+SELECT * FROM sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE username = $1
+    AND NOT is_blocked
+    AND NOT is_used
+    AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked;
+
+-- name: BlockUserSessionFamilies :many
+-- Blocks like BlockUserSessions and returns the family of every session it
+-- blocked.
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked
+RETURNING family_id;
+
+-- name: BlockSessionFamily :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1 AND NOT is_blocked;
+
+-- name: MarkSessionUsed :one
+UPDATE sessions
+SET is_used = true
+WHERE id = $1
+RETURNING *;
+
+-- name: IsSessionFamilyBlocked :one
+SELECT EXISTS (
+    SELECT 1 FROM sessions
+    WHERE family_id = $1 AND is_blocked
+);
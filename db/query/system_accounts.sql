@@ -0,0 +1,24 @@
+-- name: GetSystemAccountForUpdate :one
+INSERT INTO system_accounts (
+    code,
+    currency
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (code, currency) DO UPDATE
+SET code = EXCLUDED.code
+RETURNING *;
+
+-- name: GetSystemAccount :one
+SELECT * FROM system_accounts
+WHERE code = $1 AND currency = $2 LIMIT 1;
+
+-- name: ListSystemAccounts :many
+SELECT * FROM system_accounts
+ORDER BY code, currency;
+
+-- name: AddSystemAccountBalance :one
+UPDATE system_accounts
+SET balance = balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,34 @@
+-- name: UpsertTOTPCredential :one
+INSERT INTO totp_credentials (
+    username,
+    secret
+) VALUES (
+    $1, $2
+) ON CONFLICT (username) DO UPDATE
+SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE totp_credentials.confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetTOTPCredential :one
+SELECT * FROM totp_credentials
+WHERE username = $1 LIMIT 1;
+
+-- name: ConfirmTOTPCredential :one
+UPDATE totp_credentials
+SET confirmed_at = now()
+WHERE username = $1
+  AND confirmed_at IS NULL
+RETURNING *;
+
+-- name: UseTOTPStep :one
+UPDATE totp_credentials
+SET last_used_step = sqlc.arg(step)
+WHERE username = sqlc.arg(username)
+  AND last_used_step < sqlc.arg(step)
+RETURNING *;
+
+-- name: DeleteTOTPCredential :exec
+DELETE FROM totp_credentials
+WHERE username = $1;
@@ -14,8 +14,29 @@ WHERE id = $1 LIMIT 1;
 -- name: ListTransfers :many
 SELECT * FROM transfers
 WHERE
-    from_account_id = $1 OR
-    to_account_id = $2
+    from_account_id = sqlc.arg(account_id) OR
+    to_account_id = sqlc.arg(account_id)
 ORDER BY id
-LIMIT $3
-OFFSET $4;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListTransfersByOwner :many
+SELECT * FROM transfers
+WHERE
+    from_account_id IN (SELECT a.id FROM accounts a WHERE a.owner = sqlc.arg(owner)::varchar) OR
+    to_account_id IN (SELECT a.id FROM accounts a WHERE a.owner = sqlc.arg(owner)::varchar)
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CreateExchangeTransfer :one
+INSERT INTO transfers (
+    from_account_id,
+    to_account_id,
+    amount,
+    to_amount,
+    exchange_rate,
+    quote_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
@@ -0,0 +1,45 @@
+-- name: GetTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE scope = $1 AND key = $2 LIMIT 1;
+
+-- name: GetTransferLimitForUpdate :one
+SELECT * FROM transfer_limits
+WHERE scope = $1 AND key = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpsertTransferLimit :one
+INSERT INTO transfer_limits (
+    scope,
+    key,
+    per_transfer,
+    daily_amount,
+    monthly_amount,
+    hourly_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) ON CONFLICT (scope, key) DO UPDATE
+SET per_transfer = EXCLUDED.per_transfer,
+    daily_amount = EXCLUDED.daily_amount,
+    monthly_amount = EXCLUDED.monthly_amount,
+    hourly_count = EXCLUDED.hourly_count,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetTransferUsage :one
+-- Windows are rolling and end now: a day is 24 hours and a month 30 days.
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE created_at > now() - interval '1 day'), 0)::bigint AS daily_amount,
+    COALESCE(SUM(amount), 0)::bigint AS monthly_amount,
+    COUNT(*) FILTER (WHERE created_at > now() - interval '1 hour') AS hourly_count
+FROM transfers
+WHERE from_account_id = $1 AND created_at > now() - interval '30 days';
+
+-- name: GetOwnerTransferUsage :one
+-- Same windows as GetTransferUsage, over every account of the owner.
+SELECT
+    COALESCE(SUM(t.amount) FILTER (WHERE t.created_at > now() - interval '1 day'), 0)::bigint AS daily_amount,
+    COALESCE(SUM(t.amount), 0)::bigint AS monthly_amount,
+    COUNT(*) FILTER (WHERE t.created_at > now() - interval '1 hour') AS hourly_count
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND t.created_at > now() - interval '30 days';
@@ -0,0 +1,31 @@
+-- name: CreateTransferQuote :one
+INSERT INTO transfer_quotes (
+    id,
+    username,
+    from_account_id,
+    to_account_id,
+    from_amount,
+    from_currency,
+    to_amount,
+    to_currency,
+    exchange_rate_id,
+    rate,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetTransferQuote :one
+SELECT * FROM transfer_quotes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferQuoteForUpdate :one
+SELECT * FROM transfer_quotes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: MarkTransferQuoteUsed :one
+UPDATE transfer_quotes
+SET is_used = true
+WHERE id = $1
+RETURNING *;
@@ -22,4 +22,49 @@ SET
     email = COALESCE(sqlc.narg(email), email),
     is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
 WHERE username = sqlc.arg(username) 
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetUserPasswordChangeAt :one
+-- Tokens issued before a freeze, an unfreeze or a role change are rejected
+-- like those issued before a password change. The application sets all four
+-- times, from the clock it issues tokens with.
+SELECT GREATEST(password_change_at, frozen_at, unfrozen_at, role_changed_at)::timestamptz AS password_change_at FROM users
+WHERE username = $1 LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
+
+-- name: RehashUserPassword :execrows
+UPDATE users
+SET hashed_password = @new_hashed_password
+WHERE username = @username AND hashed_password = @old_hashed_password;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = @role,
+    role_changed_at = @role_changed_at
+WHERE username = @username
+RETURNING *;
+
+-- name: SearchUsers :many
+SELECT * FROM users
+WHERE
+    username ILIKE sqlc.arg(pattern) OR
+    full_name ILIKE sqlc.arg(pattern) OR
+    email ILIKE sqlc.arg(pattern)
+ORDER BY username
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateUserFrozenAt :one
+UPDATE users
+SET frozen_at = sqlc.narg(frozen_at),
+    unfrozen_at = COALESCE(sqlc.narg(unfrozen_at), unfrozen_at)
+WHERE username = sqlc.arg(username)
+RETURNING *;
@@ -7,13 +7,14 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, status_changed_at
 `
 
 type AddAccountBalanceParams struct {
@@ -30,6 +31,8 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusChangedAt,
 	)
 	return i, err
 }
@@ -41,7 +44,7 @@ INSERT INTO accounts (
     currency
 ) VALUES (
     $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at
+) RETURNING id, owner, balance, currency, created_at, status, status_changed_at
 `
 
 type CreateAccountParams struct {
@@ -59,22 +62,14 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusChangedAt,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1
-`
-
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteAccount, id)
-	return err
-}
-
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, status_changed_at FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -87,12 +82,14 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusChangedAt,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, status_changed_at FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -106,12 +103,14 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusChangedAt,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, status_changed_at FROM accounts
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
@@ -139,6 +138,8 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Status,
+			&i.StatusChangedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -150,20 +151,50 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
-const updateAccount = `-- name: UpdateAccount :one
+const markDormantAccounts = `-- name: MarkDormantAccounts :execrows
+UPDATE accounts a
+SET
+    status = 'dormant',
+    status_changed_at = now()
+WHERE
+    a.status = 'active' AND
+    a.status_changed_at < $1::timestamptz AND
+    a.created_at < $1::timestamptz AND
+    NOT EXISTS (
+        SELECT 1 FROM entries e
+        WHERE
+            e.account_id = a.id AND
+            e.type IN ('transfer', 'deposit', 'withdrawal', 'exchange') AND
+            e.created_at >= $1::timestamptz
+    )
+`
+
+// Fees and adjustments are not made by the customer, so they do not keep an
+// account active.
+func (q *Queries) MarkDormantAccounts(ctx context.Context, inactiveSince time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, markDormantAccounts, inactiveSince)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
 UPDATE accounts
-SET balance = $2
+SET
+    status = $2,
+    status_changed_at = now()
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, status_changed_at
 `
 
-type UpdateAccountParams struct {
-	ID      int64 `json:"id"`
-	Balance int64 `json:"balance"`
+type UpdateAccountStatusParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
 }
 
-func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
-	row := q.db.QueryRow(ctx, updateAccount, arg.ID, arg.Balance)
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountStatus, arg.ID, arg.Status)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -171,6 +202,8 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusChangedAt,
 	)
 	return i, err
 }
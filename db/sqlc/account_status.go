@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+const (
+	AccountStatusActive = "active"
+	// AccountStatusFrozen accounts can neither send nor receive money.
+	AccountStatusFrozen = "frozen"
+	// AccountStatusDormant accounts had no customer activity for a long time.
+	// They can receive money but must be reactivated before sending any.
+	AccountStatusDormant = "dormant"
+	// AccountStatusClosed is final. Only accounts with a zero balance can be
+	// closed.
+	AccountStatusClosed = "closed"
+)
+
+// accountStatusTransitions lists the statuses each status can change to.
+var accountStatusTransitions = map[string][]string{
+	AccountStatusActive:  {AccountStatusFrozen, AccountStatusDormant, AccountStatusClosed},
+	AccountStatusFrozen:  {AccountStatusActive},
+	AccountStatusDormant: {AccountStatusActive, AccountStatusFrozen, AccountStatusClosed},
+	AccountStatusClosed:  {},
+}
+
+func CanChangeAccountStatus(from string, to string) bool {
+	return slices.Contains(accountStatusTransitions[from], to)
+}
+
+// checkAccountDebit rejects taking money out of an account that is not
+// active.
+func checkAccountDebit(account Account) error {
+	if account.Status != AccountStatusActive {
+		return fmt.Errorf("%w: account %d is %s", ErrAccountUnavailable, account.ID, account.Status)
+	}
+	return nil
+}
+
+// checkAccountCredit rejects putting money into a frozen or closed account.
+func checkAccountCredit(account Account) error {
+	if account.Status != AccountStatusActive && account.Status != AccountStatusDormant {
+		return fmt.Errorf("%w: account %d is %s", ErrAccountUnavailable, account.ID, account.Status)
+	}
+	return nil
+}
+
+// checkAccountOpen rejects any change to the balance of a closed account.
+func checkAccountOpen(account Account) error {
+	if account.Status == AccountStatusClosed {
+		return fmt.Errorf("%w: account %d is %s", ErrAccountUnavailable, account.ID, account.Status)
+	}
+	return nil
+}
+
+type UpdateAccountStatusTxParams struct {
+	AccountID int64
+	// From, when set, is the status the account must have.
+	From   string
+	Status string
+	Reason string
+	Actor  AuditActor
+}
+
+type UpdateAccountStatusTxResult struct {
+	Account Account
+	// DeactivatedScheduledTransfers is the number of scheduled transfers
+	// stopped because the account was closed.
+	DeactivatedScheduledTransfers int64
+	AuditEvent                    AuditEvent
+}
+
+type accountStatusState struct {
+	Status string `json:"status"`
+}
+
+// UpdateAccountStatusTx moves an account to another status. Closing an
+// account also stops the scheduled transfers from and to it.
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error) {
+	var result UpdateAccountStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if arg.From != "" && account.Status != arg.From {
+			return fmt.Errorf("%w: account %d is %s, not %s", ErrAccountStatusTransition, account.ID, account.Status, arg.From)
+		}
+
+		if !CanChangeAccountStatus(account.Status, arg.Status) {
+			return fmt.Errorf("%w: account %d cannot go from %s to %s", ErrAccountStatusTransition, account.ID, account.Status, arg.Status)
+		}
+
+		if arg.Status == AccountStatusClosed {
+			if account.Balance != 0 {
+				return fmt.Errorf("%w: account %d balance is %d", ErrAccountBalanceNotZero, account.ID, account.Balance)
+			}
+
+			result.DeactivatedScheduledTransfers, err = q.DeactivateAccountScheduledTransfers(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     arg.AccountID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     accountStatusAuditAction(account.Status, arg.Status),
+			TargetType: AuditTargetAccount,
+			TargetID:   strconv.FormatInt(arg.AccountID, 10),
+			Reason:     arg.Reason,
+			Before:     accountStatusState{Status: account.Status},
+			After:      accountStatusState{Status: result.Account.Status},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func accountStatusAuditAction(from string, to string) string {
+	switch {
+	case to == AccountStatusFrozen:
+		return AuditActionFreezeAccount
+	case from == AccountStatusFrozen:
+		return AuditActionUnfreezeAccount
+	case to == AccountStatusClosed:
+		return AuditActionCloseAccount
+	}
+	return AuditActionReactivateAccount
+}
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setAccountStatus(t *testing.T, account Account, status string) Account {
+	banker := createRandomUser(t)
+
+	result, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    status,
+		Reason:    "test",
+		Actor:     AuditActor{Username: banker.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, status, result.Account.Status)
+
+	return result.Account
+}
+
+func TestCanChangeAccountStatus(t *testing.T) {
+	require.True(t, CanChangeAccountStatus(AccountStatusActive, AccountStatusFrozen))
+	require.True(t, CanChangeAccountStatus(AccountStatusDormant, AccountStatusActive))
+	require.False(t, CanChangeAccountStatus(AccountStatusFrozen, AccountStatusClosed))
+	require.False(t, CanChangeAccountStatus(AccountStatusClosed, AccountStatusActive))
+	require.False(t, CanChangeAccountStatus(AccountStatusActive, AccountStatusActive))
+}
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	banker := createRandomUser(t)
+	account := createRandomAccount(t)
+	require.Equal(t, AccountStatusActive, account.Status)
+
+	_, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		From:      AccountStatusFrozen,
+		Status:    AccountStatusActive,
+		Actor:     AuditActor{Username: banker.Username},
+	})
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+
+	result, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusFrozen,
+		Reason:    "court order",
+		Actor:     AuditActor{Username: banker.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, result.Account.Status)
+	require.True(t, result.Account.StatusChangedAt.After(account.StatusChangedAt))
+	require.Equal(t, AuditActionFreezeAccount, result.AuditEvent.Action)
+	require.Equal(t, strconv.FormatInt(account.ID, 10), result.AuditEvent.TargetID)
+	require.JSONEq(t, `{"status": "active"}`, string(result.AuditEvent.Before))
+	require.JSONEq(t, `{"status": "frozen"}`, string(result.AuditEvent.After))
+
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Actor:     AuditActor{Username: banker.Username},
+	})
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+}
+
+func TestCloseAccount(t *testing.T) {
+	scheduledTransfer := createRandomScheduledTransfer(t, time.Now().Add(time.Hour))
+
+	account, err := testStore.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Actor:     AuditActor{Username: account.Owner},
+	})
+	require.ErrorIs(t, err, ErrAccountBalanceNotZero)
+
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    account.Balance,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Actor:     AuditActor{Username: account.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.Equal(t, int64(1), result.DeactivatedScheduledTransfers)
+	require.Equal(t, AuditActionCloseAccount, result.AuditEvent.Action)
+
+	scheduledTransfer, err = testStore.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.False(t, scheduledTransfer.IsActive)
+
+	// The account and its history are kept.
+	entries, err := testStore.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	_, err = testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.ErrorIs(t, err, ErrAccountUnavailable)
+}
+
+func TestTransferTxAccountStatus(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	account2 = setAccountStatus(t, account2, AccountStatusDormant)
+
+	// Dormant accounts can receive money but not send it.
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountUnavailable)
+
+	setAccountStatus(t, account2, AccountStatusFrozen)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountUnavailable)
+
+	account1, err = testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(90), account1.Balance)
+}
+
+func TestMarkDormantAccounts(t *testing.T) {
+	inactive := createRandomAccount(t)
+	recent := createAccountWithBalance(t, utils.USD, 100)
+
+	inactiveSince := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	_, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID: recent.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	marked, err := testStore.MarkDormantAccounts(context.Background(), inactiveSince)
+	require.NoError(t, err)
+	require.Positive(t, marked)
+
+	inactive, err = testStore.GetAccount(context.Background(), inactive.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusDormant, inactive.Status)
+
+	recent, err = testStore.GetAccount(context.Background(), recent.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, recent.Status)
+}
+
+func TestReopenClosedAccountCurrency(t *testing.T) {
+	account := createAccountWithBalance(t, utils.USD, 0)
+
+	_, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Actor:     AuditActor{Username: account.Owner},
+	})
+	require.NoError(t, err)
+
+	reopened, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, account.ID, reopened.ID)
+	require.Equal(t, AccountStatusActive, reopened.Status)
+}
@@ -10,13 +10,17 @@ import (
 )
 
 func createRandomAccount(t *testing.T) Account {
+	return createAccountWithBalance(t, utils.RandomCurrency(), utils.RandomMoney())
+}
+
+func createAccountWithBalance(t *testing.T, currency string, balance int64) Account {
 
 	user := createRandomUser(t)
 
 	arg := CreateAccountParams{
 		Owner:    user.Username,
-		Balance:  utils.RandomMoney(),
-		Currency: utils.RandomCurrency(),
+		Balance:  balance,
+		Currency: currency,
 	}
 
 	account, err := testStore.CreateAccount(context.Background(), arg)
@@ -53,40 +57,6 @@ func TestGetAccount(t *testing.T) {
 	require.Equal(t, account1.ID, account2.ID)
 }
 
-func TestUpdateAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-
-	arg := UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: utils.RandomMoney(),
-	}
-
-	account2, err := testStore.UpdateAccount(context.Background(), arg)
-
-	require.NoError(t, err)
-
-	require.NotEmpty(t, account2)
-
-	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, arg.Balance, account2.Balance)
-}
-
-func TestDeleteAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-
-	err := testStore.DeleteAccount(context.Background(), account1.ID)
-
-	require.NoError(t, err)
-
-	account2, err := testStore.GetAccount(context.Background(), account1.ID)
-
-	require.Error(t, err)
-
-	require.Empty(t, account2)
-}
-
 func TestListAccounts(t *testing.T) {
 	var lastAccount Account
 	for range 10 {
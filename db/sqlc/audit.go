@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Audit target types.
+const (
+	AuditTargetUser     = "user"
+	AuditTargetAccount  = "account"
+	AuditTargetTransfer = "transfer"
+	AuditTargetSession  = "session"
+	AuditTargetRole     = "role"
+
+	AuditTargetScheduledTransfer = "scheduled_transfer"
+	AuditTargetExchangeRate      = "exchange_rate"
+)
+
+// Audit actions, named after the target they change.
+const (
+	AuditActionCreateUser        = "user.create"
+	AuditActionUpdateUser        = "user.update"
+	AuditActionResetPassword     = "user.reset_password"
+	AuditActionAssignRole        = "user.assign_role"
+	AuditActionEnableTwoFactor   = "user.enable_two_factor"
+	AuditActionDisableTwoFactor  = "user.disable_two_factor"
+	AuditActionVerifyEmail       = "user.verify_email"
+	AuditActionFreezeUser        = "user.freeze"
+	AuditActionUnfreezeUser      = "user.unfreeze"
+	AuditActionFreezeAccount     = "account.freeze"
+	AuditActionUnfreezeAccount   = "account.unfreeze"
+	AuditActionReactivateAccount = "account.reactivate"
+	AuditActionCloseAccount      = "account.close"
+	AuditActionAdjustBalance     = "account.adjust_balance"
+	AuditActionDeposit           = "account.deposit"
+	AuditActionWithdraw          = "account.withdraw"
+	AuditActionChargeFee         = "account.charge_fee"
+	AuditActionCreateAccount     = "account.create"
+	AuditActionCreateTransfer    = "transfer.create"
+	AuditActionCreateSession     = "session.create"
+	AuditActionRotateSession     = "session.rotate"
+	AuditActionRevokeSession     = "session.revoke"
+	AuditActionRevokeSessions    = "user.revoke_sessions"
+
+	AuditActionCreateScheduledTransfer = "scheduled_transfer.create"
+	AuditActionUpdateScheduledTransfer = "scheduled_transfer.update"
+	AuditActionDeleteScheduledTransfer = "scheduled_transfer.delete"
+	AuditActionCreateExchangeRate      = "exchange_rate.create"
+
+	AuditActionUpdateAccountTransferLimits = "account.update_transfer_limits"
+	AuditActionUpdateRoleTransferLimits    = "role.update_transfer_limits"
+)
+
+// AuditActor is the user making a change and where the request came from. A
+// change without a username is recorded as made by the system.
+type AuditActor struct {
+	Username  string
+	ClientIP  string
+	UserAgent string
+}
+
+type auditEventParams struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Before     any
+	After      any
+}
+
+// recordAuditEvent records a change made by actor. It is called inside the
+// transaction of the change so that neither is kept without the other.
+func recordAuditEvent(ctx context.Context, q *Queries, actor AuditActor, arg auditEventParams) (AuditEvent, error) {
+	before, err := marshalAuditState(arg.Before)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	after, err := marshalAuditState(arg.After)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	return q.CreateAuditEvent(ctx, CreateAuditEventParams{
+		Actor: pgtype.Text{
+			String: actor.Username,
+			Valid:  actor.Username != "",
+		},
+		Action:     arg.Action,
+		TargetType: arg.TargetType,
+		TargetID:   arg.TargetID,
+		Reason:     arg.Reason,
+		Before:     before,
+		After:      after,
+		ClientIp:   actor.ClientIP,
+		UserAgent:  actor.UserAgent,
+	})
+}
+
+func marshalAuditState(state any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern returns the LIKE pattern that matches values containing s.
+func ContainsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
@@ -0,0 +1,136 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: audit_events.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    actor,
+    action,
+    target_type,
+    target_id,
+    reason,
+    before,
+    after,
+    client_ip,
+    user_agent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, actor, action, target_type, target_id, reason, before, after, client_ip, user_agent, created_at
+`
+
+type CreateAuditEventParams struct {
+	Actor      pgtype.Text `json:"actor"`
+	Action     string      `json:"action"`
+	TargetType string      `json:"target_type"`
+	TargetID   string      `json:"target_id"`
+	Reason     string      `json:"reason"`
+	Before     []byte      `json:"before"`
+	After      []byte      `json:"after"`
+	ClientIp   string      `json:"client_ip"`
+	UserAgent  string      `json:"user_agent"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRow(ctx, createAuditEvent,
+		arg.Actor,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Reason,
+		arg.Before,
+		arg.After,
+		arg.ClientIp,
+		arg.UserAgent,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.TargetType,
+		&i.TargetID,
+		&i.Reason,
+		&i.Before,
+		&i.After,
+		&i.ClientIp,
+		&i.UserAgent,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, actor, action, target_type, target_id, reason, before, after, client_ip, user_agent, created_at FROM audit_events
+WHERE
+    ($1::varchar IS NULL OR actor = $1) AND
+    ($2::varchar IS NULL OR action = $2) AND
+    ($3::varchar IS NULL OR target_type = $3) AND
+    ($4::varchar IS NULL OR target_id = $4) AND
+    ($5::timestamptz IS NULL OR created_at >= $5) AND
+    ($6::timestamptz IS NULL OR created_at < $6)
+ORDER BY created_at DESC, id DESC
+LIMIT $8
+OFFSET $7
+`
+
+type ListAuditEventsParams struct {
+	Actor      pgtype.Text        `json:"actor"`
+	Action     pgtype.Text        `json:"action"`
+	TargetType pgtype.Text        `json:"target_type"`
+	TargetID   pgtype.Text        `json:"target_id"`
+	StartTime  pgtype.Timestamptz `json:"start_time"`
+	EndTime    pgtype.Timestamptz `json:"end_time"`
+	Offset     int32              `json:"offset"`
+	Limit      int32              `json:"limit"`
+}
+
+// Every filter is optional. Events are listed newest first.
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEvents,
+		arg.Actor,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Reason,
+			&i.Before,
+			&i.After,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
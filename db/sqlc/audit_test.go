@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func auditEventsOf(t *testing.T, targetType string, targetID string) []AuditEvent {
+	events, err := testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		TargetType: pgtype.Text{String: targetType, Valid: true},
+		TargetID:   pgtype.Text{String: targetID, Valid: true},
+		Limit:      10,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	return events
+}
+
+func TestTransferTxAuditEvent(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	actor := AuditActor{
+		Username:  account1.Owner,
+		ClientIP:  "127.0.0.1",
+		UserAgent: "test",
+	}
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Owner:         account1.Owner,
+		Actor:         actor,
+	})
+	require.NoError(t, err)
+
+	events := auditEventsOf(t, AuditTargetTransfer, strconv.FormatInt(result.Transfer.ID, 10))
+	require.Len(t, events, 1)
+	require.Equal(t, result.AuditEvent.ID, events[0].ID)
+	require.Equal(t, AuditActionCreateTransfer, events[0].Action)
+	require.Equal(t, actor.Username, events[0].Actor.String)
+	require.Equal(t, actor.ClientIP, events[0].ClientIp)
+	require.Equal(t, actor.UserAgent, events[0].UserAgent)
+	require.Nil(t, events[0].Before)
+
+	var after Transfer
+	require.NoError(t, json.Unmarshal(events[0].After, &after))
+	require.Equal(t, result.Transfer.ID, after.ID)
+	require.Equal(t, int64(10), after.Amount)
+}
+
+func TestTransferTxAuditEventSystem(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.False(t, result.AuditEvent.Actor.Valid)
+}
+
+func TestUpdateUserTxAuditEvent(t *testing.T) {
+	user := createRandomUser(t)
+	newFullName := utils.RandomOwner()
+
+	result, err := testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			FullName: pgtype.Text{
+				String: newFullName,
+				Valid:  true,
+			},
+		},
+		Actor: AuditActor{Username: user.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditActionUpdateUser, result.AuditEvent.Action)
+	require.Equal(t, user.Username, result.AuditEvent.TargetID)
+
+	var before, after userState
+	require.NoError(t, json.Unmarshal(result.AuditEvent.Before, &before))
+	require.NoError(t, json.Unmarshal(result.AuditEvent.After, &after))
+	require.Equal(t, user.FullName, before.FullName)
+	require.Equal(t, newFullName, after.FullName)
+
+	// Password hashes are never copied into the audit log.
+	require.NotContains(t, string(result.AuditEvent.Before), user.HashedPassword)
+}
+
+func TestVerifyEmailTxAuditEvent(t *testing.T) {
+	user := createRandomUser(t)
+
+	verifyEmail, err := testStore.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: utils.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+		Actor:      AuditActor{ClientIP: "127.0.0.1"},
+	})
+	require.NoError(t, err)
+	require.True(t, result.User.IsEmailVerified)
+	require.Equal(t, AuditActionVerifyEmail, result.AuditEvent.Action)
+	require.Equal(t, user.Username, result.AuditEvent.Actor.String)
+	require.Equal(t, "127.0.0.1", result.AuditEvent.ClientIp)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	banker := createRandomUser(t)
+	user := createRandomUser(t)
+	start := time.Now()
+
+	for _, frozen := range []bool{true, false} {
+		_, err := testStore.FreezeUserTx(context.Background(), FreezeUserTxParams{
+			Username: user.Username,
+			Frozen:   frozen,
+			Reason:   "test",
+			Actor:    AuditActor{Username: banker.Username},
+		})
+		require.NoError(t, err)
+	}
+
+	events, err := testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor:     pgtype.Text{String: banker.Username, Valid: true},
+		StartTime: pgtype.Timestamptz{Time: start, Valid: true},
+		Limit:     10,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, AuditActionUnfreezeUser, events[0].Action)
+	require.Equal(t, AuditActionFreezeUser, events[1].Action)
+
+	events, err = testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor:  pgtype.Text{String: banker.Username, Valid: true},
+		Action: pgtype.Text{String: AuditActionFreezeUser, Valid: true},
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	events, err = testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor:   pgtype.Text{String: banker.Username, Valid: true},
+		EndTime: pgtype.Timestamptz{Time: start, Valid: true},
+		Limit:   10,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestAuditEventsAppendOnly(t *testing.T) {
+	banker := createRandomUser(t)
+	user := createRandomUser(t)
+
+	result, err := testStore.FreezeUserTx(context.Background(), FreezeUserTxParams{
+		Username: user.Username,
+		Frozen:   true,
+		Reason:   "test",
+		Actor:    AuditActor{Username: banker.Username},
+	})
+	require.NoError(t, err)
+
+	connPool := testStore.(*SQLStore).connPool
+
+	_, err = connPool.Exec(context.Background(), "UPDATE audit_events SET reason = 'changed' WHERE id = $1", result.AuditEvent.ID)
+	require.Error(t, err)
+
+	_, err = connPool.Exec(context.Background(), "DELETE FROM audit_events WHERE id = $1", result.AuditEvent.ID)
+	require.Error(t, err)
+
+	events := auditEventsOf(t, AuditTargetUser, user.Username)
+	require.Len(t, events, 1)
+	require.Equal(t, "test", events[0].Reason)
+}
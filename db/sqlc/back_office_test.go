@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeUserTx(t *testing.T) {
+	banker := createRandomUser(t)
+	scheduledTransfer := createRandomScheduledTransfer(t, time.Now())
+	user, err := testStore.GetUser(context.Background(), scheduledTransfer.Owner)
+	require.NoError(t, err)
+	session := createRandomSession(t, user.Username)
+
+	actor := AuditActor{
+		Username:  banker.Username,
+		ClientIP:  "127.0.0.1",
+		UserAgent: "test",
+	}
+
+	result, err := testStore.FreezeUserTx(context.Background(), FreezeUserTxParams{
+		Username: user.Username,
+		Frozen:   true,
+		Reason:   "suspected fraud",
+		Actor:    actor,
+	})
+	require.NoError(t, err)
+	require.True(t, result.User.FrozenAt.Valid)
+	require.Equal(t, int64(1), result.RevokedSessions)
+	require.Equal(t, int64(1), result.DeactivatedScheduledTransfers)
+
+	require.Equal(t, banker.Username, result.AuditEvent.Actor.String)
+	require.Equal(t, AuditActionFreezeUser, result.AuditEvent.Action)
+	require.Equal(t, AuditTargetUser, result.AuditEvent.TargetType)
+	require.Equal(t, user.Username, result.AuditEvent.TargetID)
+	require.Equal(t, "suspected fraud", result.AuditEvent.Reason)
+	require.Equal(t, actor.ClientIP, result.AuditEvent.ClientIp)
+	require.JSONEq(t, `{"frozen_at": null}`, string(result.AuditEvent.Before))
+
+	blocked, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	changedAt, err := testStore.GetUserPasswordChangeAt(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, result.User.FrozenAt.Time, changedAt, 0)
+
+	_, err = testStore.FreezeUserTx(context.Background(), FreezeUserTxParams{
+		Username: user.Username,
+		Frozen:   true,
+		Actor:    actor,
+	})
+	require.ErrorIs(t, err, ErrAlreadyFrozen)
+
+	result, err = testStore.FreezeUserTx(context.Background(), FreezeUserTxParams{
+		Username: user.Username,
+		Frozen:   false,
+		Reason:   "cleared",
+		Actor:    actor,
+	})
+	require.NoError(t, err)
+	require.False(t, result.User.FrozenAt.Valid)
+	require.Equal(t, AuditActionUnfreezeUser, result.AuditEvent.Action)
+
+	// Tokens issued before the freeze must stay rejected after the unfreeze.
+	unfrozenChangedAt, err := testStore.GetUserPasswordChangeAt(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, result.User.UnfrozenAt, unfrozenChangedAt, 0)
+	require.False(t, unfrozenChangedAt.Before(changedAt))
+
+	stopped, err := testStore.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.False(t, stopped.IsActive)
+}
+
+func TestAdjustBalanceTx(t *testing.T) {
+	banker := createRandomUser(t)
+	account := createAccountWithBalance(t, utils.USD, 100)
+
+	result, err := testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID: account.ID,
+		Amount:    -40,
+		Reason:    "duplicated payment",
+		Actor:     AuditActor{Username: banker.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(60), result.Account.Balance)
+	require.Equal(t, EntryTypeAdjustment, result.Entry.Type)
+	require.Equal(t, int64(60), result.Entry.BalanceAfter)
+	require.Equal(t, EntryTypeAdjustment, result.Journal.Type)
+
+	var before, after balanceState
+	require.NoError(t, json.Unmarshal(result.AuditEvent.Before, &before))
+	require.NoError(t, json.Unmarshal(result.AuditEvent.After, &after))
+	require.Equal(t, int64(100), before.Balance)
+	require.Equal(t, int64(60), after.Balance)
+
+	_, err = testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID: account.ID,
+		Amount:    -61,
+		Reason:    "too much",
+		Actor:     AuditActor{Username: banker.Username},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestSearchUsers(t *testing.T) {
+	user := createRandomUser(t)
+
+	users, err := testStore.SearchUsers(context.Background(), SearchUsersParams{
+		Pattern: ContainsPattern(user.Email[:len(user.Email)-2]),
+		Limit:   5,
+		Offset:  0,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, users)
+
+	found := false
+	for _, u := range users {
+		found = found || u.Username == user.Username
+	}
+	require.True(t, found)
+}
+
+func TestListTransfersByOwner(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	for _, owner := range []string{account1.Owner, account2.Owner} {
+		transfers, err := testStore.ListTransfersByOwner(context.Background(), ListTransfersByOwnerParams{
+			Owner:  owner,
+			Limit:  5,
+			Offset: 0,
+		})
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	require.Equal(t, `%john%`, ContainsPattern("john"))
+	require.Equal(t, `%50\%\_off\\%`, ContainsPattern(`50%_off\`))
+}
@@ -7,36 +7,129 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createEntry = `-- name: CreateEntry :one
+WITH new_entry AS (
+    SELECT
+        (SELECT balance FROM accounts WHERE id = $1) + $2::bigint AS balance_after,
+        clock_timestamp()::timestamp AS created_at,
+        (
+            SELECT hash FROM entries
+            WHERE account_id = $1
+            ORDER BY id DESC
+            LIMIT 1
+        ) AS prev_hash
+)
 INSERT INTO entries (
     account_id,
-    amount
-) VALUES (
-    $1, $2
-) RETURNING id, account_id, amount, created_at
+    amount,
+    type,
+    external_reference,
+    transfer_id,
+    balance_after,
+    created_at,
+    prev_hash,
+    hash
+)
+SELECT
+    $1::bigint,
+    $2::bigint,
+    $3::varchar,
+    $4::varchar,
+    $5::bigint,
+    n.balance_after,
+    n.created_at,
+    n.prev_hash,
+    entry_hash(
+        n.prev_hash,
+        $1::bigint,
+        $2::bigint,
+        $3::varchar,
+        $5::bigint,
+        n.balance_after,
+        n.created_at,
+        $4::varchar
+    )
+FROM new_entry n
+RETURNING id, account_id, amount, created_at, type, external_reference, transfer_id, balance_after, prev_hash, hash
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID         int64       `json:"account_id"`
+	Amount            int64       `json:"amount"`
+	Type              string      `json:"type"`
+	ExternalReference pgtype.Text `json:"external_reference"`
+	TransferID        pgtype.Int8 `json:"transfer_id"`
 }
 
+// Entries must be created while the account row is locked and before its
+// balance is updated, so that balance_after is the balance once the entry is
+// applied and the entry is chained to the last one of the account.
+// created_at is taken once the lock is held rather than when the transaction
+// started, so that entries of an account are in the same order by
+// (created_at, id) as by id.
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.Type,
+		arg.ExternalReference,
+		arg.TransferID,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Type,
+		&i.ExternalReference,
+		&i.TransferID,
+		&i.BalanceAfter,
+		&i.PrevHash,
+		&i.Hash,
 	)
 	return i, err
 }
 
+const getAccountBalanceAt = `-- name: GetAccountBalanceAt :one
+SELECT COALESCE(
+    (
+        SELECT e.balance_after FROM entries e
+        WHERE e.account_id = a.id AND e.created_at < $1
+        ORDER BY e.created_at DESC, e.id DESC
+        LIMIT 1
+    ),
+    (
+        SELECT e.balance_after - e.amount FROM entries e
+        WHERE e.account_id = a.id
+        ORDER BY e.created_at, e.id
+        LIMIT 1
+    ),
+    a.balance
+)::bigint AS balance
+FROM accounts a
+WHERE a.id = $2
+`
+
+type GetAccountBalanceAtParams struct {
+	At        pgtype.Timestamp `json:"at"`
+	AccountID int64            `json:"account_id"`
+}
+
+// Returns the balance of an account just before the given time.
+func (q *Queries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getAccountBalanceAt, arg.At, arg.AccountID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, type, external_reference, transfer_id, balance_after, prev_hash, hash FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,12 +141,112 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Type,
+		&i.ExternalReference,
+		&i.TransferID,
+		&i.BalanceAfter,
+		&i.PrevHash,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const getMismatchedAccountBalance = `-- name: GetMismatchedAccountBalance :one
+SELECT a.id AS account_id, a.balance, e.id AS entry_id, e.balance_after FROM accounts a
+JOIN LATERAL (
+    SELECT id, balance_after FROM entries
+    WHERE account_id = a.id
+    ORDER BY id DESC
+    LIMIT 1
+) e ON true
+WHERE ($1::bigint IS NULL OR a.id = $1)
+    AND a.balance <> e.balance_after
+ORDER BY a.id
+LIMIT 1
+`
+
+type GetMismatchedAccountBalanceRow struct {
+	AccountID    int64 `json:"account_id"`
+	Balance      int64 `json:"balance"`
+	EntryID      int64 `json:"entry_id"`
+	BalanceAfter int64 `json:"balance_after"`
+}
+
+// Returns the first account whose balance differs from the balance after its
+// last entry. Accounts without entries are skipped.
+func (q *Queries) GetMismatchedAccountBalance(ctx context.Context, accountID pgtype.Int8) (GetMismatchedAccountBalanceRow, error) {
+	row := q.db.QueryRow(ctx, getMismatchedAccountBalance, accountID)
+	var i GetMismatchedAccountBalanceRow
+	err := row.Scan(
+		&i.AccountID,
+		&i.Balance,
+		&i.EntryID,
+		&i.BalanceAfter,
+	)
+	return i, err
+}
+
+const getMismatchedTransferEntry = `-- name: GetMismatchedTransferEntry :one
+SELECT e.id, e.account_id, e.amount, e.created_at, e.type, e.external_reference, e.transfer_id, e.balance_after, e.prev_hash, e.hash FROM entries e
+JOIN transfers t ON t.id = e.transfer_id
+WHERE ($1::bigint IS NULL OR e.account_id = $1)
+    AND NOT (
+        (e.account_id = t.from_account_id AND e.amount = -t.amount) OR
+        (e.account_id = t.to_account_id AND e.amount = COALESCE(t.to_amount, t.amount))
+    )
+ORDER BY e.account_id, e.id
+LIMIT 1
+`
+
+// Returns the first entry that does not match the transfer it belongs to.
+func (q *Queries) GetMismatchedTransferEntry(ctx context.Context, accountID pgtype.Int8) (Entry, error) {
+	row := q.db.QueryRow(ctx, getMismatchedTransferEntry, accountID)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.Type,
+		&i.ExternalReference,
+		&i.TransferID,
+		&i.BalanceAfter,
+		&i.PrevHash,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const getTransferWithoutEntries = `-- name: GetTransferWithoutEntries :one
+SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.created_at, t.to_amount, t.exchange_rate, t.quote_id FROM transfers t
+WHERE ($1::bigint IS NULL OR $1 IN (t.from_account_id, t.to_account_id))
+    AND (
+        NOT EXISTS (SELECT 1 FROM entries e WHERE e.transfer_id = t.id AND e.account_id = t.from_account_id) OR
+        NOT EXISTS (SELECT 1 FROM entries e WHERE e.transfer_id = t.id AND e.account_id = t.to_account_id)
+    )
+ORDER BY t.id
+LIMIT 1
+`
+
+// Returns the first transfer that misses the entry of one of its accounts.
+func (q *Queries) GetTransferWithoutEntries(ctx context.Context, accountID pgtype.Int8) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferWithoutEntries, accountID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
 	)
 	return i, err
 }
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, type, external_reference, transfer_id, balance_after, prev_hash, hash FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
@@ -80,6 +273,142 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Type,
+			&i.ExternalReference,
+			&i.TransferID,
+			&i.BalanceAfter,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesForVerification = `-- name: ListEntriesForVerification :many
+SELECT id, account_id, amount, created_at, type, external_reference, transfer_id, balance_after, prev_hash, hash FROM entries
+WHERE (account_id, id) > ($1::bigint, $2::bigint)
+    AND ($3::bigint IS NULL OR account_id = $3)
+ORDER BY account_id, id
+LIMIT $4
+`
+
+type ListEntriesForVerificationParams struct {
+	AfterAccountID int64       `json:"after_account_id"`
+	AfterID        int64       `json:"after_id"`
+	AccountID      pgtype.Int8 `json:"account_id"`
+	LimitCount     int32       `json:"limit_count"`
+}
+
+// Lists the entries in chain order, from the entry after (after_account_id,
+// after_id). account_id limits the list to one account when set.
+func (q *Queries) ListEntriesForVerification(ctx context.Context, arg ListEntriesForVerificationParams) ([]Entry, error) {
+	rows, err := q.db.Query(ctx, listEntriesForVerification,
+		arg.AfterAccountID,
+		arg.AfterID,
+		arg.AccountID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Type,
+			&i.ExternalReference,
+			&i.TransferID,
+			&i.BalanceAfter,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT
+    e.id, e.account_id, e.amount, e.created_at, e.type, e.external_reference, e.transfer_id, e.balance_after, e.prev_hash, e.hash,
+    COALESCE(CASE
+        WHEN t.from_account_id = e.account_id THEN t.to_account_id
+        ELSE t.from_account_id
+    END, 0)::bigint AS counterparty_account_id,
+    COALESCE(c.owner, '')::varchar AS counterparty_owner
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = CASE
+    WHEN t.from_account_id = e.account_id THEN t.to_account_id
+    ELSE t.from_account_id
+END
+WHERE e.account_id = $1
+    AND e.created_at >= $2
+    AND e.created_at < $3
+    AND (e.created_at, e.id) > ($4::timestamp, $5::bigint)
+ORDER BY e.created_at, e.id
+LIMIT $6
+`
+
+type ListStatementEntriesParams struct {
+	AccountID      int64            `json:"account_id"`
+	StartTime      pgtype.Timestamp `json:"start_time"`
+	EndTime        pgtype.Timestamp `json:"end_time"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	AfterID        int64            `json:"after_id"`
+	LimitCount     int32            `json:"limit_count"`
+}
+
+type ListStatementEntriesRow struct {
+	Entry                 Entry  `json:"entry"`
+	CounterpartyAccountID int64  `json:"counterparty_account_id"`
+	CounterpartyOwner     string `json:"counterparty_owner"`
+}
+
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listStatementEntries,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.Entry.ID,
+			&i.Entry.AccountID,
+			&i.Entry.Amount,
+			&i.Entry.CreatedAt,
+			&i.Entry.Type,
+			&i.Entry.ExternalReference,
+			&i.Entry.TransferID,
+			&i.Entry.BalanceAfter,
+			&i.Entry.PrevHash,
+			&i.Entry.Hash,
+			&i.CounterpartyAccountID,
+			&i.CounterpartyOwner,
 		); err != nil {
 			return nil, err
 		}
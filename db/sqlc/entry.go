@@ -0,0 +1,12 @@
+package db
+
+// Entry types double as journal types.
+const (
+	EntryTypeTransfer       = "transfer"
+	EntryTypeDeposit        = "deposit"
+	EntryTypeWithdrawal     = "withdrawal"
+	EntryTypeFee            = "fee"
+	EntryTypeOpeningBalance = "opening_balance"
+	EntryTypeExchange       = "exchange"
+	EntryTypeAdjustment     = "adjustment"
+)
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListStatementEntries(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 1000)
+	account2 := createAccountWithBalance(t, utils.USD, 1000)
+
+	start := time.Now().UTC().Add(-time.Minute)
+
+	var transfers []TransferTxResult
+	for i := 0; i < 3; i++ {
+		result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, result.Transfer.ID, result.FromEntry.TransferID.Int64)
+		require.Equal(t, result.FromAccount.Balance, result.FromEntry.BalanceAfter)
+		require.Equal(t, result.ToAccount.Balance, result.ToEntry.BalanceAfter)
+		transfers = append(transfers, result)
+	}
+
+	end := time.Now().UTC().Add(time.Minute)
+
+	arg := ListStatementEntriesParams{
+		AccountID:      account1.ID,
+		StartTime:      pgtype.Timestamp{Time: start, Valid: true},
+		EndTime:        pgtype.Timestamp{Time: end, Valid: true},
+		AfterCreatedAt: pgtype.Timestamp{Time: start, Valid: true},
+		LimitCount:     2,
+	}
+
+	firstPage, err := testStore.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+	require.Equal(t, transfers[0].FromEntry.ID, firstPage[0].Entry.ID)
+	require.Equal(t, account2.ID, firstPage[0].CounterpartyAccountID)
+	require.Equal(t, account2.Owner, firstPage[0].CounterpartyOwner)
+
+	last := firstPage[len(firstPage)-1].Entry
+	arg.AfterCreatedAt = last.CreatedAt
+	arg.AfterID = last.ID
+
+	secondPage, err := testStore.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	require.Equal(t, transfers[2].FromEntry.ID, secondPage[0].Entry.ID)
+
+	opening, err := testStore.GetAccountBalanceAt(context.Background(), GetAccountBalanceAtParams{
+		AccountID: account1.ID,
+		At:        arg.StartTime,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, opening)
+
+	closing, err := testStore.GetAccountBalanceAt(context.Background(), GetAccountBalanceAtParams{
+		AccountID: account1.ID,
+		At:        arg.EndTime,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-30, closing)
+}
+
+func TestCreateEntryOrder(t *testing.T) {
+	account := createAccountWithBalance(t, utils.USD, 0)
+
+	n := 10
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testStore.DepositTx(context.Background(), DepositTxParams{
+				AccountID:         account.ID,
+				Amount:            10,
+				ExternalReference: utils.RandomString(12),
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	entries, err := testStore.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		Limit:     int32(n),
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, n)
+
+	// Entries made by transactions that waited for the account lock must not
+	// be dated before the entries they are chained to.
+	for i := 1; i < n; i++ {
+		require.False(t, entries[i].CreatedAt.Time.Before(entries[i-1].CreatedAt.Time))
+		require.Equal(t, entries[i-1].BalanceAfter+10, entries[i].BalanceAfter)
+	}
+}
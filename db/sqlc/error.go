@@ -9,6 +9,38 @@ import (
 
 var ErrorRecordNotFound = pgx.ErrNoRows
 
+var (
+	ErrSameAccount          = errors.New("cannot transfer to the same account")
+	ErrInvalidAmount        = errors.New("amount must be positive")
+	ErrInsufficientFunds    = errors.New("insufficient funds")
+	ErrCurrencyMismatch     = errors.New("accounts currency mismatch")
+	ErrAccountOwnerMismatch = errors.New("from account does not belong to the user")
+
+	ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+	ErrIdempotencyKeyConflict = errors.New("idempotency key already used with a different request")
+
+	ErrUnbalancedJournal = errors.New("journal postings do not balance")
+
+	ErrQuoteNotFound = errors.New("transfer quote not found")
+	ErrQuoteExpired  = errors.New("transfer quote has expired")
+	ErrQuoteUsed     = errors.New("transfer quote has already been used")
+
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+	ErrSessionBlocked     = errors.New("session is blocked")
+	ErrSessionExpired     = errors.New("session has expired")
+
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPCodeReused   = errors.New("two-factor code has already been used")
+
+	ErrAccountUnavailable      = errors.New("account cannot be used")
+	ErrAccountStatusTransition = errors.New("account status cannot be changed")
+	ErrAccountBalanceNotZero   = errors.New("account balance must be zero")
+
+	ErrAlreadyFrozen = errors.New("already frozen")
+	ErrNotFrozen     = errors.New("not frozen")
+)
+
 const (
 	ForeignKeyViolation = "23503"
 	UniqueViolation     = "23505"
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ParseRate parses a decimal exchange rate such as "1.0845".
+func ParseRate(value string) (pgtype.Numeric, error) {
+	var rate pgtype.Numeric
+
+	if err := rate.Scan(value); err != nil {
+		return rate, fmt.Errorf("invalid rate %q: %w", value, err)
+	}
+
+	if !rate.Valid || rate.NaN || rate.InfinityModifier != pgtype.Finite || rate.Int.Sign() <= 0 {
+		return rate, fmt.Errorf("invalid rate %q: must be a positive number", value)
+	}
+
+	return rate, nil
+}
+
+// FormatRate renders a rate the way it is stored in the database.
+func FormatRate(rate pgtype.Numeric) string {
+	if !rate.Valid {
+		return ""
+	}
+
+	value, err := rate.Value()
+	if err != nil {
+		return ""
+	}
+
+	text, _ := value.(string)
+	return text
+}
+
+// ConvertAmount converts amount with rate, rounding down to the nearest
+// minor unit so the bank never pays out more than the quoted rate.
+func ConvertAmount(amount int64, rate pgtype.Numeric) (int64, error) {
+	if !rate.Valid || rate.NaN || rate.InfinityModifier != pgtype.Finite {
+		return 0, fmt.Errorf("invalid rate")
+	}
+
+	result := new(big.Int).Mul(big.NewInt(amount), rate.Int)
+
+	exp := big.NewInt(10)
+	if rate.Exp >= 0 {
+		exp.Exp(exp, big.NewInt(int64(rate.Exp)), nil)
+		result.Mul(result, exp)
+	} else {
+		exp.Exp(exp, big.NewInt(int64(-rate.Exp)), nil)
+		result.Quo(result, exp)
+	}
+
+	if !result.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows")
+	}
+
+	return result.Int64(), nil
+}
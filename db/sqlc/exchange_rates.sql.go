@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: exchange_rates.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createExchangeRate = `-- name: CreateExchangeRate :one
+INSERT INTO exchange_rates (
+    base_currency,
+    quote_currency,
+    rate,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, base_currency, quote_currency, rate, created_by, created_at
+`
+
+type CreateExchangeRateParams struct {
+	BaseCurrency  string         `json:"base_currency"`
+	QuoteCurrency string         `json:"quote_currency"`
+	Rate          pgtype.Numeric `json:"rate"`
+	CreatedBy     string         `json:"created_by"`
+}
+
+func (q *Queries) CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error) {
+	row := q.db.QueryRow(ctx, createExchangeRate,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.Rate,
+		arg.CreatedBy,
+	)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.ID,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLatestExchangeRate = `-- name: GetLatestExchangeRate :one
+SELECT id, base_currency, quote_currency, rate, created_by, created_at FROM exchange_rates
+WHERE base_currency = $1 AND quote_currency = $2
+ORDER BY created_at DESC, id DESC
+LIMIT 1
+`
+
+type GetLatestExchangeRateParams struct {
+	BaseCurrency  string `json:"base_currency"`
+	QuoteCurrency string `json:"quote_currency"`
+}
+
+func (q *Queries) GetLatestExchangeRate(ctx context.Context, arg GetLatestExchangeRateParams) (ExchangeRate, error) {
+	row := q.db.QueryRow(ctx, getLatestExchangeRate, arg.BaseCurrency, arg.QuoteCurrency)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.ID,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmount(t *testing.T) {
+	testCases := []struct {
+		rate     string
+		amount   int64
+		expected int64
+	}{
+		{"1", 100, 100},
+		{"1.0845", 1000, 1084},
+		{"0.5", 3, 1},
+		{"12", 10, 120},
+		{"1.23456789", 100000000, 123456789},
+	}
+
+	for _, tc := range testCases {
+		rate, err := ParseRate(tc.rate)
+		require.NoError(t, err)
+
+		converted, err := ConvertAmount(tc.amount, rate)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, converted, tc.rate)
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	for _, value := range []string{"", "abc", "0", "-1.5", "NaN"} {
+		_, err := ParseRate(value)
+		require.Error(t, err, value)
+	}
+
+	rate, err := ParseRate("1.0845")
+	require.NoError(t, err)
+	require.Equal(t, "1.0845", FormatRate(rate))
+}
+
+func createRandomQuote(t *testing.T, fromAccount, toAccount Account, amount int64, expiresAt time.Time) TransferQuote {
+	rates, err := testStore.CreateExchangeRatesTx(context.Background(), CreateExchangeRatesTxParams{
+		Rates: []CreateExchangeRateParams{
+			{
+				BaseCurrency:  fromAccount.Currency,
+				QuoteCurrency: toAccount.Currency,
+				Rate:          mustParseRate(t, "1.5"),
+				CreatedBy:     fromAccount.Owner,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, rates.Rates, 1)
+	require.Len(t, rates.AuditEvents, 1)
+	require.Equal(t, AuditActionCreateExchangeRate, rates.AuditEvents[0].Action)
+
+	rate := rates.Rates[0]
+	toAmount, err := ConvertAmount(amount, rate.Rate)
+	require.NoError(t, err)
+
+	quote, err := testStore.CreateTransferQuote(context.Background(), CreateTransferQuoteParams{
+		ID:             uuid.New(),
+		Username:       fromAccount.Owner,
+		FromAccountID:  fromAccount.ID,
+		ToAccountID:    toAccount.ID,
+		FromAmount:     amount,
+		FromCurrency:   fromAccount.Currency,
+		ToAmount:       toAmount,
+		ToCurrency:     toAccount.Currency,
+		ExchangeRateID: rate.ID,
+		Rate:           rate.Rate,
+		ExpiresAt:      expiresAt,
+	})
+	require.NoError(t, err)
+
+	return quote
+}
+
+func mustParseRate(t *testing.T, value string) pgtype.Numeric {
+	rate, err := ParseRate(value)
+	require.NoError(t, err)
+	return rate
+}
+
+func TestExchangeTransferTx(t *testing.T) {
+	fromAccount := createAccountWithBalance(t, utils.USD, 1000)
+	toAccount := createAccountWithBalance(t, utils.EUR, 1000)
+
+	quote := createRandomQuote(t, fromAccount, toAccount, 100, time.Now().Add(time.Minute))
+	require.Equal(t, int64(150), quote.ToAmount)
+
+	arg := ExchangeTransferTxParams{
+		QuoteID: quote.ID,
+		Owner:   fromAccount.Owner,
+	}
+
+	result, err := testStore.ExchangeTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.True(t, result.Quote.IsUsed)
+	require.Equal(t, int64(100), result.Transfer.Amount)
+	require.Equal(t, int64(150), result.Transfer.ToAmount.Int64)
+	require.Equal(t, "1.5", FormatRate(result.Transfer.ExchangeRate))
+	require.Equal(t, fromAccount.Balance-100, result.FromAccount.Balance)
+	require.Equal(t, toAccount.Balance+150, result.ToAccount.Balance)
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.Equal(t, int64(150), result.ToEntry.Amount)
+
+	postings := requireJournalBalanced(t, result.Journal)
+	require.Len(t, postings, 4)
+
+	_, err = testStore.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrQuoteUsed)
+}
+
+func TestExchangeTransferTxExpiredQuote(t *testing.T) {
+	fromAccount := createAccountWithBalance(t, utils.USD, 1000)
+	toAccount := createAccountWithBalance(t, utils.EUR, 1000)
+
+	quote := createRandomQuote(t, fromAccount, toAccount, 100, time.Now().Add(-time.Second))
+
+	_, err := testStore.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		QuoteID: quote.ID,
+		Owner:   fromAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrQuoteExpired)
+}
+
+func TestExchangeTransferTxOwnerMismatch(t *testing.T) {
+	fromAccount := createAccountWithBalance(t, utils.USD, 1000)
+	toAccount := createAccountWithBalance(t, utils.EUR, 1000)
+
+	quote := createRandomQuote(t, fromAccount, toAccount, 100, time.Now().Add(time.Minute))
+
+	_, err := testStore.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		QuoteID: quote.ID,
+		Owner:   toAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrAccountOwnerMismatch)
+}
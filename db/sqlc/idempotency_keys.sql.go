@@ -0,0 +1,100 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: idempotency_keys.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    username,
+    key,
+    operation,
+    request_hash
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (username, key) DO NOTHING
+RETURNING username, key, operation, request_hash, response, created_at
+`
+
+type CreateIdempotencyKeyParams struct {
+	Username    string `json:"username"`
+	Key         string `json:"key"`
+	Operation   string `json:"operation"`
+	RequestHash string `json:"request_hash"`
+}
+
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, createIdempotencyKey,
+		arg.Username,
+		arg.Key,
+		arg.Operation,
+		arg.RequestHash,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.Operation,
+		&i.RequestHash,
+		&i.Response,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT username, key, operation, request_hash, response, created_at FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1
+`
+
+type GetIdempotencyKeyParams struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, arg.Username, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.Operation,
+		&i.RequestHash,
+		&i.Response,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateIdempotencyKeyResponse = `-- name: UpdateIdempotencyKeyResponse :one
+UPDATE idempotency_keys
+SET response = $3
+WHERE username = $1 AND key = $2
+RETURNING username, key, operation, request_hash, response, created_at
+`
+
+type UpdateIdempotencyKeyResponseParams struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	Response []byte `json:"response"`
+}
+
+func (q *Queries) UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, updateIdempotencyKeyResponse, arg.Username, arg.Key, arg.Response)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.Operation,
+		&i.RequestHash,
+		&i.Response,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: journals.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createJournal = `-- name: CreateJournal :one
+INSERT INTO journals (
+    type,
+    transfer_id
+) VALUES (
+    $1, $2
+) RETURNING id, type, transfer_id, created_at
+`
+
+type CreateJournalParams struct {
+	Type       string      `json:"type"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+}
+
+func (q *Queries) CreateJournal(ctx context.Context, arg CreateJournalParams) (Journal, error) {
+	row := q.db.QueryRow(ctx, createJournal, arg.Type, arg.TransferID)
+	var i Journal
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getJournal = `-- name: GetJournal :one
+SELECT id, type, transfer_id, created_at FROM journals
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetJournal(ctx context.Context, id int64) (Journal, error) {
+	row := q.db.QueryRow(ctx, getJournal, id)
+	var i Journal
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
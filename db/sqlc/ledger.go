@@ -0,0 +1,140 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Internal accounts that balance the customer side of the ledger. One
+// system account exists per code and currency.
+const (
+	SystemAccountCash        = "cash"
+	SystemAccountFees        = "fees"
+	SystemAccountSuspense    = "suspense"
+	SystemAccountFX          = "fx"
+	SystemAccountAdjustments = "adjustments"
+)
+
+// JournalPosting is one leg of a journal. Exactly one of AccountID and
+// SystemAccount is set.
+type JournalPosting struct {
+	AccountID     int64
+	SystemAccount string
+	EntryID       int64
+	Amount        int64
+	Currency      string
+}
+
+type PostJournalParams struct {
+	Type       string
+	TransferID int64
+	Postings   []JournalPosting
+}
+
+// postJournal records a balanced journal and moves the balance of every
+// system account it touches. Customer account balances are moved by the
+// caller together with the entries the postings refer to. System accounts
+// are locked in code and currency order so that concurrent journals touching
+// the same system accounts, e.g. USD to EUR and EUR to USD exchanges, cannot
+// deadlock.
+func postJournal(ctx context.Context, q *Queries, arg PostJournalParams) (Journal, error) {
+	if err := checkJournalBalanced(arg.Postings); err != nil {
+		return Journal{}, err
+	}
+
+	journal, err := q.CreateJournal(ctx, CreateJournalParams{
+		Type: arg.Type,
+		TransferID: pgtype.Int8{
+			Int64: arg.TransferID,
+			Valid: arg.TransferID != 0,
+		},
+	})
+	if err != nil {
+		return journal, err
+	}
+
+	for _, posting := range sortPostingsForLocking(arg.Postings) {
+		postingArg := CreatePostingParams{
+			JournalID: journal.ID,
+			AccountID: pgtype.Int8{
+				Int64: posting.AccountID,
+				Valid: posting.AccountID != 0,
+			},
+			EntryID: pgtype.Int8{
+				Int64: posting.EntryID,
+				Valid: posting.EntryID != 0,
+			},
+			Amount:   posting.Amount,
+			Currency: posting.Currency,
+		}
+
+		if posting.SystemAccount != "" {
+			systemAccount, err := q.GetSystemAccountForUpdate(ctx, GetSystemAccountForUpdateParams{
+				Code:     posting.SystemAccount,
+				Currency: posting.Currency,
+			})
+			if err != nil {
+				return journal, err
+			}
+
+			_, err = q.AddSystemAccountBalance(ctx, AddSystemAccountBalanceParams{
+				ID:     systemAccount.ID,
+				Amount: posting.Amount,
+			})
+			if err != nil {
+				return journal, err
+			}
+
+			postingArg.SystemAccountID = pgtype.Int8{
+				Int64: systemAccount.ID,
+				Valid: true,
+			}
+		}
+
+		if _, err := q.CreatePosting(ctx, postingArg); err != nil {
+			return journal, err
+		}
+	}
+
+	return journal, nil
+}
+
+// sortPostingsForLocking returns the postings with customer account postings
+// first, in their original order, followed by system account postings sorted
+// by code and currency.
+func sortPostingsForLocking(postings []JournalPosting) []JournalPosting {
+	sorted := slices.Clone(postings)
+	slices.SortStableFunc(sorted, func(a, b JournalPosting) int {
+		if a.SystemAccount == "" || b.SystemAccount == "" {
+			return cmp.Compare(a.SystemAccount, b.SystemAccount)
+		}
+		return cmp.Or(
+			cmp.Compare(a.SystemAccount, b.SystemAccount),
+			cmp.Compare(a.Currency, b.Currency),
+		)
+	})
+	return sorted
+}
+
+func checkJournalBalanced(postings []JournalPosting) error {
+	sums := make(map[string]int64)
+
+	for _, posting := range postings {
+		if (posting.AccountID == 0) == (posting.SystemAccount == "") {
+			return fmt.Errorf("%w: posting must target exactly one account", ErrUnbalancedJournal)
+		}
+		sums[posting.Currency] += posting.Amount
+	}
+
+	for currency, sum := range sums {
+		if sum != 0 {
+			return fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedJournal, currency, sum)
+		}
+	}
+
+	return nil
+}
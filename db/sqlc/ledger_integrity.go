@@ -0,0 +1,182 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ledgerVerificationPageSize = 1000
+
+	// entryHashTimeLayout matches the to_char format of the entry_hash
+	// function of the database.
+	entryHashTimeLayout = "2006-01-02T15:04:05.000000"
+)
+
+// entryHash returns the hash of entry chained to prevHash. It computes the
+// same value as the entry_hash function of the database, so that the chain can
+// be checked without trusting that function.
+func entryHash(prevHash []byte, entry Entry) []byte {
+	transferID := ""
+	if entry.TransferID.Valid {
+		transferID = strconv.FormatInt(entry.TransferID.Int64, 10)
+	}
+
+	content := strings.Join([]string{
+		strconv.FormatInt(entry.AccountID, 10),
+		strconv.FormatInt(entry.Amount, 10),
+		entry.Type,
+		transferID,
+		strconv.FormatInt(entry.BalanceAfter, 10),
+		entry.CreatedAt.Time.Format(entryHashTimeLayout),
+		entry.ExternalReference.String,
+	}, "|")
+
+	hash := sha256.New()
+	hash.Write(prevHash)
+	hash.Write([]byte(content))
+	return hash.Sum(nil)
+}
+
+type VerifyLedgerIntegrityParams struct {
+	// AccountID, when set, limits the check to the entries of one account.
+	AccountID int64
+}
+
+// LedgerBreak is the first entry found not to match the ledger.
+type LedgerBreak struct {
+	AccountID int64
+	// EntryID is zero when the break is a transfer missing its entries.
+	EntryID int64
+	Reason  string
+}
+
+type VerifyLedgerIntegrityResult struct {
+	CheckedEntries int64
+	// Break is nil when the ledger is intact.
+	Break *LedgerBreak
+}
+
+// VerifyLedgerIntegrity walks the hash chain of the entries of every account
+// and checks that the transfers and the account balances still match their
+// entries. It stops at the
+// first broken link. Everything is read from one snapshot, so entries made
+// during the check are not mistaken for breaks.
+func (store *SQLStore) VerifyLedgerIntegrity(ctx context.Context, arg VerifyLedgerIntegrityParams) (VerifyLedgerIntegrityResult, error) {
+	var result VerifyLedgerIntegrityResult
+
+	tx, err := store.connPool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := New(tx)
+
+	accountID := pgtype.Int8{
+		Int64: arg.AccountID,
+		Valid: arg.AccountID != 0,
+	}
+
+	var previous Entry
+
+	for {
+		entries, err := q.ListEntriesForVerification(ctx, ListEntriesForVerificationParams{
+			AfterAccountID: previous.AccountID,
+			AfterID:        previous.ID,
+			AccountID:      accountID,
+			LimitCount:     ledgerVerificationPageSize,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		for _, entry := range entries {
+			var prevHash []byte
+			if entry.AccountID == previous.AccountID {
+				prevHash = previous.Hash
+			}
+
+			if !bytes.Equal(entry.PrevHash, prevHash) {
+				result.Break = &LedgerBreak{
+					AccountID: entry.AccountID,
+					EntryID:   entry.ID,
+					Reason:    "previous hash does not match the previous entry of the account",
+				}
+				return result, nil
+			}
+
+			if !bytes.Equal(entry.Hash, entryHash(prevHash, entry)) {
+				result.Break = &LedgerBreak{
+					AccountID: entry.AccountID,
+					EntryID:   entry.ID,
+					Reason:    "hash does not match the content of the entry",
+				}
+				return result, nil
+			}
+
+			previous = entry
+			result.CheckedEntries++
+		}
+
+		if len(entries) < ledgerVerificationPageSize {
+			break
+		}
+	}
+
+	result.Break, err = findLedgerMismatch(ctx, q, accountID)
+	return result, err
+}
+
+// findLedgerMismatch returns the first entry that does not match its
+// transfer, the first transfer missing an entry or the first account whose
+// balance does not match its last entry, in that order.
+func findLedgerMismatch(ctx context.Context, q *Queries, accountID pgtype.Int8) (*LedgerBreak, error) {
+	entry, err := q.GetMismatchedTransferEntry(ctx, accountID)
+	if err == nil {
+		return &LedgerBreak{
+			AccountID: entry.AccountID,
+			EntryID:   entry.ID,
+			Reason:    "entry does not match transfer " + strconv.FormatInt(entry.TransferID.Int64, 10),
+		}, nil
+	}
+	if !errors.Is(err, ErrorRecordNotFound) {
+		return nil, err
+	}
+
+	transfer, err := q.GetTransferWithoutEntries(ctx, accountID)
+	if err == nil {
+		return &LedgerBreak{
+			AccountID: transfer.FromAccountID,
+			Reason:    "transfer " + strconv.FormatInt(transfer.ID, 10) + " is missing entries",
+		}, nil
+	}
+	if !errors.Is(err, ErrorRecordNotFound) {
+		return nil, err
+	}
+
+	balance, err := q.GetMismatchedAccountBalance(ctx, accountID)
+	if err == nil {
+		return &LedgerBreak{
+			AccountID: balance.AccountID,
+			EntryID:   balance.EntryID,
+			Reason: "account balance " + strconv.FormatInt(balance.Balance, 10) +
+				" does not match the balance after its last entry " + strconv.FormatInt(balance.BalanceAfter, 10),
+		}, nil
+	}
+	if !errors.Is(err, ErrorRecordNotFound) {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryHash(t *testing.T) {
+	entry := Entry{
+		AccountID:    1,
+		Amount:       -10,
+		Type:         EntryTypeTransfer,
+		TransferID:   pgtype.Int8{Int64: 7, Valid: true},
+		BalanceAfter: 90,
+		CreatedAt: pgtype.Timestamp{
+			Time:  time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC),
+			Valid: true,
+		},
+	}
+
+	hash := entryHash(nil, entry)
+	require.Len(t, hash, 32)
+	require.Equal(t, hash, entryHash(nil, entry))
+	require.NotEqual(t, hash, entryHash(hash, entry))
+
+	changed := entry
+	changed.Amount = -11
+	require.NotEqual(t, hash, entryHash(nil, changed))
+}
+
+func TestCreateEntryHashChain(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	var results []TransferTxResult
+	for i := 0; i < 2; i++ {
+		result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+
+	// The hashes made by the database match the ones checked in Go.
+	first := results[0].FromEntry
+	require.Nil(t, first.PrevHash)
+	require.Equal(t, entryHash(nil, first), first.Hash)
+
+	second := results[1].FromEntry
+	require.Equal(t, first.Hash, second.PrevHash)
+	require.Equal(t, entryHash(first.Hash, second), second.Hash)
+
+	result, err := testStore.VerifyLedgerIntegrity(context.Background(), VerifyLedgerIntegrityParams{
+		AccountID: account1.ID,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Break)
+	require.Equal(t, int64(2), result.CheckedEntries)
+}
+
+func TestVerifyLedgerIntegrityEditedEntry(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	transfer, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	connPool := testStore.(*SQLStore).connPool
+
+	_, err = connPool.Exec(context.Background(), "UPDATE entries SET amount = -1 WHERE id = $1", transfer.FromEntry.ID)
+	require.NoError(t, err)
+
+	result, err := testStore.VerifyLedgerIntegrity(context.Background(), VerifyLedgerIntegrityParams{
+		AccountID: account1.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Break)
+	require.Equal(t, account1.ID, result.Break.AccountID)
+	require.Equal(t, transfer.FromEntry.ID, result.Break.EntryID)
+}
+
+func TestVerifyLedgerIntegrityEditedTransfer(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	transfer, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	connPool := testStore.(*SQLStore).connPool
+
+	_, err = connPool.Exec(context.Background(), "UPDATE transfers SET amount = 1 WHERE id = $1", transfer.Transfer.ID)
+	require.NoError(t, err)
+
+	result, err := testStore.VerifyLedgerIntegrity(context.Background(), VerifyLedgerIntegrityParams{
+		AccountID: account2.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Break)
+	require.Equal(t, transfer.ToEntry.ID, result.Break.EntryID)
+}
+
+func TestVerifyLedgerIntegrityMissingEntry(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	transfer, err := testStore.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.VerifyLedgerIntegrity(context.Background(), VerifyLedgerIntegrityParams{
+		AccountID: account2.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Break)
+	require.Equal(t, account1.ID, result.Break.AccountID)
+	require.Zero(t, result.Break.EntryID)
+	require.Contains(t, result.Break.Reason, strconv.FormatInt(transfer.ID, 10))
+}
+
+func TestVerifyLedgerIntegrityEditedBalance(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	transfer, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	connPool := testStore.(*SQLStore).connPool
+
+	_, err = connPool.Exec(context.Background(), "UPDATE accounts SET balance = 1000 WHERE id = $1", account1.ID)
+	require.NoError(t, err)
+
+	result, err := testStore.VerifyLedgerIntegrity(context.Background(), VerifyLedgerIntegrityParams{
+		AccountID: account1.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Break)
+	require.Equal(t, account1.ID, result.Break.AccountID)
+	require.Equal(t, transfer.FromEntry.ID, result.Break.EntryID)
+}
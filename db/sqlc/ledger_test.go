@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func requireJournalBalanced(t *testing.T, journal Journal) []Posting {
+	postings, err := testStore.ListJournalPostings(context.Background(), journal.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, postings)
+
+	sums := make(map[string]int64)
+	for _, posting := range postings {
+		sums[posting.Currency] += posting.Amount
+	}
+
+	for _, sum := range sums {
+		require.Zero(t, sum)
+	}
+
+	return postings
+}
+
+func TestTransferTxJournal(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, EntryTypeTransfer, result.Journal.Type)
+	require.Equal(t, result.Transfer.ID, result.Journal.TransferID.Int64)
+
+	postings := requireJournalBalanced(t, result.Journal)
+	require.Len(t, postings, 2)
+}
+
+func TestDepositWithdrawJournal(t *testing.T) {
+	account := createAccountWithBalance(t, utils.CAD, 0)
+
+	cashBefore, err := testStore.GetSystemAccountForUpdate(context.Background(), GetSystemAccountForUpdateParams{
+		Code:     SystemAccountCash,
+		Currency: utils.CAD,
+	})
+	require.NoError(t, err)
+
+	deposit, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            100,
+		ExternalReference: utils.RandomString(12),
+	})
+	require.NoError(t, err)
+	requireJournalBalanced(t, deposit.Journal)
+
+	withdraw, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            40,
+		ExternalReference: utils.RandomString(12),
+	})
+	require.NoError(t, err)
+	requireJournalBalanced(t, withdraw.Journal)
+
+	cashAfter, err := testStore.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Code:     SystemAccountCash,
+		Currency: utils.CAD,
+	})
+	require.NoError(t, err)
+	require.Equal(t, cashBefore.Balance-60, cashAfter.Balance)
+}
+
+func TestChargeFeeTx(t *testing.T) {
+	account := createAccountWithBalance(t, utils.EUR, 0)
+
+	_, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            50,
+		ExternalReference: utils.RandomString(12),
+	})
+	require.NoError(t, err)
+
+	actor := AuditActor{Username: createRandomUser(t).Username}
+
+	result, err := testStore.ChargeFeeTx(context.Background(), ChargeFeeTxParams{
+		AccountID: account.ID,
+		Amount:    5,
+		Reason:    "monthly maintenance fee",
+		Actor:     actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(45), result.Account.Balance)
+	require.Equal(t, EntryTypeFee, result.Entry.Type)
+	requireJournalBalanced(t, result.Journal)
+
+	require.Equal(t, AuditActionChargeFee, result.AuditEvent.Action)
+	require.Equal(t, actor.Username, result.AuditEvent.Actor.String)
+	require.Equal(t, strconv.FormatInt(account.ID, 10), result.AuditEvent.TargetID)
+	require.Equal(t, "monthly maintenance fee", result.AuditEvent.Reason)
+	require.JSONEq(t, `{"balance": 50}`, string(result.AuditEvent.Before))
+	require.JSONEq(t, `{"balance": 45}`, string(result.AuditEvent.After))
+
+	_, err = testStore.ChargeFeeTx(context.Background(), ChargeFeeTxParams{
+		AccountID: account.ID,
+		Amount:    100,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCheckJournalBalanced(t *testing.T) {
+	err := checkJournalBalanced([]JournalPosting{
+		{AccountID: 1, Amount: -10, Currency: utils.USD},
+		{SystemAccount: SystemAccountCash, Amount: 5, Currency: utils.USD},
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	err = checkJournalBalanced([]JournalPosting{
+		{AccountID: 1, Amount: -10, Currency: utils.USD},
+		{AccountID: 2, SystemAccount: SystemAccountCash, Amount: 10, Currency: utils.USD},
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	err = checkJournalBalanced([]JournalPosting{
+		{AccountID: 1, Amount: -10, Currency: utils.USD},
+		{AccountID: 2, Amount: 10, Currency: utils.USD},
+	})
+	require.NoError(t, err)
+}
+
+func TestSortPostingsForLocking(t *testing.T) {
+	postings := []JournalPosting{
+		{SystemAccount: SystemAccountFX, Amount: 10, Currency: utils.USD},
+		{AccountID: 2, Amount: -10, Currency: utils.USD},
+		{SystemAccount: SystemAccountFX, Amount: -9, Currency: utils.EUR},
+		{AccountID: 1, Amount: 9, Currency: utils.EUR},
+		{SystemAccount: SystemAccountCash, Amount: 0, Currency: utils.USD},
+	}
+
+	sorted := sortPostingsForLocking(postings)
+	require.Equal(t, []JournalPosting{
+		{AccountID: 2, Amount: -10, Currency: utils.USD},
+		{AccountID: 1, Amount: 9, Currency: utils.EUR},
+		{SystemAccount: SystemAccountCash, Amount: 0, Currency: utils.USD},
+		{SystemAccount: SystemAccountFX, Amount: -9, Currency: utils.EUR},
+		{SystemAccount: SystemAccountFX, Amount: 10, Currency: utils.USD},
+	}, sorted)
+	require.Equal(t, SystemAccountFX, postings[0].SystemAccount)
+}
@@ -0,0 +1,14 @@
+package db
+
+const (
+	LoginFailureScopeUsername = "username"
+	LoginFailureScopeClientIP = "client_ip"
+	// LoginFailureScopeTwoFactor counts wrong TOTP and recovery codes of a
+	// username.
+	LoginFailureScopeTwoFactor = "two_factor"
+	// LoginFailureScopePasswordResetEmail and
+	// LoginFailureScopePasswordResetClientIP count every password reset
+	// request, since each one sends an email.
+	LoginFailureScopePasswordResetEmail    = "password_reset_email"
+	LoginFailureScopePasswordResetClientIP = "password_reset_client_ip"
+)
@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: login_failures.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteLoginFailure = `-- name: DeleteLoginFailure :exec
+DELETE FROM login_failures
+WHERE scope = $1 AND key = $2
+`
+
+type DeleteLoginFailureParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) DeleteLoginFailure(ctx context.Context, arg DeleteLoginFailureParams) error {
+	_, err := q.db.Exec(ctx, deleteLoginFailure, arg.Scope, arg.Key)
+	return err
+}
+
+const getLoginFailure = `-- name: GetLoginFailure :one
+SELECT scope, key, failed_count, last_failed_at, locked_until FROM login_failures
+WHERE scope = $1 AND key = $2 LIMIT 1
+`
+
+type GetLoginFailureParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (LoginFailure, error) {
+	row := q.db.QueryRow(ctx, getLoginFailure, arg.Scope, arg.Key)
+	var i LoginFailure
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.FailedCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const lockLogin = `-- name: LockLogin :one
+UPDATE login_failures
+SET locked_until = $1
+WHERE scope = $2 AND key = $3
+RETURNING scope, key, failed_count, last_failed_at, locked_until
+`
+
+type LockLoginParams struct {
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	Scope       string             `json:"scope"`
+	Key         string             `json:"key"`
+}
+
+func (q *Queries) LockLogin(ctx context.Context, arg LockLoginParams) (LoginFailure, error) {
+	row := q.db.QueryRow(ctx, lockLogin, arg.LockedUntil, arg.Scope, arg.Key)
+	var i LoginFailure
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.FailedCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO login_failures (
+    scope,
+    key,
+    failed_count
+) VALUES (
+    $1, $2, 1
+) ON CONFLICT (scope, key) DO UPDATE
+SET failed_count = CASE
+        WHEN login_failures.last_failed_at < $3 THEN 1
+        ELSE login_failures.failed_count + 1
+    END,
+    last_failed_at = now()
+RETURNING scope, key, failed_count, last_failed_at, locked_until
+`
+
+type RecordLoginFailureParams struct {
+	Scope       string    `json:"scope"`
+	Key         string    `json:"key"`
+	ResetBefore time.Time `json:"reset_before"`
+}
+
+// The count restarts when the previous failure is older than reset_before.
+func (q *Queries) RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error) {
+	row := q.db.QueryRow(ctx, recordLoginFailure, arg.Scope, arg.Key, arg.ResetBefore)
+	var i LoginFailure
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.FailedCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
@@ -17,13 +17,154 @@ type Account struct {
 	Balance   int64            `json:"balance"`
 	Currency  string           `json:"currency"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
+	// active, frozen, dormant or closed
+	Status          string    `json:"status"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+}
+
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// null for changes made by the system
+	Actor      pgtype.Text `json:"actor"`
+	Action     string      `json:"action"`
+	TargetType string      `json:"target_type"`
+	TargetID   string      `json:"target_id"`
+	Reason     string      `json:"reason"`
+	Before     []byte      `json:"before"`
+	After      []byte      `json:"after"`
+	ClientIp   string      `json:"client_ip"`
+	UserAgent  string      `json:"user_agent"`
+	CreatedAt  time.Time   `json:"created_at"`
 }
 
 type Entry struct {
-	ID        int64            `json:"id"`
-	AccountID int64            `json:"account_id"`
-	Amount    int64            `json:"amount"`
-	CreatedAt pgtype.Timestamp `json:"created_at"`
+	ID                int64            `json:"id"`
+	AccountID         int64            `json:"account_id"`
+	Amount            int64            `json:"amount"`
+	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	Type              string           `json:"type"`
+	ExternalReference pgtype.Text      `json:"external_reference"`
+	TransferID        pgtype.Int8      `json:"transfer_id"`
+	BalanceAfter      int64            `json:"balance_after"`
+	// hash of the previous entry of the account, null for its first entry
+	PrevHash []byte `json:"prev_hash"`
+	// sha256 of prev_hash and the content of the entry
+	Hash []byte `json:"hash"`
+}
+
+type ExchangeRate struct {
+	ID            int64          `json:"id"`
+	BaseCurrency  string         `json:"base_currency"`
+	QuoteCurrency string         `json:"quote_currency"`
+	Rate          pgtype.Numeric `json:"rate"`
+	CreatedBy     string         `json:"created_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	Username    string    `json:"username"`
+	Key         string    `json:"key"`
+	Operation   string    `json:"operation"`
+	RequestHash string    `json:"request_hash"`
+	Response    []byte    `json:"response"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Journal struct {
+	ID         int64       `json:"id"`
+	Type       string      `json:"type"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type LoginFailure struct {
+	// username, client_ip, two_factor, password_reset_email or password_reset_client_ip
+	Scope        string             `json:"scope"`
+	Key          string             `json:"key"`
+	FailedCount  int32              `json:"failed_count"`
+	LastFailedAt time.Time          `json:"last_failed_at"`
+	LockedUntil  pgtype.Timestamptz `json:"locked_until"`
+}
+
+// previous passwords of users, the current one is in users
+type PasswordHistory struct {
+	ID             int64     `json:"id"`
+	Username       string    `json:"username"`
+	HashedPassword string    `json:"hashed_password"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type PasswordReset struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// sha256 of the code, which is only sent by email
+	SecretCodeHash string    `json:"secret_code_hash"`
+	IsUsed         bool      `json:"is_used"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiredAt      time.Time `json:"expired_at"`
+}
+
+type Posting struct {
+	ID              int64       `json:"id"`
+	JournalID       int64       `json:"journal_id"`
+	AccountID       pgtype.Int8 `json:"account_id"`
+	SystemAccountID pgtype.Int8 `json:"system_account_id"`
+	EntryID         pgtype.Int8 `json:"entry_id"`
+	Amount          int64       `json:"amount"`
+	Currency        string      `json:"currency"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+type RecoveryCode struct {
+	ID        int64              `json:"id"`
+	Username  string             `json:"username"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type RevokedToken struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+type Role struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RolePermission struct {
+	Role string `json:"role"`
+	// resource:action:scope, e.g. accounts:read:any
+	Permission string `json:"permission"`
+}
+
+type ScheduledTransfer struct {
+	ID              int64       `json:"id"`
+	Owner           string      `json:"owner"`
+	FromAccountID   int64       `json:"from_account_id"`
+	ToAccountID     int64       `json:"to_account_id"`
+	Amount          int64       `json:"amount"`
+	CronExpression  pgtype.Text `json:"cron_expression"`
+	IntervalSeconds pgtype.Int8 `json:"interval_seconds"`
+	NextRunAt       time.Time   `json:"next_run_at"`
+	IsActive        bool        `json:"is_active"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64       `json:"id"`
+	ScheduledTransferID int64       `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time   `json:"scheduled_at"`
+	Attempt             int32       `json:"attempt"`
+	Status              string      `json:"status"`
+	TransferID          pgtype.Int8 `json:"transfer_id"`
+	Error               pgtype.Text `json:"error"`
+	CreatedAt           time.Time   `json:"created_at"`
 }
 
 type Session struct {
@@ -35,6 +176,24 @@ type Session struct {
 	IsBlocked    bool             `json:"is_blocked"`
 	ExpiresAt    time.Time        `json:"expires_at"`
 	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	FamilyID     uuid.UUID        `json:"family_id"`
+	IsUsed       bool             `json:"is_used"`
+}
+
+type SystemAccount struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Currency  string    `json:"currency"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TotpCredential struct {
+	Username     string             `json:"username"`
+	Secret       string             `json:"secret"`
+	ConfirmedAt  pgtype.Timestamptz `json:"confirmed_at"`
+	LastUsedStep int64              `json:"last_used_step"`
+	CreatedAt    time.Time          `json:"created_at"`
 }
 
 type Transfer struct {
@@ -43,6 +202,38 @@ type Transfer struct {
 	ToAccountID   int64            `json:"to_account_id"`
 	Amount        int64            `json:"amount"`
 	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	ToAmount      pgtype.Int8      `json:"to_amount"`
+	ExchangeRate  pgtype.Numeric   `json:"exchange_rate"`
+	QuoteID       pgtype.UUID      `json:"quote_id"`
+}
+
+// a null limit is unlimited for a role and falls back to the role for an account
+type TransferLimit struct {
+	// role or account
+	Scope string `json:"scope"`
+	// role name or account id
+	Key           string      `json:"key"`
+	PerTransfer   pgtype.Int8 `json:"per_transfer"`
+	DailyAmount   pgtype.Int8 `json:"daily_amount"`
+	MonthlyAmount pgtype.Int8 `json:"monthly_amount"`
+	HourlyCount   pgtype.Int8 `json:"hourly_count"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+type TransferQuote struct {
+	ID             uuid.UUID      `json:"id"`
+	Username       string         `json:"username"`
+	FromAccountID  int64          `json:"from_account_id"`
+	ToAccountID    int64          `json:"to_account_id"`
+	FromAmount     int64          `json:"from_amount"`
+	FromCurrency   string         `json:"from_currency"`
+	ToAmount       int64          `json:"to_amount"`
+	ToCurrency     string         `json:"to_currency"`
+	ExchangeRateID int64          `json:"exchange_rate_id"`
+	Rate           pgtype.Numeric `json:"rate"`
+	IsUsed         bool           `json:"is_used"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+	CreatedAt      time.Time      `json:"created_at"`
 }
 
 type User struct {
@@ -54,6 +245,12 @@ type User struct {
 	CreatedAt        pgtype.Timestamp `json:"created_at"`
 	IsEmailVerified  bool             `json:"is_email_verified"`
 	Role             string           `json:"role"`
+	// frozen users cannot log in, null when not frozen
+	FrozenAt pgtype.Timestamptz `json:"frozen_at"`
+	// tokens issued before are rejected since they carry the old role
+	RoleChangedAt time.Time `json:"role_changed_at"`
+	// tokens issued before are rejected since frozen_at is cleared on unfreeze
+	UnfrozenAt time.Time `json:"unfrozen_at"`
 }
 
 type VerifyEmail struct {
@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: password_history.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPasswordHistory = `-- name: CreatePasswordHistory :one
+INSERT INTO password_history (username, hashed_password)
+VALUES ($1, $2)
+RETURNING id, username, hashed_password, created_at
+`
+
+type CreatePasswordHistoryParams struct {
+	Username       string `json:"username"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+func (q *Queries) CreatePasswordHistory(ctx context.Context, arg CreatePasswordHistoryParams) (PasswordHistory, error) {
+	row := q.db.QueryRow(ctx, createPasswordHistory, arg.Username, arg.HashedPassword)
+	var i PasswordHistory
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedPassword,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPasswordHistory = `-- name: ListPasswordHistory :many
+SELECT id, username, hashed_password, created_at FROM password_history
+WHERE username = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+type ListPasswordHistoryParams struct {
+	Username string `json:"username"`
+	Limit    int32  `json:"limit"`
+}
+
+func (q *Queries) ListPasswordHistory(ctx context.Context, arg ListPasswordHistoryParams) ([]PasswordHistory, error) {
+	rows, err := q.db.Query(ctx, listPasswordHistory, arg.Username, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PasswordHistory{}
+	for rows.Next() {
+		var i PasswordHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.HashedPassword,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
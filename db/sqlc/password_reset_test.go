@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPasswordReset returns the reset and the code sent by email.
+func createRandomPasswordReset(t *testing.T, user User) (PasswordReset, string) {
+	secretCode, err := utils.NewSecretCode()
+	require.NoError(t, err)
+
+	reset, err := testStore.CreatePasswordReset(context.Background(), CreatePasswordResetParams{
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: utils.HashSecretCode(secretCode),
+	})
+	require.NoError(t, err)
+	require.False(t, reset.IsUsed)
+	require.NotEqual(t, secretCode, reset.SecretCodeHash)
+
+	return reset, secretCode
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+	reset, secretCode := createRandomPasswordReset(t, user)
+	otherReset, otherSecretCode := createRandomPasswordReset(t, user)
+
+	hashedPassword, err := utils.HashedPassword(utils.RandomString(8))
+	require.NoError(t, err)
+
+	result, err := testStore.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		ResetId:        reset.ID,
+		SecretCodeHash: utils.HashSecretCode(secretCode),
+		HashPassword: func(User) (string, error) {
+			return hashedPassword, nil
+		},
+		Actor: AuditActor{ClientIP: "192.0.2.1"},
+	})
+	require.NoError(t, err)
+	require.True(t, result.PasswordReset.IsUsed)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+	require.True(t, result.User.PasswordChangeAt.After(user.PasswordChangeAt))
+	require.Equal(t, int64(1), result.RevokedSessions)
+	require.Equal(t, AuditActionResetPassword, result.AuditEvent.Action)
+	require.Equal(t, user.Username, result.AuditEvent.Actor.String)
+	require.Equal(t, "192.0.2.1", result.AuditEvent.ClientIp)
+
+	blocked, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	// Neither the used code nor any other pending one works again.
+	codes := map[int64]string{reset.ID: secretCode, otherReset.ID: otherSecretCode}
+	for id, code := range codes {
+		_, err = testStore.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+			ResetId:        id,
+			SecretCodeHash: utils.HashSecretCode(code),
+			HashPassword: func(User) (string, error) {
+				return hashedPassword, nil
+			},
+		})
+		require.ErrorIs(t, err, ErrorRecordNotFound)
+	}
+}
+
+func TestResetPasswordTxWrongCode(t *testing.T) {
+	user := createRandomUser(t)
+	reset, secretCode := createRandomPasswordReset(t, user)
+
+	// The code itself is not accepted in place of its hash.
+	_, err := testStore.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		ResetId:        reset.ID,
+		SecretCodeHash: secretCode,
+		HashPassword: func(User) (string, error) {
+			t.Fatal("password hashed before the code was checked")
+			return "", nil
+		},
+	})
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+}
@@ -0,0 +1,83 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: password_resets.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createPasswordReset = `-- name: CreatePasswordReset :one
+INSERT INTO password_resets (username, email, secret_code_hash)
+VALUES ($1, $2, $3)
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expired_at
+`
+
+type CreatePasswordResetParams struct {
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	SecretCodeHash string `json:"secret_code_hash"`
+}
+
+func (q *Queries) CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, createPasswordReset, arg.Username, arg.Email, arg.SecretCodeHash)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCodeHash,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const invalidatePasswordResets = `-- name: InvalidatePasswordResets :exec
+UPDATE password_resets
+SET
+    is_used = true
+WHERE
+    username = $1
+    AND is_used = false
+`
+
+func (q *Queries) InvalidatePasswordResets(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, invalidatePasswordResets, username)
+	return err
+}
+
+const usePasswordReset = `-- name: UsePasswordReset :one
+UPDATE password_resets
+SET
+    is_used = true
+WHERE
+    id = $1
+    AND secret_code_hash = $2
+    AND expired_at > now()
+    AND is_used = false
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expired_at
+`
+
+type UsePasswordResetParams struct {
+	ID             int64  `json:"id"`
+	SecretCodeHash string `json:"secret_code_hash"`
+}
+
+func (q *Queries) UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, usePasswordReset, arg.ID, arg.SecretCodeHash)
+	var i PasswordReset
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCodeHash,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
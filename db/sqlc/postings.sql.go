@@ -0,0 +1,178 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: postings.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPosting = `-- name: CreatePosting :one
+INSERT INTO postings (
+    journal_id,
+    account_id,
+    system_account_id,
+    entry_id,
+    amount,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, journal_id, account_id, system_account_id, entry_id, amount, currency, created_at
+`
+
+type CreatePostingParams struct {
+	JournalID       int64       `json:"journal_id"`
+	AccountID       pgtype.Int8 `json:"account_id"`
+	SystemAccountID pgtype.Int8 `json:"system_account_id"`
+	EntryID         pgtype.Int8 `json:"entry_id"`
+	Amount          int64       `json:"amount"`
+	Currency        string      `json:"currency"`
+}
+
+func (q *Queries) CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error) {
+	row := q.db.QueryRow(ctx, createPosting,
+		arg.JournalID,
+		arg.AccountID,
+		arg.SystemAccountID,
+		arg.EntryID,
+		arg.Amount,
+		arg.Currency,
+	)
+	var i Posting
+	err := row.Scan(
+		&i.ID,
+		&i.JournalID,
+		&i.AccountID,
+		&i.SystemAccountID,
+		&i.EntryID,
+		&i.Amount,
+		&i.Currency,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountLedgerMismatches = `-- name: ListAccountLedgerMismatches :many
+SELECT
+    a.id,
+    a.balance,
+    COALESCE(SUM(p.amount), 0)::bigint AS ledger_balance
+FROM accounts a
+LEFT JOIN postings p ON p.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY a.id
+`
+
+type ListAccountLedgerMismatchesRow struct {
+	ID            int64 `json:"id"`
+	Balance       int64 `json:"balance"`
+	LedgerBalance int64 `json:"ledger_balance"`
+}
+
+func (q *Queries) ListAccountLedgerMismatches(ctx context.Context) ([]ListAccountLedgerMismatchesRow, error) {
+	rows, err := q.db.Query(ctx, listAccountLedgerMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountLedgerMismatchesRow{}
+	for rows.Next() {
+		var i ListAccountLedgerMismatchesRow
+		if err := rows.Scan(&i.ID, &i.Balance, &i.LedgerBalance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJournalPostings = `-- name: ListJournalPostings :many
+SELECT id, journal_id, account_id, system_account_id, entry_id, amount, currency, created_at FROM postings
+WHERE journal_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListJournalPostings(ctx context.Context, journalID int64) ([]Posting, error) {
+	rows, err := q.db.Query(ctx, listJournalPostings, journalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Posting{}
+	for rows.Next() {
+		var i Posting
+		if err := rows.Scan(
+			&i.ID,
+			&i.JournalID,
+			&i.AccountID,
+			&i.SystemAccountID,
+			&i.EntryID,
+			&i.Amount,
+			&i.Currency,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSystemAccountLedgerMismatches = `-- name: ListSystemAccountLedgerMismatches :many
+SELECT
+    s.id,
+    s.code,
+    s.currency,
+    s.balance,
+    COALESCE(SUM(p.amount), 0)::bigint AS ledger_balance
+FROM system_accounts s
+LEFT JOIN postings p ON p.system_account_id = s.id
+GROUP BY s.id
+HAVING s.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY s.id
+`
+
+type ListSystemAccountLedgerMismatchesRow struct {
+	ID            int64  `json:"id"`
+	Code          string `json:"code"`
+	Currency      string `json:"currency"`
+	Balance       int64  `json:"balance"`
+	LedgerBalance int64  `json:"ledger_balance"`
+}
+
+func (q *Queries) ListSystemAccountLedgerMismatches(ctx context.Context) ([]ListSystemAccountLedgerMismatchesRow, error) {
+	rows, err := q.db.Query(ctx, listSystemAccountLedgerMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSystemAccountLedgerMismatchesRow{}
+	for rows.Next() {
+		var i ListSystemAccountLedgerMismatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Currency,
+			&i.Balance,
+			&i.LedgerBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -6,31 +6,140 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddSystemAccountBalance(ctx context.Context, arg AddSystemAccountBalanceParams) (SystemAccount, error)
+	BlockSession(ctx context.Context, id uuid.UUID) (Session, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	// Blocks like BlockUserSessions and returns the family of every session it
+	// blocked.
+	BlockUserSessionFamilies(ctx context.Context, username string) ([]uuid.UUID, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	ConfirmTOTPCredential(ctx context.Context, username string) (TotpCredential, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	// Entries must be created while the account row is locked and before its
+	// balance is updated, so that balance_after is the balance once the entry is
+	// applied and the entry is chained to the last one of the account.
+	// created_at is taken once the lock is held rather than when the transaction
+	// started, so that entries of an account are in the same order by
+	// (created_at, id) as by id.
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error)
+	CreateExchangeTransfer(ctx context.Context, arg CreateExchangeTransferParams) (Transfer, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateJournal(ctx context.Context, arg CreateJournalParams) (Journal, error)
+	CreatePasswordHistory(ctx context.Context, arg CreatePasswordHistoryParams) (PasswordHistory, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferQuote(ctx context.Context, arg CreateTransferQuoteParams) (TransferQuote, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
-	DeleteAccount(ctx context.Context, id int64) error
+	DeactivateAccountScheduledTransfers(ctx context.Context, fromAccountID int64) (int64, error)
+	DeactivateOwnerScheduledTransfers(ctx context.Context, owner string) (int64, error)
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+	DeleteLoginFailure(ctx context.Context, arg DeleteLoginFailureParams) error
+	DeleteRecoveryCodes(ctx context.Context, username string) error
+	DeleteScheduledTransfer(ctx context.Context, id int64) error
+	DeleteTOTPCredential(ctx context.Context, username string) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	// Returns the balance of an account just before the given time.
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetJournal(ctx context.Context, id int64) (Journal, error)
+	GetLatestExchangeRate(ctx context.Context, arg GetLatestExchangeRateParams) (ExchangeRate, error)
+	GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (LoginFailure, error)
+	// Returns the first account whose balance differs from the balance after its
+	// last entry. Accounts without entries are skipped.
+	GetMismatchedAccountBalance(ctx context.Context, accountID pgtype.Int8) (GetMismatchedAccountBalanceRow, error)
+	// Returns the first entry that does not match the transfer it belongs to.
+	GetMismatchedTransferEntry(ctx context.Context, accountID pgtype.Int8) (Entry, error)
+	// Same windows as GetTransferUsage, over every account of the owner.
+	GetOwnerTransferUsage(ctx context.Context, owner string) (GetOwnerTransferUsageRow, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (SystemAccount, error)
+	GetSystemAccountForUpdate(ctx context.Context, arg GetSystemAccountForUpdateParams) (SystemAccount, error)
+	GetTOTPCredential(ctx context.Context, username string) (TotpCredential, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error)
+	GetTransferLimitForUpdate(ctx context.Context, arg GetTransferLimitForUpdateParams) (TransferLimit, error)
+	GetTransferQuote(ctx context.Context, id uuid.UUID) (TransferQuote, error)
+	GetTransferQuoteForUpdate(ctx context.Context, id uuid.UUID) (TransferQuote, error)
+	// Windows are rolling and end now: a day is 24 hours and a month 30 days.
+	GetTransferUsage(ctx context.Context, fromAccountID int64) (GetTransferUsageRow, error)
+	// Returns the first transfer that misses the entry of one of its accounts.
+	GetTransferWithoutEntries(ctx context.Context, accountID pgtype.Int8) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	// Tokens issued before a freeze, an unfreeze or a role change are rejected
+	// like those issued before a password change. The application sets all four
+	// times, from the clock it issues tokens with.
+	GetUserPasswordChangeAt(ctx context.Context, username string) (time.Time, error)
+	InvalidatePasswordResets(ctx context.Context, username string) error
+	IsSessionFamilyBlocked(ctx context.Context, familyID uuid.UUID) (bool, error)
+	IsTokenRevoked(ctx context.Context, id uuid.UUID) (bool, error)
+	ListAccountLedgerMismatches(ctx context.Context) ([]ListAccountLedgerMismatchesRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
+	// Every filter is optional. Events are listed newest first.
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	// Lists the entries in chain order, from the entry after (after_account_id,
+	// after_id). account_id limits the list to one account when set.
+	ListEntriesForVerification(ctx context.Context, arg ListEntriesForVerificationParams) ([]Entry, error)
+	ListJournalPostings(ctx context.Context, journalID int64) ([]Posting, error)
+	ListPasswordHistory(ctx context.Context, arg ListPasswordHistoryParams) ([]PasswordHistory, error)
+	// Roles without permissions are listed with a null permission.
+	ListRolePermissions(ctx context.Context) ([]ListRolePermissionsRow, error)
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListSystemAccountLedgerMismatches(ctx context.Context) ([]ListSystemAccountLedgerMismatchesRow, error)
+	ListSystemAccounts(ctx context.Context) ([]SystemAccount, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
-	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	ListTransfersByOwner(ctx context.Context, arg ListTransfersByOwnerParams) ([]Transfer, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) (LoginFailure, error)
+	// Fees and adjustments are not made by the customer, so they do not keep an
+	// account active.
+	MarkDormantAccounts(ctx context.Context, inactiveSince time.Time) (int64, error)
+	MarkSessionUsed(ctx context.Context, id uuid.UUID) (Session, error)
+	MarkTransferQuoteUsed(ctx context.Context, id uuid.UUID) (TransferQuote, error)
+	// The count restarts when the previous failure is older than reset_before.
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error)
+	RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) (int64, error)
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
+	UpdateScheduledTransferNextRun(ctx context.Context, arg UpdateScheduledTransferNextRunParams) (ScheduledTransfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserFrozenAt(ctx context.Context, arg UpdateUserFrozenAtParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertTOTPCredential(ctx context.Context, arg UpsertTOTPCredentialParams) (TotpCredential, error)
+	UpsertTransferLimit(ctx context.Context, arg UpsertTransferLimitParams) (TransferLimit, error)
+	UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (PasswordReset, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error)
+	UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (TotpCredential, error)
 }
 
 var _ Querier = (*Queries)(nil)
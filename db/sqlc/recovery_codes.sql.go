@@ -0,0 +1,74 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: recovery_codes.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createRecoveryCode = `-- name: CreateRecoveryCode :one
+INSERT INTO recovery_codes (
+    username,
+    code_hash
+) VALUES (
+    $1, $2
+) RETURNING id, username, code_hash, used_at, created_at
+`
+
+type CreateRecoveryCodeParams struct {
+	Username string `json:"username"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error) {
+	row := q.db.QueryRow(ctx, createRecoveryCode, arg.Username, arg.CodeHash)
+	var i RecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE username = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, username)
+	return err
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :one
+UPDATE recovery_codes
+SET used_at = now()
+WHERE username = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+RETURNING id, username, code_hash, used_at, created_at
+`
+
+type UseRecoveryCodeParams struct {
+	Username string `json:"username"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error) {
+	row := q.db.QueryRow(ctx, useRecoveryCode, arg.Username, arg.CodeHash)
+	var i RecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,22 @@
+package db
+
+import "context"
+
+// GetRolePermissions returns every role with its permissions.
+func (store *SQLStore) GetRolePermissions(ctx context.Context) (map[string][]string, error) {
+	rows, err := store.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string][]string)
+	for _, row := range rows {
+		if !row.Permission.Valid {
+			permissions[row.Role] = []string{}
+			continue
+		}
+		permissions[row.Role] = append(permissions[row.Role], row.Permission.String)
+	}
+
+	return permissions, nil
+}
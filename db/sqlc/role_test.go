@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRolePermissions(t *testing.T) {
+	permissions, err := testStore.GetRolePermissions(context.Background())
+	require.NoError(t, err)
+
+	require.Contains(t, permissions, utils.DepositorRole)
+	require.Contains(t, permissions, utils.BankerRole)
+	require.Contains(t, permissions, utils.AdminRole)
+	require.NotContains(t, permissions, utils.TwoFactorChallengeRole)
+
+	require.Contains(t, permissions[utils.BankerRole], utils.PermissionAccountsDepositAny)
+	require.NotContains(t, permissions[utils.DepositorRole], utils.PermissionAccountsDepositAny)
+	require.Contains(t, permissions[utils.AdminRole], utils.PermissionRolesAssignAny)
+}
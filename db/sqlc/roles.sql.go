@@ -0,0 +1,44 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: roles.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listRolePermissions = `-- name: ListRolePermissions :many
+SELECT roles.name AS role, role_permissions.permission FROM roles
+LEFT JOIN role_permissions ON role_permissions.role = roles.name
+ORDER BY roles.name, role_permissions.permission
+`
+
+type ListRolePermissionsRow struct {
+	Role       string      `json:"role"`
+	Permission pgtype.Text `json:"permission"`
+}
+
+// Roles without permissions are listed with a null permission.
+func (q *Queries) ListRolePermissions(ctx context.Context) ([]ListRolePermissionsRow, error) {
+	rows, err := q.db.Query(ctx, listRolePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRolePermissionsRow{}
+	for rows.Next() {
+		var i ListRolePermissionsRow
+		if err := rows.Scan(&i.Role, &i.Permission); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
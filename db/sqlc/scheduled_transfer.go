@@ -0,0 +1,7 @@
+package db
+
+const (
+	ScheduledTransferRunSucceeded = "succeeded"
+	ScheduledTransferRunRetrying  = "retrying"
+	ScheduledTransferRunFailed    = "failed"
+)
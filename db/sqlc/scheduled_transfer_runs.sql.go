@@ -0,0 +1,101 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: scheduled_transfer_runs.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createScheduledTransferRun = `-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+    scheduled_transfer_id,
+    scheduled_at,
+    attempt,
+    status,
+    transfer_id,
+    error
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, scheduled_transfer_id, scheduled_at, attempt, status, transfer_id, error, created_at
+`
+
+type CreateScheduledTransferRunParams struct {
+	ScheduledTransferID int64       `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time   `json:"scheduled_at"`
+	Attempt             int32       `json:"attempt"`
+	Status              string      `json:"status"`
+	TransferID          pgtype.Int8 `json:"transfer_id"`
+	Error               pgtype.Text `json:"error"`
+}
+
+func (q *Queries) CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransferRun,
+		arg.ScheduledTransferID,
+		arg.ScheduledAt,
+		arg.Attempt,
+		arg.Status,
+		arg.TransferID,
+		arg.Error,
+	)
+	var i ScheduledTransferRun
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledTransferID,
+		&i.ScheduledAt,
+		&i.Attempt,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listScheduledTransferRuns = `-- name: ListScheduledTransferRuns :many
+SELECT id, scheduled_transfer_id, scheduled_at, attempt, status, transfer_id, error, created_at FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListScheduledTransferRunsParams struct {
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	Limit               int32 `json:"limit"`
+	Offset              int32 `json:"offset"`
+}
+
+func (q *Queries) ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error) {
+	rows, err := q.db.Query(ctx, listScheduledTransferRuns, arg.ScheduledTransferID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransferRun{}
+	for rows.Next() {
+		var i ScheduledTransferRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledTransferID,
+			&i.ScheduledAt,
+			&i.Attempt,
+			&i.Status,
+			&i.TransferID,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
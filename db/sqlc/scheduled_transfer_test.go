@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomScheduledTransfer(t *testing.T, nextRunAt time.Time) ScheduledTransfer {
+	account1 := createAccountWithBalance(t, utils.USD, 1000)
+	account2 := createAccountWithBalance(t, utils.USD, 1000)
+
+	arg := CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		IntervalSeconds: pgtype.Int8{
+			Int64: 3600,
+			Valid: true,
+		},
+		NextRunAt: nextRunAt,
+	}
+
+	scheduledTransfer, err := testStore.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	require.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	require.True(t, scheduledTransfer.IsActive)
+	require.WithinDuration(t, arg.NextRunAt, scheduledTransfer.NextRunAt, time.Second)
+
+	return scheduledTransfer
+}
+
+func TestDispatchScheduledTransfersTx(t *testing.T) {
+	now := time.Now()
+	due := createRandomScheduledTransfer(t, now.Add(-time.Minute))
+	notDue := createRandomScheduledTransfer(t, now.Add(time.Hour))
+
+	var claimed []int64
+
+	_, err := testStore.DispatchScheduledTransfersTx(context.Background(), DispatchScheduledTransfersTxParams{
+		Now:   now,
+		Limit: 1000,
+		AfterClaim: func(scheduledTransfer ScheduledTransfer) error {
+			claimed = append(claimed, scheduledTransfer.ID)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Contains(t, claimed, due.ID)
+	require.NotContains(t, claimed, notDue.ID)
+
+	updated, err := testStore.GetScheduledTransfer(context.Background(), due.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, due.NextRunAt.Add(time.Hour), updated.NextRunAt, time.Second)
+}
+
+func TestCreateScheduledTransferRun(t *testing.T) {
+	scheduledTransfer := createRandomScheduledTransfer(t, time.Now())
+
+	run, err := testStore.CreateScheduledTransferRun(context.Background(), CreateScheduledTransferRunParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         scheduledTransfer.NextRunAt,
+		Attempt:             1,
+		Status:              ScheduledTransferRunRetrying,
+		Error: pgtype.Text{
+			String: ErrInsufficientFunds.Error(),
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	runs, err := testStore.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               5,
+		Offset:              0,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, run.ID, runs[0].ID)
+	require.Equal(t, ScheduledTransferRunRetrying, runs[0].Status)
+}
+
+func TestUpdateAndDeleteScheduledTransferTx(t *testing.T) {
+	scheduledTransfer := createRandomScheduledTransfer(t, time.Now().Add(time.Hour))
+	actor := AuditActor{Username: scheduledTransfer.Owner}
+
+	updated, err := testStore.UpdateScheduledTransferTx(context.Background(), UpdateScheduledTransferTxParams{
+		UpdateScheduledTransferParams: UpdateScheduledTransferParams{
+			ID:       scheduledTransfer.ID,
+			IsActive: pgtype.Bool{Bool: false, Valid: true},
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.False(t, updated.ScheduledTransfer.IsActive)
+	require.Equal(t, AuditActionUpdateScheduledTransfer, updated.AuditEvent.Action)
+	require.Contains(t, string(updated.AuditEvent.Before), `"is_active": true`)
+
+	deleted, err := testStore.DeleteScheduledTransferTx(context.Background(), DeleteScheduledTransferTxParams{
+		ID:    scheduledTransfer.ID,
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditActionDeleteScheduledTransfer, deleted.AuditEvent.Action)
+	require.Nil(t, deleted.AuditEvent.After)
+
+	_, err = testStore.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+}
@@ -0,0 +1,329 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: scheduled_transfers.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createScheduledTransfer = `-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    cron_expression,
+    interval_seconds,
+    next_run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at
+`
+
+type CreateScheduledTransferParams struct {
+	Owner           string      `json:"owner"`
+	FromAccountID   int64       `json:"from_account_id"`
+	ToAccountID     int64       `json:"to_account_id"`
+	Amount          int64       `json:"amount"`
+	CronExpression  pgtype.Text `json:"cron_expression"`
+	IntervalSeconds pgtype.Int8 `json:"interval_seconds"`
+	NextRunAt       time.Time   `json:"next_run_at"`
+}
+
+func (q *Queries) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.CronExpression,
+		arg.IntervalSeconds,
+		arg.NextRunAt,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CronExpression,
+		&i.IntervalSeconds,
+		&i.NextRunAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deactivateAccountScheduledTransfers = `-- name: DeactivateAccountScheduledTransfers :execrows
+UPDATE scheduled_transfers
+SET
+    is_active = false,
+    updated_at = now()
+WHERE is_active AND (from_account_id = $1 OR to_account_id = $1)
+`
+
+func (q *Queries) DeactivateAccountScheduledTransfers(ctx context.Context, fromAccountID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deactivateAccountScheduledTransfers, fromAccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deactivateOwnerScheduledTransfers = `-- name: DeactivateOwnerScheduledTransfers :execrows
+UPDATE scheduled_transfers
+SET
+    is_active = false,
+    updated_at = now()
+WHERE is_active AND owner = $1
+`
+
+func (q *Queries) DeactivateOwnerScheduledTransfers(ctx context.Context, owner string) (int64, error) {
+	result, err := q.db.Exec(ctx, deactivateOwnerScheduledTransfers, owner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteScheduledTransfer = `-- name: DeleteScheduledTransfer :exec
+DELETE FROM scheduled_transfers
+WHERE id = $1
+`
+
+func (q *Queries) DeleteScheduledTransfer(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteScheduledTransfer, id)
+	return err
+}
+
+const getScheduledTransfer = `-- name: GetScheduledTransfer :one
+SELECT id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CronExpression,
+		&i.IntervalSeconds,
+		&i.NextRunAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransferForUpdate = `-- name: GetScheduledTransferForUpdate :one
+SELECT id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransferForUpdate, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CronExpression,
+		&i.IntervalSeconds,
+		&i.NextRunAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listDueScheduledTransfers = `-- name: ListDueScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at FROM scheduled_transfers
+WHERE is_active AND next_run_at <= $1
+ORDER BY next_run_at
+LIMIT $2
+FOR NO KEY UPDATE SKIP LOCKED
+`
+
+type ListDueScheduledTransfersParams struct {
+	Now        time.Time `json:"now"`
+	LimitCount int32     `json:"limit_count"`
+}
+
+func (q *Queries) ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.Query(ctx, listDueScheduledTransfers, arg.Now, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CronExpression,
+			&i.IntervalSeconds,
+			&i.NextRunAt,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTransfers = `-- name: ListScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type ListScheduledTransfersParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.Query(ctx, listScheduledTransfers, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CronExpression,
+			&i.IntervalSeconds,
+			&i.NextRunAt,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateScheduledTransfer = `-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+    amount = COALESCE($1, amount),
+    cron_expression = CASE WHEN $2::boolean THEN $3 ELSE cron_expression END,
+    interval_seconds = CASE WHEN $2::boolean THEN $4 ELSE interval_seconds END,
+    next_run_at = COALESCE($5, next_run_at),
+    is_active = COALESCE($6, is_active),
+    updated_at = now()
+WHERE id = $7
+RETURNING id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at
+`
+
+type UpdateScheduledTransferParams struct {
+	Amount          pgtype.Int8        `json:"amount"`
+	UpdateSchedule  bool               `json:"update_schedule"`
+	CronExpression  pgtype.Text        `json:"cron_expression"`
+	IntervalSeconds pgtype.Int8        `json:"interval_seconds"`
+	NextRunAt       pgtype.Timestamptz `json:"next_run_at"`
+	IsActive        pgtype.Bool        `json:"is_active"`
+	ID              int64              `json:"id"`
+}
+
+func (q *Queries) UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, updateScheduledTransfer,
+		arg.Amount,
+		arg.UpdateSchedule,
+		arg.CronExpression,
+		arg.IntervalSeconds,
+		arg.NextRunAt,
+		arg.IsActive,
+		arg.ID,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CronExpression,
+		&i.IntervalSeconds,
+		&i.NextRunAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateScheduledTransferNextRun = `-- name: UpdateScheduledTransferNextRun :one
+UPDATE scheduled_transfers
+SET
+    next_run_at = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, is_active, created_at, updated_at
+`
+
+type UpdateScheduledTransferNextRunParams struct {
+	ID        int64     `json:"id"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+func (q *Queries) UpdateScheduledTransferNextRun(ctx context.Context, arg UpdateScheduledTransferNextRunParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, updateScheduledTransferNextRun, arg.ID, arg.NextRunAt)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CronExpression,
+		&i.IntervalSeconds,
+		&i.NextRunAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
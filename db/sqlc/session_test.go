@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func randomSessionParams(username string) CreateSessionParams {
+	id := uuid.New()
+
+	return CreateSessionParams{
+		ID:           id,
+		Username:     username,
+		RefreshToken: utils.RandomString(32),
+		UserAgent:    "test",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     id,
+	}
+}
+
+func createRandomSession(t *testing.T, username string) Session {
+	arg := randomSessionParams(username)
+
+	session, err := testStore.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.FamilyID)
+	require.False(t, session.IsUsed)
+
+	return session
+}
+
+func rotateSessionParams(session Session) RotateSessionTxParams {
+	return RotateSessionTxParams{
+		SessionID: session.ID,
+		NewSession: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     session.Username,
+			RefreshToken: utils.RandomString(32),
+			UserAgent:    session.UserAgent,
+			ClientIp:     session.ClientIp,
+			ExpiresAt:    session.ExpiresAt,
+		},
+	}
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	result, err := testStore.RotateSessionTx(context.Background(), rotateSessionParams(session))
+	require.NoError(t, err)
+	require.Equal(t, session.FamilyID, result.Session.FamilyID)
+	require.False(t, result.Session.IsUsed)
+	require.Equal(t, AuditActionRotateSession, result.AuditEvent.Action)
+	require.Equal(t, result.Session.ID.String(), result.AuditEvent.TargetID)
+
+	old, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, old.IsUsed)
+	require.False(t, old.IsBlocked)
+}
+
+func TestRotateSessionTxReused(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	result, err := testStore.RotateSessionTx(context.Background(), rotateSessionParams(session))
+	require.NoError(t, err)
+
+	_, err = testStore.RotateSessionTx(context.Background(), rotateSessionParams(session))
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	for _, id := range []uuid.UUID{session.ID, result.Session.ID} {
+		s, err := testStore.GetSession(context.Background(), id)
+		require.NoError(t, err)
+		require.True(t, s.IsBlocked)
+	}
+}
+
+func TestRotateSessionTxBlocked(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	// The session is revoked after the caller checked it but before rotation.
+	_, err := testStore.RevokeSessionTx(context.Background(), RevokeSessionTxParams{
+		SessionID: session.ID,
+		Actor:     AuditActor{Username: user.Username},
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.RotateSessionTx(context.Background(), rotateSessionParams(session))
+	require.ErrorIs(t, err, ErrSessionBlocked)
+
+	old, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.False(t, old.IsUsed)
+}
+
+func TestCreateSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := randomSessionParams(user.Username)
+
+	result, err := testStore.CreateSessionTx(context.Background(), CreateSessionTxParams{
+		CreateSessionParams: session,
+		Actor: AuditActor{
+			Username:  user.Username,
+			ClientIP:  session.ClientIp,
+			UserAgent: session.UserAgent,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.ID, result.Session.ID)
+	require.Equal(t, AuditActionCreateSession, result.AuditEvent.Action)
+	require.Equal(t, session.ID.String(), result.AuditEvent.TargetID)
+
+	// Refresh tokens are never copied into the audit log.
+	require.NotContains(t, string(result.AuditEvent.After), session.RefreshToken)
+}
+
+func TestRevokeSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	rotated, err := testStore.RotateSessionTx(context.Background(), rotateSessionParams(session))
+	require.NoError(t, err)
+
+	result, err := testStore.RevokeSessionTx(context.Background(), RevokeSessionTxParams{
+		SessionID: session.ID,
+		Actor:     AuditActor{Username: user.Username},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Session.IsBlocked)
+	require.Equal(t, AuditActionRevokeSession, result.AuditEvent.Action)
+	require.Equal(t, session.ID.String(), result.AuditEvent.TargetID)
+
+	// The session that replaced it belongs to the same login.
+	newest, err := testStore.GetSession(context.Background(), rotated.Session.ID)
+	require.NoError(t, err)
+	require.True(t, newest.IsBlocked)
+}
+
+func TestRevokeUserSessionsTx(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user.Username)
+	session2 := createRandomSession(t, user.Username)
+
+	result, err := testStore.RevokeUserSessionsTx(context.Background(), RevokeUserSessionsTxParams{
+		Username: user.Username,
+		Actor:    AuditActor{Username: user.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.RevokedSessions)
+	require.ElementsMatch(t, []uuid.UUID{session1.FamilyID, session2.FamilyID}, result.FamilyIDs)
+	require.Equal(t, AuditActionRevokeSessions, result.AuditEvent.Action)
+	require.JSONEq(t, `{"revoked_sessions": 2}`, string(result.AuditEvent.After))
+}
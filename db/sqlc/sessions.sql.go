@@ -12,6 +12,88 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockSession = `-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1
+RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, is_used
+`
+
+func (q *Queries) BlockSession(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRow(ctx, blockSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const blockSessionFamily = `-- name: BlockSessionFamily :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1 AND NOT is_blocked
+`
+
+func (q *Queries) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, blockSessionFamily, familyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const blockUserSessionFamilies = `-- name: BlockUserSessionFamilies :many
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked
+RETURNING family_id
+`
+
+// Blocks like BlockUserSessions and returns the family of every session it
+// blocked.
+func (q *Queries) BlockUserSessionFamilies(ctx context.Context, username string) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, blockUserSessionFamilies, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var family_id uuid.UUID
+		if err := rows.Scan(&family_id); err != nil {
+			return nil, err
+		}
+		items = append(items, family_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const blockUserSessions = `-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, username string) (int64, error) {
+	result, err := q.db.Exec(ctx, blockUserSessions, username)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
     id,
@@ -20,10 +102,11 @@ INSERT INTO sessions (
     user_agent,
     client_ip,
     is_blocked,
-    expires_at
+    expires_at,
+    family_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, is_used
 `
 
 type CreateSessionParams struct {
@@ -34,6 +117,7 @@ type CreateSessionParams struct {
 	ClientIp     string    `json:"client_ip"`
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	FamilyID     uuid.UUID `json:"family_id"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -45,6 +129,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.ClientIp,
 		arg.IsBlocked,
 		arg.ExpiresAt,
+		arg.FamilyID,
 	)
 	var i Session
 	err := row.Scan(
@@ -56,12 +141,14 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
 	)
 	return i, err
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at FROM sessions
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, is_used FROM sessions
 WHERE id = $1 LIMIT 1
 `
 
@@ -77,6 +164,111 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const getSessionForUpdate = `-- name: GetSessionForUpdate :one
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, is_used FROM sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRow(ctx, getSessionForUpdate, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const isSessionFamilyBlocked = `-- name: IsSessionFamilyBlocked :one
+SELECT EXISTS (
+    SELECT 1 FROM sessions
+    WHERE family_id = $1 AND is_blocked
+)
+`
+
+func (q *Queries) IsSessionFamilyBlocked(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isSessionFamilyBlocked, familyID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listActiveSessions = `-- name: ListActiveSessions :many
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, is_used FROM sessions
+WHERE username = $1
+    AND NOT is_blocked
+    AND NOT is_used
+    AND expires_at > now()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveSessions(ctx context.Context, username string) ([]Session, error) {
+	rows, err := q.db.Query(ctx, listActiveSessions, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.FamilyID,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markSessionUsed = `-- name: MarkSessionUsed :one
+UPDATE sessions
+SET is_used = true
+WHERE id = $1
+RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, is_used
+`
+
+func (q *Queries) MarkSessionUsed(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRow(ctx, markSessionUsed, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.FamilyID,
+		&i.IsUsed,
 	)
 	return i, err
 }
@@ -9,8 +9,35 @@ import (
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ReplayTransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, bool, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error)
+	ChargeFeeTx(ctx context.Context, arg ChargeFeeTxParams) (ChargeFeeTxResult, error)
+	CreateExchangeRatesTx(ctx context.Context, arg CreateExchangeRatesTxParams) (CreateExchangeRatesTxResult, error)
+	ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (ExchangeTransferTxResult, error)
+	CreateScheduledTransferTx(ctx context.Context, arg CreateScheduledTransferTxParams) (CreateScheduledTransferTxResult, error)
+	UpdateScheduledTransferTx(ctx context.Context, arg UpdateScheduledTransferTxParams) (UpdateScheduledTransferTxResult, error)
+	DeleteScheduledTransferTx(ctx context.Context, arg DeleteScheduledTransferTxParams) (DeleteScheduledTransferTxResult, error)
+	DispatchScheduledTransfersTx(ctx context.Context, arg DispatchScheduledTransfersTxParams) (DispatchScheduledTransfersTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
+	AssignRoleTx(ctx context.Context, arg AssignRoleTxParams) (AssignRoleTxResult, error)
+	GetRolePermissions(ctx context.Context) (map[string][]string, error)
+	FreezeUserTx(ctx context.Context, arg FreezeUserTxParams) (FreezeUserTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error)
+	AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error)
+	CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
+	RevokeSessionTx(ctx context.Context, arg RevokeSessionTxParams) (RevokeSessionTxResult, error)
+	RevokeUserSessionsTx(ctx context.Context, arg RevokeUserSessionsTxParams) (RevokeUserSessionsTxResult, error)
+	EnableTwoFactorTx(ctx context.Context, arg EnableTwoFactorTxParams) (EnableTwoFactorTxResult, error)
+	DisableTwoFactorTx(ctx context.Context, arg DisableTwoFactorTxParams) (DisableTwoFactorTxResult, error)
+	VerifyLedgerIntegrity(ctx context.Context, arg VerifyLedgerIntegrityParams) (VerifyLedgerIntegrityResult, error)
+	GetAccountTransferLimits(ctx context.Context, account Account) (AccountTransferLimits, error)
+	SetTransferLimitsTx(ctx context.Context, arg SetTransferLimitsTxParams) (SetTransferLimitsTxResult, error)
 }
 
 type SQLStore struct {
@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/starjardin/simplebank/utils"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createAccountWithBalance(t, utils.USD, utils.RandomInt(1000, 2000))
+	account2 := createAccountWithBalance(t, utils.USD, utils.RandomInt(1000, 2000))
 
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
@@ -123,8 +124,8 @@ func TestTransferTx(t *testing.T) {
 
 func TestTransferTxDeadlock(t *testing.T) {
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createAccountWithBalance(t, utils.USD, utils.RandomInt(1000, 2000))
+	account2 := createAccountWithBalance(t, utils.USD, utils.RandomInt(1000, 2000))
 
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
@@ -174,3 +175,164 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updateAccount1.Balance)
 	require.Equal(t, account2.Balance, updateAccount2.Balance)
 }
+
+func TestTransferTxSameAccount(t *testing.T) {
+	account := createAccountWithBalance(t, utils.USD, utils.RandomInt(1000, 2000))
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   account.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrSameAccount)
+}
+
+func TestTransferTxInvalidAmount(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	for _, amount := range []int64{0, -10} {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.ErrorIs(t, err, ErrInvalidAmount)
+	}
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 10)
+	account2 := createAccountWithBalance(t, utils.USD, 10)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+func TestTransferTxCurrencyMismatch(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.EUR, 100)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestTransferTxOwnerMismatch(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Owner:         account2.Owner,
+	})
+	require.ErrorIs(t, err, ErrAccountOwnerMismatch)
+}
+
+func TestTransferTxIdempotency(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 100)
+	account2 := createAccountWithBalance(t, utils.USD, 100)
+
+	arg := TransferTxParams{
+		FromAccountID:  account1.ID,
+		ToAccountID:    account2.ID,
+		Amount:         10,
+		Owner:          account1.Owner,
+		IdempotencyKey: utils.RandomString(16),
+	}
+
+	_, replayed, err := testStore.ReplayTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, replayed)
+
+	result1, err := testStore.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	replayedResult, replayed, err := testStore.ReplayTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, result1.Transfer.ID, replayedResult.Transfer.ID)
+
+	result2, err := testStore.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, result1.Transfer.ID, result2.Transfer.ID)
+	require.Equal(t, result1.FromAccount.Balance, result2.FromAccount.Balance)
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-arg.Amount, updatedAccount1.Balance)
+
+	arg.Amount = 20
+	_, err = testStore.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+
+	_, _, err = testStore.ReplayTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+}
+
+func TestDepositTx(t *testing.T) {
+	account := createAccountWithBalance(t, utils.USD, 100)
+	amount := int64(50)
+
+	result, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		ExternalReference: utils.RandomString(12),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+amount, result.Account.Balance)
+	require.Equal(t, amount, result.Entry.Amount)
+	require.Equal(t, EntryTypeDeposit, result.Entry.Type)
+	require.True(t, result.Entry.ExternalReference.Valid)
+
+	_, err = testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		ExternalReference: result.Entry.ExternalReference.String,
+	})
+	require.Error(t, err)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestWithdrawTx(t *testing.T) {
+	account := createAccountWithBalance(t, utils.USD, 100)
+	amount := int64(40)
+
+	result, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		ExternalReference: utils.RandomString(12),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-amount, result.Account.Balance)
+	require.Equal(t, -amount, result.Entry.Amount)
+	require.Equal(t, EntryTypeWithdrawal, result.Entry.Type)
+
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            result.Account.Balance + 1,
+		ExternalReference: utils.RandomString(12),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -0,0 +1,119 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: system_accounts.sql
+
+package db
+
+import (
+	"context"
+)
+
+const addSystemAccountBalance = `-- name: AddSystemAccountBalance :one
+UPDATE system_accounts
+SET balance = balance + $1
+WHERE id = $2
+RETURNING id, code, currency, balance, created_at
+`
+
+type AddSystemAccountBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddSystemAccountBalance(ctx context.Context, arg AddSystemAccountBalanceParams) (SystemAccount, error) {
+	row := q.db.QueryRow(ctx, addSystemAccountBalance, arg.Amount, arg.ID)
+	var i SystemAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSystemAccount = `-- name: GetSystemAccount :one
+SELECT id, code, currency, balance, created_at FROM system_accounts
+WHERE code = $1 AND currency = $2 LIMIT 1
+`
+
+type GetSystemAccountParams struct {
+	Code     string `json:"code"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (SystemAccount, error) {
+	row := q.db.QueryRow(ctx, getSystemAccount, arg.Code, arg.Currency)
+	var i SystemAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSystemAccountForUpdate = `-- name: GetSystemAccountForUpdate :one
+INSERT INTO system_accounts (
+    code,
+    currency
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (code, currency) DO UPDATE
+SET code = EXCLUDED.code
+RETURNING id, code, currency, balance, created_at
+`
+
+type GetSystemAccountForUpdateParams struct {
+	Code     string `json:"code"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetSystemAccountForUpdate(ctx context.Context, arg GetSystemAccountForUpdateParams) (SystemAccount, error) {
+	row := q.db.QueryRow(ctx, getSystemAccountForUpdate, arg.Code, arg.Currency)
+	var i SystemAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listSystemAccounts = `-- name: ListSystemAccounts :many
+SELECT id, code, currency, balance, created_at FROM system_accounts
+ORDER BY code, currency
+`
+
+func (q *Queries) ListSystemAccounts(ctx context.Context) ([]SystemAccount, error) {
+	rows, err := q.db.Query(ctx, listSystemAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SystemAccount{}
+	for rows.Next() {
+		var i SystemAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Currency,
+			&i.Balance,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
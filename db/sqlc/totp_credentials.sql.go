@@ -0,0 +1,117 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: totp_credentials.sql
+
+package db
+
+import (
+	"context"
+)
+
+const confirmTOTPCredential = `-- name: ConfirmTOTPCredential :one
+UPDATE totp_credentials
+SET confirmed_at = now()
+WHERE username = $1
+  AND confirmed_at IS NULL
+RETURNING username, secret, confirmed_at, last_used_step, created_at
+`
+
+func (q *Queries) ConfirmTOTPCredential(ctx context.Context, username string) (TotpCredential, error) {
+	row := q.db.QueryRow(ctx, confirmTOTPCredential, username)
+	var i TotpCredential
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteTOTPCredential = `-- name: DeleteTOTPCredential :exec
+DELETE FROM totp_credentials
+WHERE username = $1
+`
+
+func (q *Queries) DeleteTOTPCredential(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, deleteTOTPCredential, username)
+	return err
+}
+
+const getTOTPCredential = `-- name: GetTOTPCredential :one
+SELECT username, secret, confirmed_at, last_used_step, created_at FROM totp_credentials
+WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetTOTPCredential(ctx context.Context, username string) (TotpCredential, error) {
+	row := q.db.QueryRow(ctx, getTOTPCredential, username)
+	var i TotpCredential
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertTOTPCredential = `-- name: UpsertTOTPCredential :one
+INSERT INTO totp_credentials (
+    username,
+    secret
+) VALUES (
+    $1, $2
+) ON CONFLICT (username) DO UPDATE
+SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE totp_credentials.confirmed_at IS NULL
+RETURNING username, secret, confirmed_at, last_used_step, created_at
+`
+
+type UpsertTOTPCredentialParams struct {
+	Username string `json:"username"`
+	Secret   string `json:"secret"`
+}
+
+func (q *Queries) UpsertTOTPCredential(ctx context.Context, arg UpsertTOTPCredentialParams) (TotpCredential, error) {
+	row := q.db.QueryRow(ctx, upsertTOTPCredential, arg.Username, arg.Secret)
+	var i TotpCredential
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useTOTPStep = `-- name: UseTOTPStep :one
+UPDATE totp_credentials
+SET last_used_step = $1
+WHERE username = $2
+  AND last_used_step < $1
+RETURNING username, secret, confirmed_at, last_used_step, created_at
+`
+
+type UseTOTPStepParams struct {
+	Step     int64  `json:"step"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (TotpCredential, error) {
+	row := q.db.QueryRow(ctx, useTOTPStep, arg.Step, arg.Username)
+	var i TotpCredential
+	err := row.Scan(
+		&i.Username,
+		&i.Secret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
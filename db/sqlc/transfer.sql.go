@@ -7,8 +7,55 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createExchangeTransfer = `-- name: CreateExchangeTransfer :one
+INSERT INTO transfers (
+    from_account_id,
+    to_account_id,
+    amount,
+    to_amount,
+    exchange_rate,
+    quote_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id
+`
+
+type CreateExchangeTransferParams struct {
+	FromAccountID int64          `json:"from_account_id"`
+	ToAccountID   int64          `json:"to_account_id"`
+	Amount        int64          `json:"amount"`
+	ToAmount      pgtype.Int8    `json:"to_amount"`
+	ExchangeRate  pgtype.Numeric `json:"exchange_rate"`
+	QuoteID       pgtype.UUID    `json:"quote_id"`
+}
+
+func (q *Queries) CreateExchangeTransfer(ctx context.Context, arg CreateExchangeTransferParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, createExchangeTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.QuoteID,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
     from_account_id,
@@ -16,7 +63,7 @@ INSERT INTO transfers (
     amount
 ) VALUES (
     $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id
 `
 
 type CreateTransferParams struct {
@@ -34,12 +81,15 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -52,34 +102,76 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id FROM transfers
 WHERE
     from_account_id = $1 OR
-    to_account_id = $2
+    to_account_id = $1
 ORDER BY id
 LIMIT $3
-OFFSET $4
+OFFSET $2
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	AccountID int64 `json:"account_id"`
+	Offset    int32 `json:"offset"`
+	Limit     int32 `json:"limit"`
 }
 
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
-	rows, err := q.db.Query(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
-		arg.Limit,
-		arg.Offset,
-	)
+	rows, err := q.db.Query(ctx, listTransfers, arg.AccountID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.QuoteID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByOwner = `-- name: ListTransfersByOwner :many
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id FROM transfers
+WHERE
+    from_account_id IN (SELECT a.id FROM accounts a WHERE a.owner = $1::varchar) OR
+    to_account_id IN (SELECT a.id FROM accounts a WHERE a.owner = $1::varchar)
+ORDER BY id
+LIMIT $3
+OFFSET $2
+`
+
+type ListTransfersByOwnerParams struct {
+	Owner  string `json:"owner"`
+	Offset int32  `json:"offset"`
+	Limit  int32  `json:"limit"`
+}
+
+func (q *Queries) ListTransfersByOwner(ctx context.Context, arg ListTransfersByOwnerParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersByOwner, arg.Owner, arg.Offset, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +185,9 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.QuoteID,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,287 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Transfer limits are set for a role and can be overridden per account. A role
+// limit holds for all the accounts of the owner together, while an account
+// limit holds for that account on its own.
+const (
+	TransferLimitScopeRole    = "role"
+	TransferLimitScopeAccount = "account"
+)
+
+// Transfer limits, named after their column. Amounts are in the currency of
+// the account the money is sent from, and are added up as they are across
+// the accounts of the owner for a role limit.
+const (
+	TransferLimitPerTransfer   = "per_transfer"
+	TransferLimitDailyAmount   = "daily_amount"
+	TransferLimitMonthlyAmount = "monthly_amount"
+	TransferLimitHourlyCount   = "hourly_count"
+)
+
+// TransferLimits are the limits of a role or an account. An unset role limit
+// is unlimited, an unset account limit falls back to the role.
+type TransferLimits struct {
+	PerTransfer   pgtype.Int8 `json:"per_transfer"`
+	DailyAmount   pgtype.Int8 `json:"daily_amount"`
+	MonthlyAmount pgtype.Int8 `json:"monthly_amount"`
+	HourlyCount   pgtype.Int8 `json:"hourly_count"`
+}
+
+func (limit TransferLimit) Limits() TransferLimits {
+	return TransferLimits{
+		PerTransfer:   limit.PerTransfer,
+		DailyAmount:   limit.DailyAmount,
+		MonthlyAmount: limit.MonthlyAmount,
+		HourlyCount:   limit.HourlyCount,
+	}
+}
+
+// orDefault returns the limits with every unset limit taken from defaults.
+func (limits TransferLimits) orDefault(defaults TransferLimits) TransferLimits {
+	pick := func(limit pgtype.Int8, fallback pgtype.Int8) pgtype.Int8 {
+		if limit.Valid {
+			return limit
+		}
+		return fallback
+	}
+
+	return TransferLimits{
+		PerTransfer:   pick(limits.PerTransfer, defaults.PerTransfer),
+		DailyAmount:   pick(limits.DailyAmount, defaults.DailyAmount),
+		MonthlyAmount: pick(limits.MonthlyAmount, defaults.MonthlyAmount),
+		HourlyCount:   pick(limits.HourlyCount, defaults.HourlyCount),
+	}
+}
+
+type AccountTransferLimits struct {
+	AccountID     int64
+	Role          string
+	RoleLimits    TransferLimits
+	AccountLimits TransferLimits
+	// Effective are the limits transfers from the account are held to.
+	Effective TransferLimits
+	// Usage is what was sent from the account in each window so far.
+	Usage GetTransferUsageRow
+	// OwnerUsage is what was sent from all the accounts of the owner, which
+	// role limits are held to.
+	OwnerUsage GetTransferUsageRow
+}
+
+// GetAccountTransferLimits returns the limits of an account and how much of
+// them is used.
+func (store *SQLStore) GetAccountTransferLimits(ctx context.Context, account Account) (AccountTransferLimits, error) {
+	return getAccountTransferLimits(ctx, store.Queries, account)
+}
+
+func getAccountTransferLimits(ctx context.Context, q *Queries, account Account) (AccountTransferLimits, error) {
+	result := AccountTransferLimits{AccountID: account.ID}
+
+	user, err := q.GetUser(ctx, account.Owner)
+	if err != nil {
+		return result, err
+	}
+	result.Role = user.Role
+
+	result.RoleLimits, err = getTransferLimits(ctx, q, TransferLimitScopeRole, user.Role)
+	if err != nil {
+		return result, err
+	}
+
+	result.AccountLimits, err = getTransferLimits(ctx, q, TransferLimitScopeAccount, strconv.FormatInt(account.ID, 10))
+	if err != nil {
+		return result, err
+	}
+
+	result.Effective = result.AccountLimits.orDefault(result.RoleLimits)
+
+	result.Usage, err = q.GetTransferUsage(ctx, account.ID)
+	if err != nil {
+		return result, err
+	}
+
+	ownerUsage, err := q.GetOwnerTransferUsage(ctx, account.Owner)
+	result.OwnerUsage = GetTransferUsageRow(ownerUsage)
+	return result, err
+}
+
+// effectiveUsage returns what counts against each effective limit: the usage
+// of the account where the account overrides the limit, else that of the
+// owner.
+func (limits AccountTransferLimits) effectiveUsage() GetTransferUsageRow {
+	pick := func(limit pgtype.Int8, accountUsage int64, ownerUsage int64) int64 {
+		if limit.Valid {
+			return accountUsage
+		}
+		return ownerUsage
+	}
+
+	return GetTransferUsageRow{
+		DailyAmount:   pick(limits.AccountLimits.DailyAmount, limits.Usage.DailyAmount, limits.OwnerUsage.DailyAmount),
+		MonthlyAmount: pick(limits.AccountLimits.MonthlyAmount, limits.Usage.MonthlyAmount, limits.OwnerUsage.MonthlyAmount),
+		HourlyCount:   pick(limits.AccountLimits.HourlyCount, limits.Usage.HourlyCount, limits.OwnerUsage.HourlyCount),
+	}
+}
+
+func getTransferLimits(ctx context.Context, q *Queries, scope string, key string) (TransferLimits, error) {
+	limit, err := q.GetTransferLimit(ctx, GetTransferLimitParams{
+		Scope: scope,
+		Key:   key,
+	})
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return TransferLimits{}, nil
+		}
+		return TransferLimits{}, err
+	}
+	return limit.Limits(), nil
+}
+
+type TransferLimitViolation struct {
+	Limit string
+	Max   int64
+	// Value is what the limit would reach with the transfer.
+	Value int64
+}
+
+// TransferLimitError lists the limits a transfer would exceed. It matches
+// ErrTransferLimitExceeded.
+type TransferLimitError struct {
+	AccountID  int64
+	Violations []TransferLimitViolation
+}
+
+func (err *TransferLimitError) Error() string {
+	limits := make([]string, len(err.Violations))
+	for i, violation := range err.Violations {
+		limits[i] = fmt.Sprintf("%s %d would reach %d", violation.Limit, violation.Max, violation.Value)
+	}
+	return fmt.Sprintf("%s: account %d: %s", ErrTransferLimitExceeded, err.AccountID, strings.Join(limits, ", "))
+}
+
+func (err *TransferLimitError) Is(target error) bool {
+	return target == ErrTransferLimitExceeded
+}
+
+// checkTransferLimits rejects sending amount from account when it would
+// exceed one of its limits. The account must be locked so that concurrent
+// transfers are counted. The owner is locked as well, for transfers from
+// their other accounts.
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, amount int64) error {
+	if _, err := q.GetUserForUpdate(ctx, account.Owner); err != nil {
+		return err
+	}
+
+	limits, err := getAccountTransferLimits(ctx, q, account)
+	if err != nil {
+		return err
+	}
+	usage := limits.effectiveUsage()
+
+	var violations []TransferLimitViolation
+	check := func(name string, limit pgtype.Int8, value int64) {
+		if limit.Valid && value > limit.Int64 {
+			violations = append(violations, TransferLimitViolation{
+				Limit: name,
+				Max:   limit.Int64,
+				Value: value,
+			})
+		}
+	}
+
+	check(TransferLimitPerTransfer, limits.Effective.PerTransfer, amount)
+	check(TransferLimitDailyAmount, limits.Effective.DailyAmount, usage.DailyAmount+amount)
+	check(TransferLimitMonthlyAmount, limits.Effective.MonthlyAmount, usage.MonthlyAmount+amount)
+	check(TransferLimitHourlyCount, limits.Effective.HourlyCount, usage.HourlyCount+1)
+
+	if violations != nil {
+		return &TransferLimitError{
+			AccountID:  account.ID,
+			Violations: violations,
+		}
+	}
+	return nil
+}
+
+type SetTransferLimitsTxParams struct {
+	Scope string
+	// Key is the role name or the account id.
+	Key string
+	// Limits replace the previous limits of the role or account.
+	Limits TransferLimits
+	Reason string
+	Actor  AuditActor
+}
+
+type SetTransferLimitsTxResult struct {
+	TransferLimit TransferLimit
+	AuditEvent    AuditEvent
+}
+
+// SetTransferLimitsTx sets the limits of a role or overrides those of an
+// account.
+func (store *SQLStore) SetTransferLimitsTx(ctx context.Context, arg SetTransferLimitsTxParams) (SetTransferLimitsTxResult, error) {
+	var result SetTransferLimitsTxResult
+
+	action, targetType, err := transferLimitsAuditTarget(arg.Scope)
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(ctx, func(q *Queries) error {
+		var before any
+
+		previous, err := q.GetTransferLimitForUpdate(ctx, GetTransferLimitForUpdateParams{
+			Scope: arg.Scope,
+			Key:   arg.Key,
+		})
+		if err == nil {
+			before = previous.Limits()
+		} else if !errors.Is(err, ErrorRecordNotFound) {
+			return err
+		}
+
+		result.TransferLimit, err = q.UpsertTransferLimit(ctx, UpsertTransferLimitParams{
+			Scope:         arg.Scope,
+			Key:           arg.Key,
+			PerTransfer:   arg.Limits.PerTransfer,
+			DailyAmount:   arg.Limits.DailyAmount,
+			MonthlyAmount: arg.Limits.MonthlyAmount,
+			HourlyCount:   arg.Limits.HourlyCount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     action,
+			TargetType: targetType,
+			TargetID:   arg.Key,
+			Reason:     arg.Reason,
+			Before:     before,
+			After:      result.TransferLimit.Limits(),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func transferLimitsAuditTarget(scope string) (action string, targetType string, err error) {
+	switch scope {
+	case TransferLimitScopeRole:
+		return AuditActionUpdateRoleTransferLimits, AuditTargetRole, nil
+	case TransferLimitScopeAccount:
+		return AuditActionUpdateAccountTransferLimits, AuditTargetAccount, nil
+	}
+	return "", "", fmt.Errorf("unknown transfer limit scope %q", scope)
+}
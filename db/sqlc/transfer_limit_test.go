@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setAccountTransferLimits(t *testing.T, account Account, limits TransferLimits) SetTransferLimitsTxResult {
+	banker := createRandomUser(t)
+
+	result, err := testStore.SetTransferLimitsTx(context.Background(), SetTransferLimitsTxParams{
+		Scope:  TransferLimitScopeAccount,
+		Key:    strconv.FormatInt(account.ID, 10),
+		Limits: limits,
+		Reason: "test",
+		Actor:  AuditActor{Username: banker.Username},
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func limit(value int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: value, Valid: true}
+}
+
+func TestSetTransferLimitsTx(t *testing.T) {
+	account := createRandomAccount(t)
+
+	result := setAccountTransferLimits(t, account, TransferLimits{DailyAmount: limit(500)})
+	require.Equal(t, TransferLimitScopeAccount, result.TransferLimit.Scope)
+	require.Equal(t, limit(500), result.TransferLimit.DailyAmount)
+	require.False(t, result.TransferLimit.PerTransfer.Valid)
+	require.Equal(t, AuditActionUpdateAccountTransferLimits, result.AuditEvent.Action)
+	require.Equal(t, strconv.FormatInt(account.ID, 10), result.AuditEvent.TargetID)
+	require.Nil(t, result.AuditEvent.Before)
+
+	result = setAccountTransferLimits(t, account, TransferLimits{PerTransfer: limit(50)})
+	require.Equal(t, limit(50), result.TransferLimit.PerTransfer)
+	require.False(t, result.TransferLimit.DailyAmount.Valid)
+	require.JSONEq(t, `{"per_transfer": null, "daily_amount": 500, "monthly_amount": null, "hourly_count": null}`, string(result.AuditEvent.Before))
+	require.JSONEq(t, `{"per_transfer": 50, "daily_amount": null, "monthly_amount": null, "hourly_count": null}`, string(result.AuditEvent.After))
+
+	_, err := testStore.SetTransferLimitsTx(context.Background(), SetTransferLimitsTxParams{
+		Scope: "user",
+		Key:   account.Owner,
+	})
+	require.Error(t, err)
+}
+
+func TestGetAccountTransferLimits(t *testing.T) {
+	account := createAccountWithBalance(t, utils.USD, 1000)
+	other := createAccountWithBalance(t, utils.USD, 0)
+
+	limits, err := testStore.GetAccountTransferLimits(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, utils.DepositorRole, limits.Role)
+	require.True(t, limits.RoleLimits.PerTransfer.Valid)
+	require.Equal(t, TransferLimits{}, limits.AccountLimits)
+	require.Equal(t, limits.RoleLimits, limits.Effective)
+	require.Zero(t, limits.Usage.HourlyCount)
+
+	setAccountTransferLimits(t, account, TransferLimits{PerTransfer: limit(100)})
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   other.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+
+	limits, err = testStore.GetAccountTransferLimits(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, limit(100), limits.Effective.PerTransfer)
+	require.Equal(t, limits.RoleLimits.DailyAmount, limits.Effective.DailyAmount)
+	require.Equal(t, int64(30), limits.Usage.DailyAmount)
+	require.Equal(t, int64(30), limits.Usage.MonthlyAmount)
+	require.Equal(t, int64(1), limits.Usage.HourlyCount)
+}
+
+func TestTransferLimitsPerOwner(t *testing.T) {
+	usdAccount := createAccountWithBalance(t, utils.USD, 1000)
+	other := createAccountWithBalance(t, utils.USD, 0)
+
+	eurAccount, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    usdAccount.Owner,
+		Balance:  1000,
+		Currency: utils.EUR,
+	})
+	require.NoError(t, err)
+
+	eurOther, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    other.Owner,
+		Balance:  0,
+		Currency: utils.EUR,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: usdAccount.ID,
+		ToAccountID:   other.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+
+	limits, err := testStore.GetAccountTransferLimits(context.Background(), eurAccount)
+	require.NoError(t, err)
+	require.Equal(t, GetTransferUsageRow{}, limits.Usage)
+	require.Equal(t, GetTransferUsageRow{DailyAmount: 30, MonthlyAmount: 30, HourlyCount: 1}, limits.OwnerUsage)
+
+	// Role limits hold for all the accounts of the owner together.
+	hourlyCount := limits.RoleLimits.HourlyCount.Int64
+	for i := int64(1); i < hourlyCount; i++ {
+		_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: usdAccount.ID,
+			ToAccountID:   other.ID,
+			Amount:        1,
+		})
+		require.NoError(t, err)
+	}
+
+	eurTransfer := func() error {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: eurAccount.ID,
+			ToAccountID:   eurOther.ID,
+			Amount:        1,
+		})
+		return err
+	}
+
+	var limitErr *TransferLimitError
+	require.True(t, errors.As(eurTransfer(), &limitErr))
+	require.Equal(t, []TransferLimitViolation{
+		{Limit: TransferLimitHourlyCount, Max: hourlyCount, Value: hourlyCount + 1},
+	}, limitErr.Violations)
+
+	// An account limit holds for the account on its own.
+	setAccountTransferLimits(t, eurAccount, TransferLimits{HourlyCount: limit(1)})
+	require.NoError(t, eurTransfer())
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	account1 := createAccountWithBalance(t, utils.USD, 1000)
+	account2 := createAccountWithBalance(t, utils.USD, 0)
+
+	setAccountTransferLimits(t, account1, TransferLimits{
+		PerTransfer: limit(50),
+		DailyAmount: limit(100),
+		HourlyCount: limit(2),
+	})
+
+	transfer := func(amount int64) error {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	err := transfer(60)
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	var limitErr *TransferLimitError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, account1.ID, limitErr.AccountID)
+	require.Equal(t, []TransferLimitViolation{
+		{Limit: TransferLimitPerTransfer, Max: 50, Value: 60},
+	}, limitErr.Violations)
+
+	require.NoError(t, transfer(50))
+	require.NoError(t, transfer(40))
+
+	err = transfer(20)
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, []TransferLimitViolation{
+		{Limit: TransferLimitDailyAmount, Max: 100, Value: 110},
+		{Limit: TransferLimitHourlyCount, Max: 2, Value: 3},
+	}, limitErr.Violations)
+
+	// Money sent to the account does not count against its limits.
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000-50-40+10), updatedAccount1.Balance)
+}
@@ -0,0 +1,160 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer_limits.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getOwnerTransferUsage = `-- name: GetOwnerTransferUsage :one
+SELECT
+    COALESCE(SUM(t.amount) FILTER (WHERE t.created_at > now() - interval '1 day'), 0)::bigint AS daily_amount,
+    COALESCE(SUM(t.amount), 0)::bigint AS monthly_amount,
+    COUNT(*) FILTER (WHERE t.created_at > now() - interval '1 hour') AS hourly_count
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND t.created_at > now() - interval '30 days'
+`
+
+type GetOwnerTransferUsageRow struct {
+	DailyAmount   int64 `json:"daily_amount"`
+	MonthlyAmount int64 `json:"monthly_amount"`
+	HourlyCount   int64 `json:"hourly_count"`
+}
+
+// Same windows as GetTransferUsage, over every account of the owner.
+func (q *Queries) GetOwnerTransferUsage(ctx context.Context, owner string) (GetOwnerTransferUsageRow, error) {
+	row := q.db.QueryRow(ctx, getOwnerTransferUsage, owner)
+	var i GetOwnerTransferUsageRow
+	err := row.Scan(&i.DailyAmount, &i.MonthlyAmount, &i.HourlyCount)
+	return i, err
+}
+
+const getTransferLimit = `-- name: GetTransferLimit :one
+SELECT scope, key, per_transfer, daily_amount, monthly_amount, hourly_count, updated_at FROM transfer_limits
+WHERE scope = $1 AND key = $2 LIMIT 1
+`
+
+type GetTransferLimitParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error) {
+	row := q.db.QueryRow(ctx, getTransferLimit, arg.Scope, arg.Key)
+	var i TransferLimit
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.PerTransfer,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.HourlyCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTransferLimitForUpdate = `-- name: GetTransferLimitForUpdate :one
+SELECT scope, key, per_transfer, daily_amount, monthly_amount, hourly_count, updated_at FROM transfer_limits
+WHERE scope = $1 AND key = $2 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+type GetTransferLimitForUpdateParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) GetTransferLimitForUpdate(ctx context.Context, arg GetTransferLimitForUpdateParams) (TransferLimit, error) {
+	row := q.db.QueryRow(ctx, getTransferLimitForUpdate, arg.Scope, arg.Key)
+	var i TransferLimit
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.PerTransfer,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.HourlyCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTransferUsage = `-- name: GetTransferUsage :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE created_at > now() - interval '1 day'), 0)::bigint AS daily_amount,
+    COALESCE(SUM(amount), 0)::bigint AS monthly_amount,
+    COUNT(*) FILTER (WHERE created_at > now() - interval '1 hour') AS hourly_count
+FROM transfers
+WHERE from_account_id = $1 AND created_at > now() - interval '30 days'
+`
+
+type GetTransferUsageRow struct {
+	DailyAmount   int64 `json:"daily_amount"`
+	MonthlyAmount int64 `json:"monthly_amount"`
+	HourlyCount   int64 `json:"hourly_count"`
+}
+
+// Windows are rolling and end now: a day is 24 hours and a month 30 days.
+func (q *Queries) GetTransferUsage(ctx context.Context, fromAccountID int64) (GetTransferUsageRow, error) {
+	row := q.db.QueryRow(ctx, getTransferUsage, fromAccountID)
+	var i GetTransferUsageRow
+	err := row.Scan(&i.DailyAmount, &i.MonthlyAmount, &i.HourlyCount)
+	return i, err
+}
+
+const upsertTransferLimit = `-- name: UpsertTransferLimit :one
+INSERT INTO transfer_limits (
+    scope,
+    key,
+    per_transfer,
+    daily_amount,
+    monthly_amount,
+    hourly_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) ON CONFLICT (scope, key) DO UPDATE
+SET per_transfer = EXCLUDED.per_transfer,
+    daily_amount = EXCLUDED.daily_amount,
+    monthly_amount = EXCLUDED.monthly_amount,
+    hourly_count = EXCLUDED.hourly_count,
+    updated_at = now()
+RETURNING scope, key, per_transfer, daily_amount, monthly_amount, hourly_count, updated_at
+`
+
+type UpsertTransferLimitParams struct {
+	Scope         string      `json:"scope"`
+	Key           string      `json:"key"`
+	PerTransfer   pgtype.Int8 `json:"per_transfer"`
+	DailyAmount   pgtype.Int8 `json:"daily_amount"`
+	MonthlyAmount pgtype.Int8 `json:"monthly_amount"`
+	HourlyCount   pgtype.Int8 `json:"hourly_count"`
+}
+
+func (q *Queries) UpsertTransferLimit(ctx context.Context, arg UpsertTransferLimitParams) (TransferLimit, error) {
+	row := q.db.QueryRow(ctx, upsertTransferLimit,
+		arg.Scope,
+		arg.Key,
+		arg.PerTransfer,
+		arg.DailyAmount,
+		arg.MonthlyAmount,
+		arg.HourlyCount,
+	)
+	var i TransferLimit
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.PerTransfer,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.HourlyCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
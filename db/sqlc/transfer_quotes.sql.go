@@ -0,0 +1,160 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer_quotes.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTransferQuote = `-- name: CreateTransferQuote :one
+INSERT INTO transfer_quotes (
+    id,
+    username,
+    from_account_id,
+    to_account_id,
+    from_amount,
+    from_currency,
+    to_amount,
+    to_currency,
+    exchange_rate_id,
+    rate,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING id, username, from_account_id, to_account_id, from_amount, from_currency, to_amount, to_currency, exchange_rate_id, rate, is_used, expires_at, created_at
+`
+
+type CreateTransferQuoteParams struct {
+	ID             uuid.UUID      `json:"id"`
+	Username       string         `json:"username"`
+	FromAccountID  int64          `json:"from_account_id"`
+	ToAccountID    int64          `json:"to_account_id"`
+	FromAmount     int64          `json:"from_amount"`
+	FromCurrency   string         `json:"from_currency"`
+	ToAmount       int64          `json:"to_amount"`
+	ToCurrency     string         `json:"to_currency"`
+	ExchangeRateID int64          `json:"exchange_rate_id"`
+	Rate           pgtype.Numeric `json:"rate"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+}
+
+func (q *Queries) CreateTransferQuote(ctx context.Context, arg CreateTransferQuoteParams) (TransferQuote, error) {
+	row := q.db.QueryRow(ctx, createTransferQuote,
+		arg.ID,
+		arg.Username,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.FromAmount,
+		arg.FromCurrency,
+		arg.ToAmount,
+		arg.ToCurrency,
+		arg.ExchangeRateID,
+		arg.Rate,
+		arg.ExpiresAt,
+	)
+	var i TransferQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.FromAmount,
+		&i.FromCurrency,
+		&i.ToAmount,
+		&i.ToCurrency,
+		&i.ExchangeRateID,
+		&i.Rate,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferQuote = `-- name: GetTransferQuote :one
+SELECT id, username, from_account_id, to_account_id, from_amount, from_currency, to_amount, to_currency, exchange_rate_id, rate, is_used, expires_at, created_at FROM transfer_quotes
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransferQuote(ctx context.Context, id uuid.UUID) (TransferQuote, error) {
+	row := q.db.QueryRow(ctx, getTransferQuote, id)
+	var i TransferQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.FromAmount,
+		&i.FromCurrency,
+		&i.ToAmount,
+		&i.ToCurrency,
+		&i.ExchangeRateID,
+		&i.Rate,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferQuoteForUpdate = `-- name: GetTransferQuoteForUpdate :one
+SELECT id, username, from_account_id, to_account_id, from_amount, from_currency, to_amount, to_currency, exchange_rate_id, rate, is_used, expires_at, created_at FROM transfer_quotes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferQuoteForUpdate(ctx context.Context, id uuid.UUID) (TransferQuote, error) {
+	row := q.db.QueryRow(ctx, getTransferQuoteForUpdate, id)
+	var i TransferQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.FromAmount,
+		&i.FromCurrency,
+		&i.ToAmount,
+		&i.ToCurrency,
+		&i.ExchangeRateID,
+		&i.Rate,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markTransferQuoteUsed = `-- name: MarkTransferQuoteUsed :one
+UPDATE transfer_quotes
+SET is_used = true
+WHERE id = $1
+RETURNING id, username, from_account_id, to_account_id, from_amount, from_currency, to_amount, to_currency, exchange_rate_id, rate, is_used, expires_at, created_at
+`
+
+func (q *Queries) MarkTransferQuoteUsed(ctx context.Context, id uuid.UUID) (TransferQuote, error) {
+	row := q.db.QueryRow(ctx, markTransferQuoteUsed, id)
+	var i TransferQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.FromAmount,
+		&i.FromCurrency,
+		&i.ToAmount,
+		&i.ToCurrency,
+		&i.ExchangeRateID,
+		&i.Rate,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
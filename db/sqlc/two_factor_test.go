@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createPendingTOTPCredential(t *testing.T, username string) TotpCredential {
+	credential, err := testStore.UpsertTOTPCredential(context.Background(), UpsertTOTPCredentialParams{
+		Username: username,
+		Secret:   utils.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.False(t, credential.ConfirmedAt.Valid)
+
+	return credential
+}
+
+func TestEnableTwoFactorTx(t *testing.T) {
+	user := createRandomUser(t)
+	createPendingTOTPCredential(t, user.Username)
+
+	hashes := []string{utils.HashRecoveryCode("code-1"), utils.HashRecoveryCode("code-2")}
+
+	result, err := testStore.EnableTwoFactorTx(context.Background(), EnableTwoFactorTxParams{
+		Username:           user.Username,
+		Step:               100,
+		RecoveryCodeHashes: hashes,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Credential.ConfirmedAt.Valid)
+	require.Equal(t, int64(100), result.Credential.LastUsedStep)
+	require.Len(t, result.RecoveryCodes, len(hashes))
+	require.Equal(t, AuditActionEnableTwoFactor, result.AuditEvent.Action)
+	require.JSONEq(t, `{"enabled": true, "recovery_codes": 2}`, string(result.AuditEvent.After))
+
+	// A confirmed secret cannot be replaced.
+	_, err = testStore.UpsertTOTPCredential(context.Background(), UpsertTOTPCredentialParams{
+		Username: user.Username,
+		Secret:   utils.RandomString(32),
+	})
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+
+	// Steps and recovery codes can only be used once.
+	_, err = testStore.UseTOTPStep(context.Background(), UseTOTPStepParams{
+		Username: user.Username,
+		Step:     100,
+	})
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+
+	arg := UseRecoveryCodeParams{
+		Username: user.Username,
+		CodeHash: hashes[0],
+	}
+
+	code, err := testStore.UseRecoveryCode(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, code.UsedAt.Valid)
+
+	_, err = testStore.UseRecoveryCode(context.Background(), arg)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+
+	disabled, err := testStore.DisableTwoFactorTx(context.Background(), DisableTwoFactorTxParams{
+		Username: user.Username,
+		Actor:    AuditActor{Username: user.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditActionDisableTwoFactor, disabled.AuditEvent.Action)
+
+	_, err = testStore.GetTOTPCredential(context.Background(), user.Username)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+}
+
+func TestEnableTwoFactorTxReusedStep(t *testing.T) {
+	user := createRandomUser(t)
+	createPendingTOTPCredential(t, user.Username)
+
+	_, err := testStore.UseTOTPStep(context.Background(), UseTOTPStepParams{
+		Username: user.Username,
+		Step:     100,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.EnableTwoFactorTx(context.Background(), EnableTwoFactorTxParams{
+		Username: user.Username,
+		Step:     100,
+	})
+	require.ErrorIs(t, err, ErrTOTPCodeReused)
+}
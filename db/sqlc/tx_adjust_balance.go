@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type AdjustBalanceTxParams struct {
+	AccountID int64
+	// Amount is added to the balance, it is negative to take money out.
+	Amount int64
+	Reason string
+	Actor  AuditActor
+}
+
+type AdjustBalanceTxResult struct {
+	Account    Account
+	Entry      Entry
+	Journal    Journal
+	AuditEvent AuditEvent
+}
+
+type balanceState struct {
+	Balance int64 `json:"balance"`
+}
+
+// AdjustBalanceTx corrects the balance of an account against the adjustments
+// system account. The balance cannot become negative, and frozen or dormant
+// accounts can be corrected but closed ones cannot.
+func (store *SQLStore) AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error) {
+	var result AdjustBalanceTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountOpen(account); err != nil {
+			return err
+		}
+
+		if account.Balance+arg.Amount < 0 {
+			return fmt.Errorf("%w: account %d balance %d is less than %d", ErrInsufficientFunds, account.ID, account.Balance, -arg.Amount)
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: arg.AccountID,
+			Amount:    arg.Amount,
+			Type:      EntryTypeAdjustment,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Journal, err = postJournal(ctx, q, PostJournalParams{
+			Type: EntryTypeAdjustment,
+			Postings: []JournalPosting{
+				{
+					AccountID: arg.AccountID,
+					EntryID:   result.Entry.ID,
+					Amount:    arg.Amount,
+					Currency:  account.Currency,
+				},
+				{
+					SystemAccount: SystemAccountAdjustments,
+					Amount:        -arg.Amount,
+					Currency:      account.Currency,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionAdjustBalance,
+			TargetType: AuditTargetAccount,
+			TargetID:   strconv.FormatInt(arg.AccountID, 10),
+			Reason:     arg.Reason,
+			Before:     balanceState{Balance: account.Balance},
+			After:      balanceState{Balance: result.Account.Balance},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type AssignRoleTxParams struct {
+	Username string
+	Role     string
+	Actor    AuditActor
+}
+
+type AssignRoleTxResult struct {
+	User User
+	// RevokedSessions is the number of sessions of the user that were blocked.
+	RevokedSessions int64
+	AuditEvent      AuditEvent
+}
+
+type roleState struct {
+	Role string `json:"role"`
+}
+
+// AssignRoleTx changes the role of a user and blocks their sessions, so that
+// refresh tokens carrying the old role cannot be renewed. Access tokens issued
+// before the change are rejected by their role_changed_at.
+func (store *SQLStore) AssignRoleTx(ctx context.Context, arg AssignRoleTxParams) (AssignRoleTxResult, error) {
+	var result AssignRoleTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		previous, err := q.GetUserForUpdate(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{
+			Username:      arg.Username,
+			Role:          arg.Role,
+			RoleChangedAt: time.Now(),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionAssignRole,
+			TargetType: AuditTargetUser,
+			TargetID:   arg.Username,
+			Before:     roleState{Role: previous.Role},
+			After:      roleState{Role: result.User.Role},
+		})
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type ChargeFeeTxParams struct {
+	AccountID int64      `json:"account_id"`
+	Amount    int64      `json:"amount"`
+	Reason    string     `json:"reason"`
+	Actor     AuditActor `json:"-"`
+}
+
+type ChargeFeeTxResult struct {
+	Account    Account    `json:"account"`
+	Entry      Entry      `json:"entry"`
+	Journal    Journal    `json:"journal"`
+	AuditEvent AuditEvent `json:"audit_event"`
+}
+
+// ChargeFeeTx moves a fee from a customer account into the fees system
+// account of the same currency.
+func (store *SQLStore) ChargeFeeTx(ctx context.Context, arg ChargeFeeTxParams) (ChargeFeeTxResult, error) {
+	var result ChargeFeeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountOpen(account); err != nil {
+			return err
+		}
+
+		if account.Balance < arg.Amount {
+			return fmt.Errorf("%w: account %d balance %d is less than %d", ErrInsufficientFunds, account.ID, account.Balance, arg.Amount)
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: arg.AccountID,
+			Amount:    -arg.Amount,
+			Type:      EntryTypeFee,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     arg.AccountID,
+			Amount: -arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Journal, err = postJournal(ctx, q, PostJournalParams{
+			Type: EntryTypeFee,
+			Postings: []JournalPosting{
+				{
+					AccountID: arg.AccountID,
+					EntryID:   result.Entry.ID,
+					Amount:    -arg.Amount,
+					Currency:  account.Currency,
+				},
+				{
+					SystemAccount: SystemAccountFees,
+					Amount:        arg.Amount,
+					Currency:      account.Currency,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionChargeFee,
+			TargetType: AuditTargetAccount,
+			TargetID:   strconv.FormatInt(arg.AccountID, 10),
+			Reason:     arg.Reason,
+			Before:     balanceState{Balance: account.Balance},
+			After:      balanceState{Balance: result.Account.Balance},
+		})
+		return err
+	})
+
+	return result, err
+}
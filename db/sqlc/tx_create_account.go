@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"strconv"
+)
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	IdempotencyKey string
+	// Actor is recorded in the audit log. It is not part of the request, so
+	// that a retry from another client is still the same account.
+	Actor AuditActor
+}
+
+type CreateAccountTxResult struct {
+	Account    Account    `json:"account"`
+	AuditEvent AuditEvent `json:"audit_event"`
+}
+
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
+	var result CreateAccountTxResult
+
+	idempotency := IdempotencyParams{
+		Username: arg.Owner,
+		Key:      arg.IdempotencyKey,
+	}
+
+	err := store.execIdempotentTx(ctx, idempotency, "create_account", arg.CreateAccountParams, &result, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionCreateAccount,
+			TargetType: AuditTargetAccount,
+			TargetID:   strconv.FormatInt(result.Account.ID, 10),
+			After:      result.Account,
+		})
+
+		return err
+	})
+
+	return result, err
+}
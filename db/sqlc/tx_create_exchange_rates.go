@@ -0,0 +1,46 @@
+package db
+
+import "context"
+
+type CreateExchangeRatesTxParams struct {
+	Rates []CreateExchangeRateParams
+	Actor AuditActor
+}
+
+type CreateExchangeRatesTxResult struct {
+	Rates       []ExchangeRate
+	AuditEvents []AuditEvent
+}
+
+// CreateExchangeRatesTx stores a batch of rates and records one audit event
+// per rate.
+func (store *SQLStore) CreateExchangeRatesTx(ctx context.Context, arg CreateExchangeRatesTxParams) (CreateExchangeRatesTxResult, error) {
+	var result CreateExchangeRatesTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, rateArg := range arg.Rates {
+			rate, err := q.CreateExchangeRate(ctx, rateArg)
+			if err != nil {
+				return err
+			}
+
+			result.Rates = append(result.Rates, rate)
+
+			event, err := recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+				Action:     AuditActionCreateExchangeRate,
+				TargetType: AuditTargetExchangeRate,
+				TargetID:   rate.BaseCurrency + "/" + rate.QuoteCurrency,
+				After:      rate,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.AuditEvents = append(result.AuditEvents, event)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
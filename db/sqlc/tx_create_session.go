@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateSessionTxParams struct {
+	CreateSessionParams
+	Actor AuditActor
+}
+
+type CreateSessionTxResult struct {
+	Session    Session
+	AuditEvent AuditEvent
+}
+
+// sessionState is what the audit log keeps of a session, without its refresh
+// token.
+type sessionState struct {
+	ID        uuid.UUID `json:"id"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func newSessionState(session Session) sessionState {
+	return sessionState{
+		ID:        session.ID,
+		FamilyID:  session.FamilyID,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
+
+// CreateSessionTx creates the session of a login.
+func (store *SQLStore) CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error) {
+	var result CreateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Session, err = q.CreateSession(ctx, arg.CreateSessionParams)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionCreateSession,
+			TargetType: AuditTargetSession,
+			TargetID:   result.Session.ID.String(),
+			After:      newSessionState(result.Session),
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -4,11 +4,14 @@ import "context"
 
 type CreateUserTxParams struct {
 	CreateUserParams
+	// Actor is recorded as the new user when it has no username.
+	Actor       AuditActor
 	AfterCreate func(user User) error
 }
 
 type CreateUserTxResult struct {
-	User User
+	User       User
+	AuditEvent AuditEvent
 }
 
 func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
@@ -23,6 +26,26 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 			return err
 		}
 
+		actor := arg.Actor
+		if actor.Username == "" {
+			actor.Username = result.User.Username
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, actor, auditEventParams{
+			Action:     AuditActionCreateUser,
+			TargetType: AuditTargetUser,
+			TargetID:   result.User.Username,
+			After:      newUserState(result.User),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+
 		return arg.AfterCreate(result.User)
 
 	})
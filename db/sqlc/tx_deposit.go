@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DepositTxParams struct {
+	AccountID         int64      `json:"account_id"`
+	Amount            int64      `json:"amount"`
+	ExternalReference string     `json:"external_reference"`
+	Actor             AuditActor `json:"-"`
+}
+
+type DepositTxResult struct {
+	Account    Account    `json:"account"`
+	Entry      Entry      `json:"entry"`
+	Journal    Journal    `json:"journal"`
+	AuditEvent AuditEvent `json:"audit_event"`
+}
+
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountCredit(account); err != nil {
+			return err
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: arg.AccountID,
+			Amount:    arg.Amount,
+			Type:      EntryTypeDeposit,
+			ExternalReference: pgtype.Text{
+				String: arg.ExternalReference,
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Journal, err = postJournal(ctx, q, PostJournalParams{
+			Type: EntryTypeDeposit,
+			Postings: []JournalPosting{
+				{
+					AccountID: arg.AccountID,
+					EntryID:   result.Entry.ID,
+					Amount:    arg.Amount,
+					Currency:  account.Currency,
+				},
+				{
+					SystemAccount: SystemAccountCash,
+					Amount:        -arg.Amount,
+					Currency:      account.Currency,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionDeposit,
+			TargetType: AuditTargetAccount,
+			TargetID:   strconv.FormatInt(arg.AccountID, 10),
+			Before:     balanceState{Balance: account.Balance},
+			After:      balanceState{Balance: result.Account.Balance},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/starjardin/simplebank/utils"
+)
+
+type DispatchScheduledTransfersTxParams struct {
+	Now   time.Time
+	Limit int32
+	// AfterClaim is called for every due scheduled transfer, before its next
+	// run is stored. It receives the run that is due.
+	AfterClaim func(scheduledTransfer ScheduledTransfer) error
+}
+
+type DispatchScheduledTransfersTxResult struct {
+	ScheduledTransfers []ScheduledTransfer
+}
+
+// DispatchScheduledTransfersTx claims the scheduled transfers that are due and
+// moves each of them to its next run. Rows locked by a concurrent dispatcher
+// are skipped.
+func (store *SQLStore) DispatchScheduledTransfersTx(ctx context.Context, arg DispatchScheduledTransfersTxParams) (DispatchScheduledTransfersTxResult, error) {
+	var result DispatchScheduledTransfersTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		dueTransfers, err := q.ListDueScheduledTransfers(ctx, ListDueScheduledTransfersParams{
+			Now:        arg.Now,
+			LimitCount: arg.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, scheduledTransfer := range dueTransfers {
+			schedule, err := utils.ParseSchedule(scheduledTransfer.CronExpression.String, scheduledTransfer.IntervalSeconds.Int64)
+			if err != nil {
+				return fmt.Errorf("scheduled transfer %d: %w", scheduledTransfer.ID, err)
+			}
+
+			if err := arg.AfterClaim(scheduledTransfer); err != nil {
+				return err
+			}
+
+			_, err = q.UpdateScheduledTransferNextRun(ctx, UpdateScheduledTransferNextRunParams{
+				ID:        scheduledTransfer.ID,
+				NextRunAt: utils.NextRunAfter(schedule, scheduledTransfer.NextRunAt, arg.Now),
+			})
+			if err != nil {
+				return err
+			}
+
+			result.ScheduledTransfers = append(result.ScheduledTransfers, scheduledTransfer)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ExchangeTransferTxParams struct {
+	QuoteID uuid.UUID `json:"quote_id"`
+	// Owner must be the user the quote was issued to.
+	Owner string     `json:"owner"`
+	Actor AuditActor `json:"-"`
+}
+
+type ExchangeTransferTxResult struct {
+	Transfer    Transfer      `json:"transfer"`
+	Quote       TransferQuote `json:"quote"`
+	FromAccount Account       `json:"from_account"`
+	ToAccount   Account       `json:"to_account"`
+	FromEntry   Entry         `json:"from_entry"`
+	ToEntry     Entry         `json:"to_entry"`
+	Journal     Journal       `json:"journal"`
+	AuditEvent  AuditEvent    `json:"audit_event"`
+}
+
+// CheckTransferQuote returns why owner cannot use the quote, if they cannot.
+func CheckTransferQuote(quote TransferQuote, owner string) error {
+	if quote.Username != owner {
+		return ErrAccountOwnerMismatch
+	}
+
+	if quote.IsUsed {
+		return ErrQuoteUsed
+	}
+
+	if time.Now().After(quote.ExpiresAt) {
+		return ErrQuoteExpired
+	}
+
+	return nil
+}
+
+// ExchangeTransferTx executes a transfer between accounts of different
+// currencies at the rate locked in by a quote. The quote can only be used once
+// and only before it expires.
+func (store *SQLStore) ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (ExchangeTransferTxResult, error) {
+	var result ExchangeTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		quote, err := q.GetTransferQuoteForUpdate(ctx, arg.QuoteID)
+		if err != nil {
+			if errors.Is(err, ErrorRecordNotFound) {
+				return ErrQuoteNotFound
+			}
+			return err
+		}
+
+		if err := CheckTransferQuote(quote, arg.Owner); err != nil {
+			return err
+		}
+
+		fromAccount, toAccount, err := lockAccounts(ctx, q, quote.FromAccountID, quote.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if fromAccount.Owner != quote.Username {
+			return ErrAccountOwnerMismatch
+		}
+
+		if err := checkAccountDebit(fromAccount); err != nil {
+			return err
+		}
+
+		if err := checkAccountCredit(toAccount); err != nil {
+			return err
+		}
+
+		if fromAccount.Currency != quote.FromCurrency || toAccount.Currency != quote.ToCurrency {
+			return fmt.Errorf("%w: quote no longer matches the account currencies", ErrCurrencyMismatch)
+		}
+
+		if fromAccount.Balance < quote.FromAmount {
+			return fmt.Errorf("%w: account %d balance %d is less than %d", ErrInsufficientFunds, fromAccount.ID, fromAccount.Balance, quote.FromAmount)
+		}
+
+		if err := checkTransferLimits(ctx, q, fromAccount, quote.FromAmount); err != nil {
+			return err
+		}
+
+		result.Quote, err = q.MarkTransferQuoteUsed(ctx, quote.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.CreateExchangeTransfer(ctx, CreateExchangeTransferParams{
+			FromAccountID: quote.FromAccountID,
+			ToAccountID:   quote.ToAccountID,
+			Amount:        quote.FromAmount,
+			ToAmount: pgtype.Int8{
+				Int64: quote.ToAmount,
+				Valid: true,
+			},
+			ExchangeRate: quote.Rate,
+			QuoteID: pgtype.UUID{
+				Bytes: quote.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: quote.FromAccountID,
+			Amount:    -quote.FromAmount,
+			Type:      EntryTypeExchange,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: quote.ToAccountID,
+			Amount:    quote.ToAmount,
+			Type:      EntryTypeExchange,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if quote.FromAccountID < quote.ToAccountID {
+			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, quote.FromAccountID, -quote.FromAmount, quote.ToAccountID, quote.ToAmount)
+		} else {
+			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, quote.ToAccountID, quote.ToAmount, quote.FromAccountID, -quote.FromAmount)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Journal, err = postJournal(ctx, q, PostJournalParams{
+			Type:       EntryTypeExchange,
+			TransferID: result.Transfer.ID,
+			Postings: []JournalPosting{
+				{
+					AccountID: quote.FromAccountID,
+					EntryID:   result.FromEntry.ID,
+					Amount:    -quote.FromAmount,
+					Currency:  quote.FromCurrency,
+				},
+				{
+					SystemAccount: SystemAccountFX,
+					Amount:        quote.FromAmount,
+					Currency:      quote.FromCurrency,
+				},
+				{
+					SystemAccount: SystemAccountFX,
+					Amount:        -quote.ToAmount,
+					Currency:      quote.ToCurrency,
+				},
+				{
+					AccountID: quote.ToAccountID,
+					EntryID:   result.ToEntry.ID,
+					Amount:    quote.ToAmount,
+					Currency:  quote.ToCurrency,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionCreateTransfer,
+			TargetType: AuditTargetTransfer,
+			TargetID:   strconv.FormatInt(result.Transfer.ID, 10),
+			After:      result.Transfer,
+		})
+		return err
+	})
+
+	return result, err
+}
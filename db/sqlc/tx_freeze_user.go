@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type FreezeUserTxParams struct {
+	Username string
+	// Frozen is true to freeze the user and false to unfreeze them.
+	Frozen bool
+	Reason string
+	Actor  AuditActor
+}
+
+type FreezeUserTxResult struct {
+	User User
+	// RevokedSessions is the number of sessions of the user that were blocked.
+	RevokedSessions int64
+	// DeactivatedScheduledTransfers is the number of scheduled transfers of
+	// the user that were stopped.
+	DeactivatedScheduledTransfers int64
+	AuditEvent                    AuditEvent
+}
+
+// FreezeUserTx freezes or unfreezes a user. Freezing also blocks the sessions
+// of the user and stops their scheduled transfers, which stay inactive after
+// an unfreeze until the user resumes them.
+func (store *SQLStore) FreezeUserTx(ctx context.Context, arg FreezeUserTxParams) (FreezeUserTxResult, error) {
+	var result FreezeUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		if user.FrozenAt.Valid == arg.Frozen {
+			return frozenStateError(arg.Frozen)
+		}
+
+		changedAt := time.Now()
+
+		result.User, err = q.UpdateUserFrozenAt(ctx, UpdateUserFrozenAtParams{
+			Username: arg.Username,
+			FrozenAt: pgtype.Timestamptz{
+				Time:  changedAt,
+				Valid: arg.Frozen,
+			},
+			UnfrozenAt: pgtype.Timestamptz{
+				Time:  changedAt,
+				Valid: !arg.Frozen,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		action := AuditActionUnfreezeUser
+		if arg.Frozen {
+			action = AuditActionFreezeUser
+
+			result.RevokedSessions, err = q.BlockUserSessions(ctx, arg.Username)
+			if err != nil {
+				return err
+			}
+
+			result.DeactivatedScheduledTransfers, err = q.DeactivateOwnerScheduledTransfers(ctx, arg.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     action,
+			TargetType: AuditTargetUser,
+			TargetID:   arg.Username,
+			Reason:     arg.Reason,
+			Before:     frozenState{FrozenAt: user.FrozenAt},
+			After:      frozenState{FrozenAt: result.User.FrozenAt},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type frozenState struct {
+	FrozenAt pgtype.Timestamptz `json:"frozen_at"`
+}
+
+func frozenStateError(frozen bool) error {
+	if frozen {
+		return ErrAlreadyFrozen
+	}
+	return ErrNotFrozen
+}
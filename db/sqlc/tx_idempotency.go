@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type IdempotencyParams struct {
+	Username string
+	Key      string
+}
+
+// execIdempotentTx runs fn in a transaction guarded by an idempotency key.
+// The first call with a key stores response; later calls with the same key and
+// request load the stored response into response instead of running fn again.
+func (store *SQLStore) execIdempotentTx(
+	ctx context.Context,
+	idempotency IdempotencyParams,
+	operation string,
+	request any,
+	response any,
+	fn func(*Queries) error,
+) error {
+	if idempotency.Key == "" {
+		return store.execTx(ctx, fn)
+	}
+
+	requestHash, err := hashRequest(operation, request)
+	if err != nil {
+		return err
+	}
+
+	return store.execTx(ctx, func(q *Queries) error {
+		_, err := q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+			Username:    idempotency.Username,
+			Key:         idempotency.Key,
+			Operation:   operation,
+			RequestHash: requestHash,
+		})
+
+		if errors.Is(err, ErrorRecordNotFound) {
+			return replayIdempotencyKey(ctx, q, idempotency, requestHash, response)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := fn(q); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("failed to marshal idempotent response: %w", err)
+		}
+
+		_, err = q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+			Username: idempotency.Username,
+			Key:      idempotency.Key,
+			Response: data,
+		})
+		return err
+	})
+}
+
+// loadIdempotentResponse loads into response the stored response of a
+// completed call with the same key and request, without running anything. It
+// reports false when the key is unused or its call has not completed yet.
+func (store *SQLStore) loadIdempotentResponse(
+	ctx context.Context,
+	idempotency IdempotencyParams,
+	operation string,
+	request any,
+	response any,
+) (bool, error) {
+	if idempotency.Key == "" {
+		return false, nil
+	}
+
+	requestHash, err := hashRequest(operation, request)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := store.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: idempotency.Username,
+		Key:      idempotency.Key,
+	})
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if key.RequestHash != requestHash {
+		return false, ErrIdempotencyKeyConflict
+	}
+
+	if len(key.Response) == 0 {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(key.Response, response)
+}
+
+func replayIdempotencyKey(
+	ctx context.Context,
+	q *Queries,
+	idempotency IdempotencyParams,
+	requestHash string,
+	response any,
+) error {
+	key, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: idempotency.Username,
+		Key:      idempotency.Key,
+	})
+	if err != nil {
+		return err
+	}
+
+	if key.RequestHash != requestHash || len(key.Response) == 0 {
+		return ErrIdempotencyKeyConflict
+	}
+
+	return json.Unmarshal(key.Response, response)
+}
+
+func hashRequest(operation string, request any) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal idempotent request: %w", err)
+	}
+
+	sum := sha256.Sum256(append([]byte(operation+":"), data...))
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ResetPasswordTxParams struct {
+	ResetId int64
+	// SecretCodeHash is the hash of the code sent by email, see
+	// utils.HashSecretCode.
+	SecretCodeHash string
+	// HashPassword is called with the user once the code is accepted. It can
+	// reject the new password by returning an error, otherwise it returns the
+	// hash to store. Hashing waits for the code so that wrong codes are cheap
+	// to turn down.
+	HashPassword func(user User) (string, error)
+	// Actor is recorded as the user of the reset code, who is not logged in.
+	Actor AuditActor
+}
+
+type ResetPasswordTxResult struct {
+	User          User
+	PasswordReset PasswordReset
+	// RevokedSessions is the number of sessions of the user that were blocked.
+	RevokedSessions int64
+	AuditEvent      AuditEvent
+}
+
+// ResetPasswordTx uses a password reset code to set a new password and keeps
+// the previous one in the password history. Every other pending reset code and
+// every session of the user is revoked, and the failed logins of the user are
+// forgotten.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.PasswordReset, err = q.UsePasswordReset(ctx, UsePasswordResetParams{
+			ID:             arg.ResetId,
+			SecretCodeHash: arg.SecretCodeHash,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		username := result.PasswordReset.Username
+
+		previous, err := q.GetUser(ctx, username)
+
+		if err != nil {
+			return err
+		}
+
+		hashedPassword, err := arg.HashPassword(previous)
+
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: username,
+			HashedPassword: pgtype.Text{
+				String: hashedPassword,
+				Valid:  true,
+			},
+			PasswordChangeAt: pgtype.Timestamptz{
+				Time:  time.Now(),
+				Valid: true,
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePasswordHistory(ctx, CreatePasswordHistoryParams{
+			Username:       username,
+			HashedPassword: previous.HashedPassword,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidatePasswordResets(ctx, username)
+
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, username)
+
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteLoginFailure(ctx, DeleteLoginFailureParams{
+			Scope: LoginFailureScopeUsername,
+			Key:   username,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		actor := arg.Actor
+		actor.Username = username
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, actor, auditEventParams{
+			Action:     AuditActionResetPassword,
+			TargetType: AuditTargetUser,
+			TargetID:   username,
+			Before:     newUserState(previous),
+			After:      newUserState(result.User),
+		})
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type RevokeSessionTxParams struct {
+	SessionID uuid.UUID
+	Actor     AuditActor
+}
+
+type RevokeSessionTxResult struct {
+	Session    Session
+	AuditEvent AuditEvent
+}
+
+// RevokeSessionTx blocks a session together with the other sessions of its
+// family, so that no refresh token of the login can be renewed.
+func (store *SQLStore) RevokeSessionTx(ctx context.Context, arg RevokeSessionTxParams) (RevokeSessionTxResult, error) {
+	var result RevokeSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Session, err = q.BlockSession(ctx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.BlockSessionFamily(ctx, result.Session.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionRevokeSession,
+			TargetType: AuditTargetSession,
+			TargetID:   result.Session.ID.String(),
+			After:      newSessionState(result.Session),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type RevokeUserSessionsTxParams struct {
+	Username string
+	Actor    AuditActor
+}
+
+type RevokeUserSessionsTxResult struct {
+	// RevokedSessions is the number of sessions of the user that were blocked.
+	RevokedSessions int64
+	// FamilyIDs are the session families that were blocked, so that the
+	// access tokens issued for them can be rejected at once.
+	FamilyIDs  []uuid.UUID
+	AuditEvent AuditEvent
+}
+
+type revokedSessionsState struct {
+	RevokedSessions int64 `json:"revoked_sessions"`
+}
+
+// RevokeUserSessionsTx blocks every session of a user.
+func (store *SQLStore) RevokeUserSessionsTx(ctx context.Context, arg RevokeUserSessionsTxParams) (RevokeUserSessionsTxResult, error) {
+	var result RevokeUserSessionsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		familyIDs, err := q.BlockUserSessionFamilies(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions = int64(len(familyIDs))
+		for _, familyID := range familyIDs {
+			if !slices.Contains(result.FamilyIDs, familyID) {
+				result.FamilyIDs = append(result.FamilyIDs, familyID)
+			}
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionRevokeSessions,
+			TargetType: AuditTargetUser,
+			TargetID:   arg.Username,
+			After:      revokedSessionsState{RevokedSessions: result.RevokedSessions},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RotateSessionTxParams struct {
+	SessionID uuid.UUID
+	// NewSession replaces the session. Its family is set to the family of the
+	// rotated session.
+	NewSession CreateSessionParams
+	Actor      AuditActor
+}
+
+type RotateSessionTxResult struct {
+	Session    Session
+	AuditEvent AuditEvent
+}
+
+// RotateSessionTx marks a session as used and creates the session that
+// replaces it. Rotating a session that was already used means its refresh
+// token was replayed, so the whole family is blocked and ErrRefreshTokenReused
+// is returned. The session is checked again under its lock, so a revocation
+// that commits after the caller looked at it still wins.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	reused := false
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		session, err := q.GetSessionForUpdate(ctx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if session.IsBlocked {
+			return ErrSessionBlocked
+		}
+
+		if time.Now().After(session.ExpiresAt) {
+			return ErrSessionExpired
+		}
+
+		if session.IsUsed {
+			reused = true
+			_, err = q.BlockSessionFamily(ctx, session.FamilyID)
+			return err
+		}
+
+		_, err = q.MarkSessionUsed(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+
+		newSession := arg.NewSession
+		newSession.FamilyID = session.FamilyID
+
+		result.Session, err = q.CreateSession(ctx, newSession)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionRotateSession,
+			TargetType: AuditTargetSession,
+			TargetID:   result.Session.ID.String(),
+			Before:     newSessionState(session),
+			After:      newSessionState(result.Session),
+		})
+		return err
+	})
+
+	if err == nil && reused {
+		return result, ErrRefreshTokenReused
+	}
+
+	return result, err
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"strconv"
+)
+
+type CreateScheduledTransferTxParams struct {
+	CreateScheduledTransferParams
+	Actor AuditActor
+}
+
+type CreateScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer
+	AuditEvent        AuditEvent
+}
+
+// CreateScheduledTransferTx creates a scheduled transfer.
+func (store *SQLStore) CreateScheduledTransferTx(ctx context.Context, arg CreateScheduledTransferTxParams) (CreateScheduledTransferTxResult, error) {
+	var result CreateScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ScheduledTransfer, err = q.CreateScheduledTransfer(ctx, arg.CreateScheduledTransferParams)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionCreateScheduledTransfer,
+			TargetType: AuditTargetScheduledTransfer,
+			TargetID:   strconv.FormatInt(result.ScheduledTransfer.ID, 10),
+			After:      result.ScheduledTransfer,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type UpdateScheduledTransferTxParams struct {
+	UpdateScheduledTransferParams
+	Actor AuditActor
+}
+
+type UpdateScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer
+	AuditEvent        AuditEvent
+}
+
+// UpdateScheduledTransferTx changes the amount, schedule or state of a
+// scheduled transfer.
+func (store *SQLStore) UpdateScheduledTransferTx(ctx context.Context, arg UpdateScheduledTransferTxParams) (UpdateScheduledTransferTxResult, error) {
+	var result UpdateScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		previous, err := q.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, arg.UpdateScheduledTransferParams)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionUpdateScheduledTransfer,
+			TargetType: AuditTargetScheduledTransfer,
+			TargetID:   strconv.FormatInt(arg.ID, 10),
+			Before:     previous,
+			After:      result.ScheduledTransfer,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type DeleteScheduledTransferTxParams struct {
+	ID    int64
+	Actor AuditActor
+}
+
+type DeleteScheduledTransferTxResult struct {
+	AuditEvent AuditEvent
+}
+
+// DeleteScheduledTransferTx deletes a scheduled transfer. The audit log keeps
+// what it was.
+func (store *SQLStore) DeleteScheduledTransferTx(ctx context.Context, arg DeleteScheduledTransferTxParams) (DeleteScheduledTransferTxResult, error) {
+	var result DeleteScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		previous, err := q.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteScheduledTransfer(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionDeleteScheduledTransfer,
+			TargetType: AuditTargetScheduledTransfer,
+			TargetID:   strconv.FormatInt(arg.ID, 10),
+			Before:     previous,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -1,29 +1,103 @@
 package db
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// Owner, when set, must be the owner of the from account.
+	Owner string `json:"owner"`
+	// IdempotencyKey, when set, makes retries with the same key return the
+	// original result. Keys are scoped to Owner.
+	IdempotencyKey string `json:"-"`
+	// Actor is recorded in the audit log. It is not part of the request, so
+	// that a retry from another client is still the same transfer.
+	Actor AuditActor `json:"-"`
 }
 
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer    Transfer   `json:"transfer"`
+	FromAccount Account    `json:"from_account"`
+	ToAccount   Account    `json:"to_account"`
+	FromEntry   Entry      `json:"from_entry"`
+	ToEntry     Entry      `json:"to_entry"`
+	Journal     Journal    `json:"journal"`
+	AuditEvent  AuditEvent `json:"audit_event"`
 }
 
 var txKey = struct{}{}
 
+// ReplayTransferTx returns the stored result of a transfer already made with
+// the same idempotency key and request. It reports false when there is none,
+// so that callers can skip checks that must not run twice for one transfer,
+// such as using a TOTP code.
+func (store *SQLStore) ReplayTransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, bool, error) {
+	var result TransferTxResult
+
+	idempotency := IdempotencyParams{
+		Username: arg.Owner,
+		Key:      arg.IdempotencyKey,
+	}
+
+	replayed, err := store.loadIdempotentResponse(ctx, idempotency, "transfer", arg, &result)
+	return result, replayed, err
+}
+
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	if arg.FromAccountID == arg.ToAccountID {
+		return result, ErrSameAccount
+	}
+
+	if arg.Amount <= 0 {
+		return result, ErrInvalidAmount
+	}
+
+	idempotency := IdempotencyParams{
+		Username: arg.Owner,
+		Key:      arg.IdempotencyKey,
+	}
+
+	err := store.execIdempotentTx(ctx, idempotency, "transfer", arg, &result, func(q *Queries) error {
 		var err error
 
+		fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Owner != "" && fromAccount.Owner != arg.Owner {
+			return ErrAccountOwnerMismatch
+		}
+
+		if err := checkAccountDebit(fromAccount); err != nil {
+			return err
+		}
+
+		if err := checkAccountCredit(toAccount); err != nil {
+			return err
+		}
+
+		if fromAccount.Currency != toAccount.Currency {
+			return fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, fromAccount.Currency, toAccount.Currency)
+		}
+
+		if fromAccount.Balance < arg.Amount {
+			return fmt.Errorf("%w: account %d balance %d is less than %d", ErrInsufficientFunds, fromAccount.ID, fromAccount.Balance, arg.Amount)
+		}
+
+		if err := checkTransferLimits(ctx, q, fromAccount, arg.Amount); err != nil {
+			return err
+		}
+
 		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
 			FromAccountID: arg.FromAccountID,
 			ToAccountID:   arg.ToAccountID,
@@ -37,6 +111,11 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
 			AccountID: arg.FromAccountID,
 			Amount:    -arg.Amount,
+			Type:      EntryTypeTransfer,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
 		})
 		if err != nil {
 			return err
@@ -45,6 +124,11 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
 			AccountID: arg.ToAccountID,
 			Amount:    arg.Amount,
+			Type:      EntryTypeTransfer,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
 		})
 		if err != nil {
 			return err
@@ -58,12 +142,71 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
 
 		}
-		return nil
+		if err != nil {
+			return err
+		}
+
+		result.Journal, err = postJournal(ctx, q, PostJournalParams{
+			Type:       EntryTypeTransfer,
+			TransferID: result.Transfer.ID,
+			Postings: []JournalPosting{
+				{
+					AccountID: arg.FromAccountID,
+					EntryID:   result.FromEntry.ID,
+					Amount:    -arg.Amount,
+					Currency:  fromAccount.Currency,
+				},
+				{
+					AccountID: arg.ToAccountID,
+					EntryID:   result.ToEntry.ID,
+					Amount:    arg.Amount,
+					Currency:  toAccount.Currency,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionCreateTransfer,
+			TargetType: AuditTargetTransfer,
+			TargetID:   strconv.FormatInt(result.Transfer.ID, 10),
+			After:      result.Transfer,
+		})
+		return err
 	})
 
 	return result, err
 }
 
+// lockAccounts locks both accounts in ascending id order so that concurrent
+// transfers in opposite directions cannot deadlock.
+func lockAccounts(
+	ctx context.Context,
+	q *Queries,
+	fromAccountID int64,
+	toAccountID int64,
+) (fromAccount Account, toAccount Account, err error) {
+	if fromAccountID < toAccountID {
+		fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
+		if err != nil {
+			return
+		}
+
+		toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+		return
+	}
+
+	toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+	if err != nil {
+		return
+	}
+
+	fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
+	return
+}
+
 func addMoney(
 	ctx context.Context,
 	q *Queries,
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+type EnableTwoFactorTxParams struct {
+	Username string
+	// Step is the TOTP time step of the code that confirmed the secret.
+	Step int64
+	// RecoveryCodeHashes replace any recovery codes the user already has.
+	RecoveryCodeHashes []string
+	Actor              AuditActor
+}
+
+type EnableTwoFactorTxResult struct {
+	Credential    TotpCredential
+	RecoveryCodes []RecoveryCode
+	AuditEvent    AuditEvent
+}
+
+// twoFactorState is what the audit log keeps of two-factor authentication,
+// without the secret or the codes.
+type twoFactorState struct {
+	Enabled       bool `json:"enabled"`
+	RecoveryCodes int  `json:"recovery_codes"`
+}
+
+// EnableTwoFactorTx confirms the pending TOTP secret of a user and stores a
+// new set of recovery codes.
+func (store *SQLStore) EnableTwoFactorTx(ctx context.Context, arg EnableTwoFactorTxParams) (EnableTwoFactorTxResult, error) {
+	var result EnableTwoFactorTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.UseTOTPStep(ctx, UseTOTPStepParams{
+			Username: arg.Username,
+			Step:     arg.Step,
+		})
+		if err != nil {
+			if errors.Is(err, ErrorRecordNotFound) {
+				return ErrTOTPCodeReused
+			}
+			return err
+		}
+
+		result.Credential, err = q.ConfirmTOTPCredential(ctx, arg.Username)
+		if err != nil {
+			if errors.Is(err, ErrorRecordNotFound) {
+				return ErrTwoFactorEnabled
+			}
+			return err
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			code, err := q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+				Username: arg.Username,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.RecoveryCodes = append(result.RecoveryCodes, code)
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionEnableTwoFactor,
+			TargetType: AuditTargetUser,
+			TargetID:   arg.Username,
+			Before:     twoFactorState{},
+			After:      twoFactorState{Enabled: true, RecoveryCodes: len(result.RecoveryCodes)},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type DisableTwoFactorTxParams struct {
+	Username string
+	Actor    AuditActor
+}
+
+type DisableTwoFactorTxResult struct {
+	AuditEvent AuditEvent
+}
+
+// DisableTwoFactorTx removes the TOTP secret and recovery codes of a user.
+func (store *SQLStore) DisableTwoFactorTx(ctx context.Context, arg DisableTwoFactorTxParams) (DisableTwoFactorTxResult, error) {
+	var result DisableTwoFactorTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteTOTPCredential(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionDisableTwoFactor,
+			TargetType: AuditTargetUser,
+			TargetID:   arg.Username,
+			Before:     twoFactorState{Enabled: true},
+			After:      twoFactorState{},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	Actor AuditActor
+}
+
+type UpdateUserTxResult struct {
+	User User
+	// RevokedSessions is the number of sessions of the user that were blocked
+	// because the password changed.
+	RevokedSessions int64
+	AuditEvent      AuditEvent
+}
+
+// userState is what the audit log keeps of a user. Password hashes are left
+// out, only the time of the last change is kept.
+type userState struct {
+	FullName         string    `json:"full_name"`
+	Email            string    `json:"email"`
+	IsEmailVerified  bool      `json:"is_email_verified"`
+	PasswordChangeAt time.Time `json:"password_change_at"`
+}
+
+func newUserState(user User) userState {
+	return userState{
+		FullName:         user.FullName,
+		Email:            user.Email,
+		IsEmailVerified:  user.IsEmailVerified,
+		PasswordChangeAt: user.PasswordChangeAt,
+	}
+}
+
+// UpdateUserTx updates a user. When the password changes the previous one is
+// kept in the password history and every session of the user is revoked.
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		previous, err := q.GetUserForUpdate(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, arg.UpdateUserParams)
+
+		if err != nil {
+			return err
+		}
+
+		if arg.HashedPassword.Valid {
+			_, err = q.CreatePasswordHistory(ctx, CreatePasswordHistoryParams{
+				Username:       previous.Username,
+				HashedPassword: previous.HashedPassword,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			result.RevokedSessions, err = q.BlockUserSessions(ctx, arg.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionUpdateUser,
+			TargetType: AuditTargetUser,
+			TargetID:   arg.Username,
+			Before:     newUserState(previous),
+			After:      newUserState(result.User),
+		})
+
+		return err
+	})
+
+	return result, err
+}
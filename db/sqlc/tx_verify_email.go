@@ -9,11 +9,15 @@ import (
 type VerifyEmailTxParams struct {
 	EmailId    int64
 	SecretCode string
+	// Actor gives where the request came from. The link is opened without
+	// logging in, so the user it was sent to is recorded as the actor.
+	Actor AuditActor
 }
 
 type VerifyEmailTxResult struct {
 	User        User
 	VerifyEmail VerifyEmail
+	AuditEvent  AuditEvent
 }
 
 func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
@@ -31,6 +35,12 @@ func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParam
 			return err
 		}
 
+		previous, err := q.GetUserForUpdate(ctx, result.VerifyEmail.Username)
+
+		if err != nil {
+			return err
+		}
+
 		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
 			Username: result.VerifyEmail.Username,
 			IsEmailVerified: pgtype.Bool{
@@ -39,6 +49,21 @@ func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParam
 			},
 		})
 
+		if err != nil {
+			return err
+		}
+
+		actor := arg.Actor
+		actor.Username = result.User.Username
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, actor, auditEventParams{
+			Action:     AuditActionVerifyEmail,
+			TargetType: AuditTargetUser,
+			TargetID:   result.User.Username,
+			Before:     newUserState(previous),
+			After:      newUserState(result.User),
+		})
+
 		return err
 
 	})
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WithdrawTxParams struct {
+	AccountID         int64      `json:"account_id"`
+	Amount            int64      `json:"amount"`
+	ExternalReference string     `json:"external_reference"`
+	Actor             AuditActor `json:"-"`
+}
+
+type WithdrawTxResult struct {
+	Account    Account    `json:"account"`
+	Entry      Entry      `json:"entry"`
+	Journal    Journal    `json:"journal"`
+	AuditEvent AuditEvent `json:"audit_event"`
+}
+
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error) {
+	var result WithdrawTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountDebit(account); err != nil {
+			return err
+		}
+
+		if account.Balance < arg.Amount {
+			return fmt.Errorf("%w: account %d balance %d is less than %d", ErrInsufficientFunds, account.ID, account.Balance, arg.Amount)
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: arg.AccountID,
+			Amount:    -arg.Amount,
+			Type:      EntryTypeWithdrawal,
+			ExternalReference: pgtype.Text{
+				String: arg.ExternalReference,
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     arg.AccountID,
+			Amount: -arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Journal, err = postJournal(ctx, q, PostJournalParams{
+			Type: EntryTypeWithdrawal,
+			Postings: []JournalPosting{
+				{
+					AccountID: arg.AccountID,
+					EntryID:   result.Entry.ID,
+					Amount:    -arg.Amount,
+					Currency:  account.Currency,
+				},
+				{
+					SystemAccount: SystemAccountCash,
+					Amount:        arg.Amount,
+					Currency:      account.Currency,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditEvent, err = recordAuditEvent(ctx, q, arg.Actor, auditEventParams{
+			Action:     AuditActionWithdraw,
+			TargetType: AuditTargetAccount,
+			TargetID:   strconv.FormatInt(arg.AccountID, 10),
+			Before:     balanceState{Balance: account.Balance},
+			After:      balanceState{Balance: result.Account.Balance},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -19,7 +20,7 @@ INSERT INTO users (
     email
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role
+) RETURNING username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at
 `
 
 type CreateUserParams struct {
@@ -46,12 +47,15 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role FROM users
+SELECT username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -67,10 +71,146 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
 	)
 	return i, err
 }
 
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordChangeAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordChangeAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
+	)
+	return i, err
+}
+
+const getUserPasswordChangeAt = `-- name: GetUserPasswordChangeAt :one
+SELECT GREATEST(password_change_at, frozen_at, unfrozen_at, role_changed_at)::timestamptz AS password_change_at FROM users
+WHERE username = $1 LIMIT 1
+`
+
+// Tokens issued before a freeze, an unfreeze or a role change are rejected
+// like those issued before a password change. The application sets all four
+// times, from the clock it issues tokens with.
+func (q *Queries) GetUserPasswordChangeAt(ctx context.Context, username string) (time.Time, error) {
+	row := q.db.QueryRow(ctx, getUserPasswordChangeAt, username)
+	var password_change_at time.Time
+	err := row.Scan(&password_change_at)
+	return password_change_at, err
+}
+
+const rehashUserPassword = `-- name: RehashUserPassword :execrows
+UPDATE users
+SET hashed_password = $1
+WHERE username = $2 AND hashed_password = $3
+`
+
+type RehashUserPasswordParams struct {
+	NewHashedPassword string `json:"new_hashed_password"`
+	Username          string `json:"username"`
+	OldHashedPassword string `json:"old_hashed_password"`
+}
+
+func (q *Queries) RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rehashUserPassword, arg.NewHashedPassword, arg.Username, arg.OldHashedPassword)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at FROM users
+WHERE
+    username ILIKE $1 OR
+    full_name ILIKE $1 OR
+    email ILIKE $1
+ORDER BY username
+LIMIT $3
+OFFSET $2
+`
+
+type SearchUsersParams struct {
+	Pattern string `json:"pattern"`
+	Offset  int32  `json:"offset"`
+	Limit   int32  `json:"limit"`
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsers, arg.Pattern, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Username,
+			&i.HashedPassword,
+			&i.Email,
+			&i.FullName,
+			&i.PasswordChangeAt,
+			&i.CreatedAt,
+			&i.IsEmailVerified,
+			&i.Role,
+			&i.FrozenAt,
+			&i.RoleChangedAt,
+			&i.UnfrozenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET 
@@ -80,7 +220,7 @@ SET
     email = COALESCE($4, email),
     is_email_verified = COALESCE($5, is_email_verified)
 WHERE username = $6 
-RETURNING username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role
+RETURNING username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at
 `
 
 type UpdateUserParams struct {
@@ -111,6 +251,75 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
+	)
+	return i, err
+}
+
+const updateUserFrozenAt = `-- name: UpdateUserFrozenAt :one
+UPDATE users
+SET frozen_at = $1,
+    unfrozen_at = COALESCE($2, unfrozen_at)
+WHERE username = $3
+RETURNING username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at
+`
+
+type UpdateUserFrozenAtParams struct {
+	FrozenAt   pgtype.Timestamptz `json:"frozen_at"`
+	UnfrozenAt pgtype.Timestamptz `json:"unfrozen_at"`
+	Username   string             `json:"username"`
+}
+
+func (q *Queries) UpdateUserFrozenAt(ctx context.Context, arg UpdateUserFrozenAtParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserFrozenAt, arg.FrozenAt, arg.UnfrozenAt, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordChangeAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $1,
+    role_changed_at = $2
+WHERE username = $3
+RETURNING username, hashed_password, email, full_name, password_change_at, created_at, is_email_verified, role, frozen_at, role_changed_at, unfrozen_at
+`
+
+type UpdateUserRoleParams struct {
+	Role          string    `json:"role"`
+	RoleChangedAt time.Time `json:"role_changed_at"`
+	Username      string    `json:"username"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.Role, arg.RoleChangedAt, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordChangeAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.FrozenAt,
+		&i.RoleChangedAt,
+		&i.UnfrozenAt,
 	)
 	return i, err
 }
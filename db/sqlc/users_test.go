@@ -156,3 +156,100 @@ func TestUpdateUserAllFields(t *testing.T) {
 	require.Equal(t, updatedUser.Email, newEmail)
 	require.Equal(t, updatedUser.FullName, newFullName)
 }
+
+func TestUpdateUserTxPasswordHistory(t *testing.T) {
+	oldUser := createRandomUser(t)
+	session := createRandomSession(t, oldUser.Username)
+
+	// Other fields touch neither the history nor the sessions.
+	fullNameResult, err := testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: oldUser.Username,
+			FullName: pgtype.Text{
+				String: utils.RandomOwner(),
+				Valid:  true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, fullNameResult.RevokedSessions)
+
+	newHashedPassword, err := utils.HashedPassword(utils.RandomString(8))
+	require.NoError(t, err)
+
+	result, err := testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: oldUser.Username,
+			HashedPassword: pgtype.Text{
+				String: newHashedPassword,
+				Valid:  true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newHashedPassword, result.User.HashedPassword)
+	require.Equal(t, int64(1), result.RevokedSessions)
+
+	blocked, err := testStore.IsSessionFamilyBlocked(context.Background(), session.FamilyID)
+	require.NoError(t, err)
+	require.True(t, blocked)
+
+	history, err := testStore.ListPasswordHistory(context.Background(), ListPasswordHistoryParams{
+		Username: oldUser.Username,
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, oldUser.HashedPassword, history[0].HashedPassword)
+}
+
+func TestAssignRoleTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	admin := createRandomUser(t)
+
+	result, err := testStore.AssignRoleTx(context.Background(), AssignRoleTxParams{
+		Username: user.Username,
+		Role:     utils.BankerRole,
+		Actor:    AuditActor{Username: admin.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.BankerRole, result.User.Role)
+	require.Equal(t, int64(1), result.RevokedSessions)
+	require.True(t, result.User.RoleChangedAt.After(user.RoleChangedAt))
+	require.Equal(t, AuditActionAssignRole, result.AuditEvent.Action)
+	require.Equal(t, admin.Username, result.AuditEvent.Actor.String)
+	require.JSONEq(t, `{"role": "depositor"}`, string(result.AuditEvent.Before))
+	require.JSONEq(t, `{"role": "banker"}`, string(result.AuditEvent.After))
+
+	blocked, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	changedAt, err := testStore.GetUserPasswordChangeAt(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, result.User.RoleChangedAt, changedAt, 0)
+}
+
+func TestCreateUserTx(t *testing.T) {
+	hashedPassword, err := utils.HashedPassword(utils.RandomString(6))
+	require.NoError(t, err)
+
+	result, err := testStore.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       utils.RandomOwner(),
+			Email:          utils.RandomEmail(),
+			HashedPassword: hashedPassword,
+			FullName:       utils.RandomOwner(),
+		},
+		Actor: AuditActor{ClientIP: "192.0.2.1"},
+	})
+	require.NoError(t, err)
+
+	// A user signing up is recorded as the actor.
+	require.Equal(t, AuditActionCreateUser, result.AuditEvent.Action)
+	require.Equal(t, result.User.Username, result.AuditEvent.Actor.String)
+	require.Equal(t, result.User.Username, result.AuditEvent.TargetID)
+	require.NotContains(t, string(result.AuditEvent.After), hashedPassword)
+}
@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// updateAccountStatus moves an account from status from, or from any status
+// when empty, to status to.
+func (server *Server) updateAccountStatus(ctx context.Context, username string, accountID int64, from string, to string, reason string) (db.UpdateAccountStatusTxResult, error) {
+	txResult, err := server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		AccountID: accountID,
+		From:      from,
+		Status:    to,
+		Reason:    reason,
+		Actor:     server.auditActor(ctx, username),
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrorRecordNotFound):
+			return txResult, status.Errorf(codes.NotFound, "account not found")
+		case errors.Is(err, db.ErrAccountStatusTransition), errors.Is(err, db.ErrAccountBalanceNotZero):
+			return txResult, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return txResult, status.Errorf(codes.Internal, "failed to update account status: %v", err)
+	}
+
+	return txResult, nil
+}
+
+func validateAccountStatusRequest(accountID int64, reason string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateReason(reason); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"strings"
 
-	"slices"
-
+	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/token"
 	"google.golang.org/grpc/metadata"
 )
@@ -16,7 +15,14 @@ const (
 	authorizationBearer = "bearer"
 )
 
-func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+// authorizeUser checks that the access token of the call grants one of the
+// permissions of method. Calls already checked by AuthInterceptor are not
+// checked again; calls made through the gateway or directly are checked here.
+func (server *Server) authorizeUser(ctx context.Context, method string) (*token.Payload, error) {
+	if call, ok := ctx.Value(authorizationKey{}).(authorizedCall); ok && call.method == method {
+		return call.payload, nil
+	}
+
 	md, ok := metadata.FromIncomingContext(ctx)
 
 	if !ok {
@@ -51,15 +57,36 @@ func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []strin
 		return nil, fmt.Errorf("invalid access token: %s", err)
 	}
 
-	if !hasPermission(payload.Role, accessibleRoles) {
-		fmt.Println("Here maybe is the issue", payload.Role, accessibleRoles, payload)
-		return nil, fmt.Errorf("permission denied")
+	if payload.Type != token.TypeAccess {
+		return nil, fmt.Errorf("invalid access token: not an access token")
+	}
+
+	if err := server.revocations.Check(ctx, payload); err != nil {
+		return nil, fmt.Errorf("invalid access token: %s", err)
+	}
+
+	if err := checkMethodPermission(payload.Role, method); err != nil {
+		return nil, err
 	}
 
 	return payload, nil
 
 }
 
-func hasPermission(userRole string, accessibleRoles []string) bool {
-	return slices.Contains(accessibleRoles, userRole)
+// revokeAccessToken stops payload's access token from being accepted before it
+// expires.
+func (server *Server) revokeAccessToken(ctx context.Context, payload *token.Payload) error {
+	err := server.store.RevokeToken(ctx, db.RevokeTokenParams{
+		ID:        payload.ID,
+		Username:  payload.Username,
+		ExpiresAt: payload.ExpiredAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	server.revocations.Revoked(payload)
+
+	return nil
 }
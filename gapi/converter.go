@@ -1,6 +1,7 @@
 package gapi
 
 import (
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -13,5 +14,210 @@ func convertUser(user db.User) *pb.User {
 		FullName:          user.FullName,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangeAt),
 		CreatedAd:         timestamppb.New(user.CreatedAt.Time),
+		Role:              user.Role,
+		FrozenAt:          convertOptionalTime(user.FrozenAt),
 	}
 }
+
+func convertUsers(users []db.User) []*pb.User {
+	result := make([]*pb.User, len(users))
+	for i, user := range users {
+		result[i] = convertUser(user)
+	}
+	return result
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:              account.ID,
+		Owner:           account.Owner,
+		Balance:         account.Balance,
+		Currency:        account.Currency,
+		CreatedAt:       timestamppb.New(account.CreatedAt.Time),
+		Status:          account.Status,
+		StatusChangedAt: timestamppb.New(account.StatusChangedAt),
+	}
+}
+
+func convertAccounts(accounts []db.Account) []*pb.Account {
+	result := make([]*pb.Account, len(accounts))
+	for i, account := range accounts {
+		result[i] = convertAccount(account)
+	}
+	return result
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:                entry.ID,
+		AccountId:         entry.AccountID,
+		Amount:            entry.Amount,
+		CreatedAt:         timestamppb.New(entry.CreatedAt.Time),
+		Type:              entry.Type,
+		ExternalReference: entry.ExternalReference.String,
+		BalanceAfter:      entry.BalanceAfter,
+		TransferId:        entry.TransferID.Int64,
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt.Time),
+		ToAmount:      transfer.ToAmount.Int64,
+		ExchangeRate:  db.FormatRate(transfer.ExchangeRate),
+	}
+}
+
+func convertTransfers(transfers []db.Transfer) []*pb.Transfer {
+	result := make([]*pb.Transfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = convertTransfer(transfer)
+	}
+	return result
+}
+
+func convertExchangeRate(rate db.ExchangeRate) *pb.ExchangeRate {
+	return &pb.ExchangeRate{
+		Id:            rate.ID,
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          db.FormatRate(rate.Rate),
+		CreatedBy:     rate.CreatedBy,
+		CreatedAt:     timestamppb.New(rate.CreatedAt),
+	}
+}
+
+func convertTransferQuote(quote db.TransferQuote) *pb.TransferQuote {
+	return &pb.TransferQuote{
+		Id:            quote.ID.String(),
+		FromAccountId: quote.FromAccountID,
+		ToAccountId:   quote.ToAccountID,
+		FromAmount:    quote.FromAmount,
+		FromCurrency:  quote.FromCurrency,
+		ToAmount:      quote.ToAmount,
+		ToCurrency:    quote.ToCurrency,
+		Rate:          db.FormatRate(quote.Rate),
+		ExpiresAt:     timestamppb.New(quote.ExpiresAt),
+	}
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	return &pb.ScheduledTransfer{
+		Id:              scheduledTransfer.ID,
+		Owner:           scheduledTransfer.Owner,
+		FromAccountId:   scheduledTransfer.FromAccountID,
+		ToAccountId:     scheduledTransfer.ToAccountID,
+		Amount:          scheduledTransfer.Amount,
+		CronExpression:  scheduledTransfer.CronExpression.String,
+		IntervalSeconds: scheduledTransfer.IntervalSeconds.Int64,
+		NextRunAt:       timestamppb.New(scheduledTransfer.NextRunAt),
+		IsActive:        scheduledTransfer.IsActive,
+		CreatedAt:       timestamppb.New(scheduledTransfer.CreatedAt),
+	}
+}
+
+func convertScheduledTransfers(scheduledTransfers []db.ScheduledTransfer) []*pb.ScheduledTransfer {
+	result := make([]*pb.ScheduledTransfer, len(scheduledTransfers))
+	for i, scheduledTransfer := range scheduledTransfers {
+		result[i] = convertScheduledTransfer(scheduledTransfer)
+	}
+	return result
+}
+
+func convertScheduledTransferRuns(runs []db.ScheduledTransferRun) []*pb.ScheduledTransferRun {
+	result := make([]*pb.ScheduledTransferRun, len(runs))
+	for i, run := range runs {
+		result[i] = &pb.ScheduledTransferRun{
+			Id:          run.ID,
+			ScheduledAt: timestamppb.New(run.ScheduledAt),
+			Attempt:     run.Attempt,
+			Status:      run.Status,
+			TransferId:  run.TransferID.Int64,
+			Error:       run.Error.String,
+			CreatedAt:   timestamppb.New(run.CreatedAt),
+		}
+	}
+	return result
+}
+
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		CreatedAt: timestamppb.New(session.CreatedAt.Time),
+	}
+}
+
+func convertSessions(sessions []db.Session) []*pb.Session {
+	result := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		result[i] = convertSession(session)
+	}
+	return result
+}
+
+func convertAuditEvent(event db.AuditEvent) *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:         event.ID,
+		Actor:      event.Actor.String,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetId:   event.TargetID,
+		Reason:     event.Reason,
+		Before:     string(event.Before),
+		After:      string(event.After),
+		ClientIp:   event.ClientIp,
+		UserAgent:  event.UserAgent,
+		CreatedAt:  timestamppb.New(event.CreatedAt),
+	}
+}
+
+func convertAuditEvents(events []db.AuditEvent) []*pb.AuditEvent {
+	result := make([]*pb.AuditEvent, len(events))
+	for i, event := range events {
+		result[i] = convertAuditEvent(event)
+	}
+	return result
+}
+
+// convertOptionalTime returns nil for a null time so that the field is left
+// unset.
+func convertOptionalTime(t pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func convertTransferLimits(limits db.TransferLimits) *pb.TransferLimits {
+	return &pb.TransferLimits{
+		PerTransfer:   convertOptionalInt(limits.PerTransfer),
+		DailyAmount:   convertOptionalInt(limits.DailyAmount),
+		MonthlyAmount: convertOptionalInt(limits.MonthlyAmount),
+		HourlyCount:   convertOptionalInt(limits.HourlyCount),
+	}
+}
+
+func convertTransferUsage(usage db.GetTransferUsageRow) *pb.TransferUsage {
+	return &pb.TransferUsage{
+		DailyAmount:   usage.DailyAmount,
+		MonthlyAmount: usage.MonthlyAmount,
+		HourlyCount:   usage.HourlyCount,
+	}
+}
+
+// convertOptionalInt returns nil for a null integer so that the field is left
+// unset.
+func convertOptionalInt(i pgtype.Int8) *int64 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int64
+}
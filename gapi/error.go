@@ -1,6 +1,11 @@
 package gapi
 
 import (
+	"errors"
+	"fmt"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/val"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,3 +36,71 @@ func inValidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
 }
+
+func transferTxError(err error) error {
+	var limitErr *db.TransferLimitError
+	if errors.As(err, &limitErr) {
+		return transferLimitError(limitErr)
+	}
+
+	switch {
+	case errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrAccountUnavailable),
+		errors.Is(err, db.ErrQuoteExpired),
+		errors.Is(err, db.ErrQuoteUsed):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, db.ErrQuoteNotFound):
+		return status.Errorf(codes.NotFound, "%s", err)
+	case errors.Is(err, db.ErrCurrencyMismatch),
+		errors.Is(err, db.ErrSameAccount),
+		errors.Is(err, db.ErrInvalidAmount):
+		return status.Errorf(codes.InvalidArgument, "%s", err)
+	case errors.Is(err, db.ErrAccountOwnerMismatch):
+		return status.Errorf(codes.PermissionDenied, "%s", err)
+	case errors.Is(err, db.ErrorRecordNotFound):
+		return status.Errorf(codes.NotFound, "account not found")
+	case errors.Is(err, db.ErrIdempotencyKeyConflict):
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to create transfer: %v", err)
+}
+
+// transferLimitError lists the limits a transfer would exceed. Exceeding the
+// per transfer limit fails however long the caller waits, a window limit only
+// until the window has moved on.
+func transferLimitError(err *db.TransferLimitError) error {
+	code := codes.ResourceExhausted
+	quotaFailure := &errdetails.QuotaFailure{}
+
+	for _, violation := range err.Violations {
+		if violation.Limit == db.TransferLimitPerTransfer {
+			code = codes.FailedPrecondition
+		}
+
+		quotaFailure.Violations = append(quotaFailure.Violations, &errdetails.QuotaFailure_Violation{
+			Subject:     fmt.Sprintf("account:%d/%s", err.AccountID, violation.Limit),
+			Description: fmt.Sprintf("limit is %d, the transfer would reach %d", violation.Max, violation.Value),
+		})
+	}
+
+	statusLimit := status.New(code, err.Error())
+
+	statusDetails, detailsErr := statusLimit.WithDetails(quotaFailure)
+	if detailsErr != nil {
+		return statusLimit.Err()
+	}
+	return statusDetails.Err()
+}
+
+func validateIdempotencyKey(mtdt *Metadata) error {
+	if mtdt.IdempotencyKey == "" {
+		return nil
+	}
+
+	if err := val.ValidateIdempotencyKey(mtdt.IdempotencyKey); err != nil {
+		return inValidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation(idempotencyKeyHeader, err),
+		})
+	}
+	return nil
+}
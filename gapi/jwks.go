@@ -0,0 +1,34 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/starjardin/simplebank/token"
+)
+
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWKSHandler serves the public keys tokens are signed with. It responds with
+// 404 when tokens are signed with a symmetric key, as there is nothing that
+// can be published.
+func (server *Server) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			res.Header().Set("Allow", http.MethodGet)
+			http.Error(res, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		maker, ok := server.tokenMaker.(token.PublicKeyMaker)
+		if !ok {
+			http.NotFound(res, req)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		res.Header().Set("Cache-Control", "public, max-age=300")
+
+		json.NewEncoder(res).Encode(maker.KeySet().JWKS())
+	})
+}
@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/starjardin/simplebank/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := token.NewKeySet("key1", map[string]crypto.Signer{"key1": privateKey}, nil)
+	require.NoError(t, err)
+
+	publicMaker, err := token.NewPasetoPublicMaker(keys)
+	require.NoError(t, err)
+
+	server := newTestServer(t, nil, nil)
+
+	recorder := httptest.NewRecorder()
+	server.JWKSHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	server.tokenMaker = publicMaker
+
+	recorder = httptest.NewRecorder()
+	server.JWKSHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var jwks token.JSONWebKeySet
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&jwks))
+	require.Len(t, jwks.Keys, 1)
+	require.Equal(t, "key1", jwks.Keys[0].KeyID)
+	require.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	require.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+	require.NotEmpty(t, jwks.Keys[0].X)
+}
@@ -0,0 +1,197 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLoginMaxAttempts     = 5
+	defaultLoginLockoutDuration = 15 * time.Minute
+
+	// loginFreeAttempts is the number of failures allowed before each further
+	// attempt has to wait, doubling from one second.
+	loginFreeAttempts = 2
+	// clientIPAttemptsFactor multiplies the attempts allowed per client IP,
+	// since many users can share one address.
+	clientIPAttemptsFactor = 4
+)
+
+// errInvalidCredentials is returned for both unknown users and wrong
+// passwords so that logins cannot be used to find out which usernames exist.
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid username or password")
+
+type loginThrottle struct {
+	scope       string
+	key         string
+	maxAttempts int32
+}
+
+func (server *Server) loginMaxAttempts() int32 {
+	if server.config.LoginMaxAttempts <= 0 {
+		return defaultLoginMaxAttempts
+	}
+	return int32(server.config.LoginMaxAttempts)
+}
+
+func (server *Server) loginThrottles(username string, clientIP string) []loginThrottle {
+	maxAttempts := server.loginMaxAttempts()
+
+	throttles := []loginThrottle{
+		{
+			scope:       db.LoginFailureScopeUsername,
+			key:         username,
+			maxAttempts: maxAttempts,
+		},
+	}
+
+	if clientIP != "" {
+		throttles = append(throttles, loginThrottle{
+			scope:       db.LoginFailureScopeClientIP,
+			key:         clientIP,
+			maxAttempts: maxAttempts * clientIPAttemptsFactor,
+		})
+	}
+
+	return throttles
+}
+
+func (server *Server) loginLockoutDuration() time.Duration {
+	if server.config.LoginLockoutDuration <= 0 {
+		return defaultLoginLockoutDuration
+	}
+	return server.config.LoginLockoutDuration
+}
+
+// loginBackoff returns how long to wait after failedCount failures and whether
+// that wait is a lockout.
+func loginBackoff(failedCount int32, maxAttempts int32, lockout time.Duration) (time.Duration, bool) {
+	if failedCount >= maxAttempts {
+		return lockout, true
+	}
+
+	if failedCount < loginFreeAttempts {
+		return 0, false
+	}
+
+	delay := time.Second << (failedCount - loginFreeAttempts)
+	if delay > lockout {
+		delay = lockout
+	}
+
+	return delay, false
+}
+
+// checkLoginThrottle rejects the login while the username or the client IP
+// has to wait after failed attempts.
+func (server *Server) checkLoginThrottle(ctx context.Context, username string, clientIP string) error {
+	return server.checkThrottles(ctx, server.loginThrottles(username, clientIP), "failed login")
+}
+
+// checkThrottles rejects the attempt while one of throttles has to wait after
+// failed attempts.
+func (server *Server) checkThrottles(ctx context.Context, throttles []loginThrottle, attempt string) error {
+	for _, throttle := range throttles {
+		failure, err := server.store.GetLoginFailure(ctx, db.GetLoginFailureParams{
+			Scope: throttle.scope,
+			Key:   throttle.key,
+		})
+
+		if err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				continue
+			}
+			return status.Errorf(codes.Internal, "failed to get %s attempts: %v", attempt, err)
+		}
+
+		if failure.LockedUntil.Valid && time.Now().Before(failure.LockedUntil.Time) {
+			return status.Errorf(codes.ResourceExhausted, "too many %s attempts, try again after %s", attempt, failure.LockedUntil.Time.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login and delays the next attempt. When
+// the username gets locked its owner is told by email.
+func (server *Server) recordLoginFailure(ctx context.Context, username string, clientIP string, userExists bool) error {
+	for _, throttle := range server.loginThrottles(username, clientIP) {
+		failure, lockedUntil, locked, err := server.recordThrottleFailure(ctx, throttle)
+
+		if err != nil {
+			return err
+		}
+
+		// Only the failure that reaches the limit sends an email.
+		if locked && userExists && throttle.scope == db.LoginFailureScopeUsername && failure.FailedCount == throttle.maxAttempts {
+			payload := worker.PayloadSendAccountLockedEmail{
+				Username:    username,
+				ClientIP:    clientIP,
+				LockedUntil: lockedUntil,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			err = server.taskDistributor.DistributeTaskSendAccountLockedEmail(ctx, payload, opts...)
+
+			if err != nil {
+				return fmt.Errorf("failed to distribute account locked email: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordThrottleFailure counts a failed attempt against throttle and delays
+// the next one. It returns until when attempts wait and whether that wait is
+// a lockout.
+func (server *Server) recordThrottleFailure(ctx context.Context, throttle loginThrottle) (db.LoginFailure, time.Time, bool, error) {
+	lockout := server.loginLockoutDuration()
+	now := time.Now()
+
+	failure, err := server.store.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Scope:       throttle.scope,
+		Key:         throttle.key,
+		ResetBefore: now.Add(-lockout),
+	})
+
+	if err != nil {
+		return failure, time.Time{}, false, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	delay, locked := loginBackoff(failure.FailedCount, throttle.maxAttempts, lockout)
+
+	if delay == 0 {
+		return failure, time.Time{}, false, nil
+	}
+
+	lockedUntil := now.Add(delay)
+
+	_, err = server.store.LockLogin(ctx, db.LockLoginParams{
+		Scope: throttle.scope,
+		Key:   throttle.key,
+		LockedUntil: pgtype.Timestamptz{
+			Time:  lockedUntil,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return failure, lockedUntil, locked, fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return failure, lockedUntil, locked, nil
+}
@@ -3,23 +3,78 @@ package gapi
 import (
 	"context"
 	"fmt"
+	"os"
+	"slices"
 	"testing"
 	"time"
 
+	mockdb "github.com/starjardin/simplebank/db/mock"
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/token"
 	"github.com/starjardin/simplebank/utils"
 	"github.com/starjardin/simplebank/worker"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/metadata"
 )
 
+var depositorTestPermissions = []string{
+	utils.PermissionAccountsCreateOwn,
+	utils.PermissionAccountsReadOwn,
+	utils.PermissionAccountsCloseOwn,
+	utils.PermissionTransfersCreateOwn,
+	utils.PermissionTransfersReadOwn,
+	utils.PermissionScheduledTransfersManageOwn,
+	utils.PermissionStatementsReadOwn,
+	utils.PermissionSessionsManageOwn,
+	utils.PermissionTwoFactorManageOwn,
+	utils.PermissionUsersUpdateOwn,
+}
+
+// testRolePermissions are the roles seeded by the role_permissions migration.
+var testRolePermissions = map[string][]string{
+	utils.DepositorRole: depositorTestPermissions,
+	utils.BankerRole: append(slices.Clone(depositorTestPermissions),
+		utils.PermissionAccountsReadAny,
+		utils.PermissionAccountsDepositAny,
+		utils.PermissionAccountsWithdrawAny,
+		utils.PermissionTransfersReadAny,
+		utils.PermissionStatementsReadAny,
+		utils.PermissionExchangeRatesWriteAny,
+		utils.PermissionUsersUpdateAny,
+		utils.PermissionUsersReadAny,
+		utils.PermissionUsersFreezeAny,
+		utils.PermissionAccountsFreezeAny,
+		utils.PermissionAccountsReactivateAny,
+		utils.PermissionAccountsCloseAny,
+		utils.PermissionAccountsAdjustAny,
+		utils.PermissionAccountsChargeFeeAny,
+		utils.PermissionAuditEventsReadAny,
+		utils.PermissionLedgerVerifyAny,
+		utils.PermissionTransferLimitsReadAny,
+		utils.PermissionTransferLimitsWriteAny,
+	),
+	utils.AdminRole: append(slices.Clone(depositorTestPermissions),
+		utils.PermissionRolesReadAny,
+		utils.PermissionRolesAssignAny,
+	),
+}
+
+func TestMain(m *testing.M) {
+	utils.LoadRolePermissions(testRolePermissions)
+	os.Exit(m.Run())
+}
+
 func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDistributor) *Server {
 	config := utils.Config{
 		TokenSymetricKey:    utils.RandomString(32),
 		AccessTokenDuration: time.Minute,
 	}
 
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		allowAllTokens(mockStore)
+	}
+
 	server, err := NewServer(config, store, taskDistributor)
 
 	require.NoError(t, err)
@@ -28,9 +83,17 @@ func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDist
 }
 
 func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role string, duration time.Duration) context.Context {
+	return newContextWithClaims(t, tokenMaker, token.Claims{
+		Username: username,
+		Role:     role,
+		Type:     token.TypeAccess,
+	}, duration)
+}
+
+func newContextWithClaims(t *testing.T, tokenMaker token.Maker, claims token.Claims, duration time.Duration) context.Context {
 	ctx := context.Background()
 
-	accessToken, _, err := tokenMaker.CreateToken(username, role, duration)
+	accessToken, _, err := tokenMaker.CreateToken(claims, duration)
 
 	require.NoError(t, err)
 
@@ -44,3 +107,18 @@ func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username st
 
 	return metadata.NewIncomingContext(ctx, md)
 }
+
+// allowAllTokens treats every token as not revoked. Expectations set before
+// it is called take precedence.
+func allowAllTokens(store *mockdb.MockStore) {
+	store.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+	store.EXPECT().IsSessionFamilyBlocked(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+	store.EXPECT().GetUserPasswordChangeAt(gomock.Any(), gomock.Any()).AnyTimes().Return(time.Time{}, nil)
+}
+
+// loginFailureScope matches the login failure params of scope.
+func loginFailureScope(scope string) gomock.Matcher {
+	return gomock.Cond(func(arg db.RecordLoginFailureParams) bool {
+		return arg.Scope == scope
+	})
+}
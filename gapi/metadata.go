@@ -2,22 +2,38 @@ package gapi
 
 import (
 	"context"
+	"net"
+	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	db "github.com/starjardin/simplebank/db/sqlc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
 type Metadata struct {
-	UserAgent string
-	ClientIP  string
+	UserAgent      string
+	ClientIP       string
+	IdempotencyKey string
 }
 
 const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	idempotencyKeyHeader       = "idempotency-key"
 )
 
+// IncomingHeaderMatcher forwards the HTTP headers the gateway needs to pass
+// on as gRPC metadata, on top of the runtime defaults.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	switch strings.ToLower(key) {
+	case idempotencyKeyHeader:
+		return idempotencyKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func (s *Server) extractMetadata(ctx context.Context) *Metadata {
 
 	mtdt := &Metadata{}
@@ -32,15 +48,47 @@ func (s *Server) extractMetadata(ctx context.Context) *Metadata {
 		}
 
 		if clientIPs := md.Get(xForwardedForHeader); len(clientIPs) > 0 {
-			mtdt.ClientIP = clientIPs[0]
+			mtdt.ClientIP = lastForwardedFor(clientIPs)
+		}
+
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			mtdt.IdempotencyKey = keys[0]
 		}
 	}
 
 	if p, ok := peer.FromContext(ctx); ok {
 		if p.Addr != nil {
-			mtdt.ClientIP = p.Addr.String()
+			mtdt.ClientIP = hostOnly(p.Addr.String())
 		}
 	}
 
 	return mtdt
 }
+
+// lastForwardedFor returns the address the gateway appended to
+// x-forwarded-for. Earlier elements come from the client and can be forged.
+func lastForwardedFor(values []string) string {
+	hops := strings.Split(values[len(values)-1], ",")
+	return hostOnly(strings.TrimSpace(hops[len(hops)-1]))
+}
+
+// hostOnly strips the port from addr so that every connection from one host
+// gets the same client IP.
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// auditActor returns who is making the call and from where, for the audit log.
+func (s *Server) auditActor(ctx context.Context, username string) db.AuditActor {
+	mtdt := s.extractMetadata(ctx)
+
+	return db.AuditActor{
+		Username:  username,
+		ClientIP:  mtdt.ClientIP,
+		UserAgent: mtdt.UserAgent,
+	}
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractMetadataClientIP(t *testing.T) {
+	testCases := []struct {
+		name       string
+		forwarded  []string
+		peerAddr   net.Addr
+		expectedIP string
+	}{
+		{
+			name:       "PeerWithoutPort",
+			peerAddr:   &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 51234},
+			expectedIP: "192.0.2.1",
+		},
+		{
+			name:       "PeerIPv6",
+			peerAddr:   &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 51234},
+			expectedIP: "2001:db8::1",
+		},
+		{
+			name:       "LastForwardedHop",
+			forwarded:  []string{"203.0.113.9, 198.51.100.7"},
+			expectedIP: "198.51.100.7",
+		},
+		{
+			name:       "LastForwardedHeader",
+			forwarded:  []string{"203.0.113.9", "198.51.100.7:8080"},
+			expectedIP: "198.51.100.7",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.forwarded != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{xForwardedForHeader: tc.forwarded})
+			}
+			if tc.peerAddr != nil {
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: tc.peerAddr})
+			}
+
+			server := &Server{}
+			require.Equal(t, tc.expectedIP, server.extractMetadata(ctx).ClientIP)
+		})
+	}
+}
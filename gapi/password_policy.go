@@ -0,0 +1,54 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkNewPassword checks a new password of an existing user against the
+// password policy and the last passwords of the user. field names the request
+// field in the returned violation.
+func (server *Server) checkNewPassword(ctx context.Context, field string, user db.User, password string) error {
+	if err := server.passwordPolicy.Validate(password, user.Username, user.Email); err != nil {
+		return inValidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(field, err)})
+	}
+
+	historySize := server.config.PasswordHistorySize
+
+	if historySize <= 0 {
+		return nil
+	}
+
+	// The current password counts as one of the last ones.
+	hashes := []string{user.HashedPassword}
+
+	if historySize > 1 {
+		history, err := server.store.ListPasswordHistory(ctx, db.ListPasswordHistoryParams{
+			Username: user.Username,
+			Limit:    int32(historySize - 1),
+		})
+
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list password history: %v", err)
+		}
+
+		for _, previous := range history {
+			hashes = append(hashes, previous.HashedPassword)
+		}
+	}
+
+	for _, hash := range hashes {
+		if utils.CheckPassword(password, hash) == nil {
+			err := fmt.Errorf("must not be one of your last %d passwords", historySize)
+			return inValidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(field, err)})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"google.golang.org/grpc"
+)
+
+// publicMethods can be called without an access token.
+var publicMethods = map[string]bool{
+	pb.SimpleBank_CreateUser_FullMethodName:           true,
+	pb.SimpleBank_LoginUser_FullMethodName:            true,
+	pb.SimpleBank_VerifyEmail_FullMethodName:          true,
+	pb.SimpleBank_RenewAccessToken_FullMethodName:     true,
+	pb.SimpleBank_IntrospectToken_FullMethodName:      true,
+	pb.SimpleBank_VerifyLoginTwoFactor_FullMethodName: true,
+	pb.SimpleBank_RequestPasswordReset_FullMethodName: true,
+	pb.SimpleBank_ResetPassword_FullMethodName:        true,
+}
+
+// methodPermissions lists for every other method the permissions of which the
+// caller needs at least one. Methods missing from both lists are denied.
+var methodPermissions = map[string][]string{
+	pb.SimpleBank_UpdateUser_FullMethodName:              {utils.PermissionUsersUpdateOwn, utils.PermissionUsersUpdateAny},
+	pb.SimpleBank_CreateAccount_FullMethodName:           {utils.PermissionAccountsCreateOwn},
+	pb.SimpleBank_GetAccount_FullMethodName:              {utils.PermissionAccountsReadOwn, utils.PermissionAccountsReadAny},
+	pb.SimpleBank_ListAccounts_FullMethodName:            {utils.PermissionAccountsReadOwn},
+	pb.SimpleBank_CreateTransfer_FullMethodName:          {utils.PermissionTransfersCreateOwn},
+	pb.SimpleBank_GetTransfer_FullMethodName:             {utils.PermissionTransfersReadOwn, utils.PermissionTransfersReadAny},
+	pb.SimpleBank_ListTransfers_FullMethodName:           {utils.PermissionTransfersReadOwn, utils.PermissionTransfersReadAny},
+	pb.SimpleBank_Deposit_FullMethodName:                 {utils.PermissionAccountsDepositAny},
+	pb.SimpleBank_Withdraw_FullMethodName:                {utils.PermissionAccountsWithdrawAny},
+	pb.SimpleBank_UploadExchangeRates_FullMethodName:     {utils.PermissionExchangeRatesWriteAny},
+	pb.SimpleBank_QuoteTransfer_FullMethodName:           {utils.PermissionTransfersCreateOwn},
+	pb.SimpleBank_CreateExchangeTransfer_FullMethodName:  {utils.PermissionTransfersCreateOwn},
+	pb.SimpleBank_CreateScheduledTransfer_FullMethodName: {utils.PermissionScheduledTransfersManageOwn},
+	pb.SimpleBank_GetScheduledTransfer_FullMethodName:    {utils.PermissionScheduledTransfersManageOwn},
+	pb.SimpleBank_ListScheduledTransfers_FullMethodName:  {utils.PermissionScheduledTransfersManageOwn},
+	pb.SimpleBank_UpdateScheduledTransfer_FullMethodName: {utils.PermissionScheduledTransfersManageOwn},
+	pb.SimpleBank_DeleteScheduledTransfer_FullMethodName: {utils.PermissionScheduledTransfersManageOwn},
+	pb.SimpleBank_GetAccountStatement_FullMethodName:     {utils.PermissionStatementsReadOwn, utils.PermissionStatementsReadAny},
+	pb.SimpleBank_ExportAccountStatement_FullMethodName:  {utils.PermissionStatementsReadOwn, utils.PermissionStatementsReadAny},
+	pb.SimpleBank_ListSessions_FullMethodName:            {utils.PermissionSessionsManageOwn},
+	pb.SimpleBank_RevokeSession_FullMethodName:           {utils.PermissionSessionsManageOwn},
+	pb.SimpleBank_RevokeAllSessions_FullMethodName:       {utils.PermissionSessionsManageOwn},
+	pb.SimpleBank_Logout_FullMethodName:                  {utils.PermissionSessionsManageOwn},
+	pb.SimpleBank_SetupTOTP_FullMethodName:               {utils.PermissionTwoFactorManageOwn},
+	pb.SimpleBank_ConfirmTOTP_FullMethodName:             {utils.PermissionTwoFactorManageOwn},
+	pb.SimpleBank_DisableTOTP_FullMethodName:             {utils.PermissionTwoFactorManageOwn},
+	pb.SimpleBank_ListRoles_FullMethodName:               {utils.PermissionRolesReadAny},
+	pb.SimpleBank_AssignRole_FullMethodName:              {utils.PermissionRolesAssignAny},
+	pb.SimpleBank_SearchUsers_FullMethodName:             {utils.PermissionUsersReadAny},
+	pb.SimpleBank_ListCustomerAccounts_FullMethodName:    {utils.PermissionAccountsReadAny},
+	pb.SimpleBank_ListCustomerTransfers_FullMethodName:   {utils.PermissionTransfersReadAny},
+	pb.SimpleBank_FreezeAccount_FullMethodName:           {utils.PermissionAccountsFreezeAny},
+	pb.SimpleBank_UnfreezeAccount_FullMethodName:         {utils.PermissionAccountsFreezeAny},
+	pb.SimpleBank_FreezeUser_FullMethodName:              {utils.PermissionUsersFreezeAny},
+	pb.SimpleBank_UnfreezeUser_FullMethodName:            {utils.PermissionUsersFreezeAny},
+	pb.SimpleBank_AdjustAccountBalance_FullMethodName:    {utils.PermissionAccountsAdjustAny},
+	pb.SimpleBank_ChargeFee_FullMethodName:               {utils.PermissionAccountsChargeFeeAny},
+	pb.SimpleBank_ReactivateAccount_FullMethodName:       {utils.PermissionAccountsReactivateAny},
+	pb.SimpleBank_CloseAccount_FullMethodName:            {utils.PermissionAccountsCloseOwn, utils.PermissionAccountsCloseAny},
+	pb.SimpleBank_ListAuditEvents_FullMethodName:         {utils.PermissionAuditEventsReadAny},
+	pb.SimpleBank_VerifyLedgerIntegrity_FullMethodName:   {utils.PermissionLedgerVerifyAny},
+	pb.SimpleBank_GetTransferLimits_FullMethodName:       {utils.PermissionTransferLimitsReadAny},
+	pb.SimpleBank_SetTransferLimits_FullMethodName:       {utils.PermissionTransferLimitsWriteAny},
+}
+
+type authorizationKey struct{}
+
+// authorizedCall is what AuthInterceptor leaves in the context for the handler.
+type authorizedCall struct {
+	method  string
+	payload *token.Payload
+}
+
+// AuthInterceptor rejects calls whose access token does not grant one of the
+// permissions of the method before they reach the handler.
+func (server *Server) AuthInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	payload, err := server.authorizeUser(ctx, info.FullMethod)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	ctx = context.WithValue(ctx, authorizationKey{}, authorizedCall{
+		method:  info.FullMethod,
+		payload: payload,
+	})
+
+	return handler(ctx, req)
+}
+
+func checkMethodPermission(role string, method string) error {
+	required, ok := methodPermissions[method]
+
+	if !ok {
+		return fmt.Errorf("no permissions are defined for %s", method)
+	}
+
+	if !slices.ContainsFunc(required, func(permission string) bool {
+		return utils.HasPermission(role, permission)
+	}) {
+		return fmt.Errorf("permission denied")
+	}
+
+	return nil
+}
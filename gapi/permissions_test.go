@@ -0,0 +1,176 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMethodPermissionsCoverService(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := "/" + pb.SimpleBank_ServiceDesc.ServiceName + "/" + method.MethodName
+
+		_, protected := methodPermissions[fullMethod]
+		require.NotEqual(t, publicMethods[fullMethod], protected, "%s must be either public or have permissions", fullMethod)
+	}
+
+	for _, permissions := range methodPermissions {
+		require.NotEmpty(t, permissions)
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	require.True(t, utils.HasPermission(utils.BankerRole, utils.PermissionAccountsDepositAny))
+	require.False(t, utils.HasPermission(utils.DepositorRole, utils.PermissionAccountsDepositAny))
+	require.False(t, utils.HasPermission(utils.AdminRole, utils.PermissionAccountsDepositAny))
+	require.True(t, utils.HasPermission(utils.AdminRole, utils.PermissionRolesAssignAny))
+	require.Empty(t, utils.RolePermissions(utils.TwoFactorChallengeRole))
+	require.False(t, utils.IsSupportedRole(utils.TwoFactorChallengeRole))
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	user, _ := randomUser(t)
+
+	sessionID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		method        string
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, server *Server) context.Context
+		checkResponse func(t *testing.T, called bool, err error)
+	}{
+		{
+			name:   "Public",
+			method: pb.SimpleBank_LoginUser_FullMethodName,
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "Allowed",
+			method: pb.SimpleBank_Deposit_FullMethodName,
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.tokenMaker, user.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+			},
+		},
+		{
+			name:   "MissingPermission",
+			method: pb.SimpleBank_Deposit_FullMethodName,
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:   "NoAuthorization",
+			method: pb.SimpleBank_ListAccounts_FullMethodName,
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:   "RefreshToken",
+			method: pb.SimpleBank_ListAccounts_FullMethodName,
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithClaims(t, server.tokenMaker, token.Claims{
+					Username: user.Username,
+					Role:     utils.DepositorRole,
+					Type:     token.TypeRefresh,
+				}, time.Hour)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:   "BlockedSession",
+			method: pb.SimpleBank_ListAccounts_FullMethodName,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().IsSessionFamilyBlocked(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(true, nil)
+			},
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithClaims(t, server.tokenMaker, token.Claims{
+					Username:  user.Username,
+					Role:      utils.DepositorRole,
+					Type:      token.TypeAccess,
+					SessionID: sessionID,
+				}, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:   "UnknownMethod",
+			method: "/pb.SimpleBank/Unknown",
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.tokenMaker, user.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, called bool, err error) {
+				require.False(t, called)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			}
+			server := newTestServer(t, store, nil)
+
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+
+				if !publicMethods[tc.method] {
+					// The handler gets the payload without checking the token again.
+					payload, err := server.authorizeUser(ctx, tc.method)
+					require.NoError(t, err)
+					require.Equal(t, user.Username, payload.Username)
+				}
+				return nil, nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+
+			_, err := server.AuthInterceptor(tc.buildContext(t, server), nil, info, handler)
+			tc.checkResponse(t, called, err)
+		})
+	}
+}
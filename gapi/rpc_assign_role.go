@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.AssignRoleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_AssignRole_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAssignRoleRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	// Stops the last admin from locking everyone out by demoting themselves.
+	if req.GetUsername() == authPayload.Username {
+		return nil, status.Error(codes.PermissionDenied, "cannot change your own role")
+	}
+
+	txResult, err := server.store.AssignRoleTx(ctx, db.AssignRoleTxParams{
+		Username: req.GetUsername(),
+		Role:     req.GetRole(),
+		Actor:    server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to assign role: %v", err)
+	}
+
+	// Access tokens carrying the old role stop working at once here.
+	server.revocations.UserChanged(txResult.User.Username)
+
+	resp := &pb.AssignRoleResponse{
+		User:            convertUser(txResult.User),
+		RevokedSessions: txResult.RevokedSessions,
+	}
+
+	return resp, nil
+}
+
+func validateAssignRoleRequest(req *pb.AssignRoleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidateRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,147 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAssignRoleAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = utils.AdminRole
+
+	user, _ := randomUser(t)
+	user.Role = utils.DepositorRole
+
+	testCases := []struct {
+		name          string
+		req           *pb.AssignRoleRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.AssignRoleResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     utils.BankerRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AssignRoleTxParams{
+					Username: user.Username,
+					Role:     utils.BankerRole,
+					Actor:    db.AuditActor{Username: admin.Username},
+				}
+
+				promoted := user
+				promoted.Role = utils.BankerRole
+
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignRoleTxResult{User: promoted, RevokedSessions: 1}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, utils.BankerRole, res.GetUser().GetRole())
+				require.Equal(t, int64(1), res.GetRevokedSessions())
+			},
+		},
+		{
+			name: "NotAdmin",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     utils.AdminRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AssignRoleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "OwnRole",
+			req: &pb.AssignRoleRequest{
+				Username: admin.Username,
+				Role:     utils.DepositorRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AssignRoleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "UnsupportedRole",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     utils.TwoFactorChallengeRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AssignRoleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "UserNotFound",
+			req: &pb.AssignRoleRequest{
+				Username: user.Username,
+				Role:     utils.BankerRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AssignRoleTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignRoleTxResult{}, db.ErrorRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.AssignRoleResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.AssignRole(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
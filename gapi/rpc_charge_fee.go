@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ChargeFee(ctx context.Context, req *pb.ChargeFeeRequest) (*pb.ChargeFeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ChargeFee_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateChargeFeeRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	_, err = server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	txResult, err := server.store.ChargeFeeTx(ctx, db.ChargeFeeTxParams{
+		AccountID: req.GetAccountId(),
+		Amount:    req.GetAmount(),
+		Reason:    req.GetReason(),
+		Actor:     server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountUnavailable) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to charge fee: %v", err)
+	}
+
+	resp := &pb.ChargeFeeResponse{
+		Account: convertAccount(txResult.Account),
+		Entry:   convertEntry(txResult.Entry),
+	}
+
+	return resp, nil
+}
+
+func validateChargeFeeRequest(req *pb.ChargeFeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateReason(req.GetReason()); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,181 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestChargeFeeAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	depositor, _ := randomUser(t)
+	account := randomAccount(depositor.Username, utils.USD)
+
+	amount := int64(5)
+	reason := "monthly maintenance fee"
+
+	req := &pb.ChargeFeeRequest{
+		AccountId: account.ID,
+		Amount:    amount,
+		Currency:  utils.USD,
+		Reason:    reason,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ChargeFeeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ChargeFeeResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.ChargeFeeTxParams{
+					AccountID: account.ID,
+					Amount:    amount,
+					Reason:    reason,
+					Actor:     db.AuditActor{Username: banker.Username},
+				}
+
+				updatedAccount := account
+				updatedAccount.Balance -= amount
+
+				store.EXPECT().
+					ChargeFeeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ChargeFeeTxResult{
+						Account: updatedAccount,
+						Entry: db.Entry{
+							AccountID: account.ID,
+							Amount:    -amount,
+							Type:      db.EntryTypeFee,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChargeFeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.Balance-amount, res.GetAccount().GetBalance())
+				require.Equal(t, db.EntryTypeFee, res.GetEntry().GetType())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ChargeFeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChargeFeeResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "NegativeAmount",
+			req: &pb.ChargeFeeRequest{
+				AccountId: account.ID,
+				Amount:    -amount,
+				Currency:  utils.USD,
+				Reason:    reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ChargeFeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChargeFeeResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "MissingReason",
+			req: &pb.ChargeFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ChargeFeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChargeFeeResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.ChargeFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  utils.EUR,
+				Reason:    reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChargeFeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChargeFeeResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ChargeFeeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ChargeFeeTxResult{}, fmt.Errorf("%w: balance too low", db.ErrInsufficientFunds))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChargeFeeResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ChargeFee(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
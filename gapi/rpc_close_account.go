@@ -0,0 +1,73 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CloseAccount(ctx context.Context, req *pb.CloseAccountRequest) (*pb.CloseAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_CloseAccount_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCloseAccountRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		if !utils.HasPermission(authPayload.Role, utils.PermissionAccountsCloseAny) {
+			return nil, status.Error(codes.PermissionDenied, "account does not belong to the authenticated user")
+		}
+
+		if req.GetReason() == "" {
+			return nil, inValidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolation("reason", errors.New("is required to close the account of another user")),
+			})
+		}
+	}
+
+	txResult, err := server.updateAccountStatus(ctx, authPayload.Username, account.ID, "", db.AccountStatusClosed, req.GetReason())
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.CloseAccountResponse{
+		Account:                       convertAccount(txResult.Account),
+		DeactivatedScheduledTransfers: txResult.DeactivatedScheduledTransfers,
+	}
+
+	return resp, nil
+}
+
+func validateCloseAccountRequest(req *pb.CloseAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.GetReason() != "" {
+		if err := val.ValidateReason(req.GetReason()); err != nil {
+			violations = append(violations, fieldViolation("reason", err))
+		}
+	}
+
+	return violations
+}
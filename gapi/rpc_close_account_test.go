@@ -0,0 +1,174 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCloseAccountAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	depositor, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(depositor.Username, utils.USD)
+	account.Status = db.AccountStatusActive
+
+	closedAccount := account
+	closedAccount.Balance = 0
+	closedAccount.Status = db.AccountStatusClosed
+
+	testCases := []struct {
+		name          string
+		req           *pb.CloseAccountRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CloseAccountResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.CloseAccountRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.UpdateAccountStatusTxParams{
+					AccountID: account.ID,
+					Status:    db.AccountStatusClosed,
+					Actor:     db.AuditActor{Username: depositor.Username},
+				}
+
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.UpdateAccountStatusTxResult{
+						Account:                       closedAccount,
+						DeactivatedScheduledTransfers: 2,
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CloseAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.AccountStatusClosed, res.GetAccount().GetStatus())
+				require.Equal(t, int64(2), res.GetDeactivatedScheduledTransfers())
+			},
+		},
+		{
+			name: "BankerWithReason",
+			req: &pb.CloseAccountRequest{
+				AccountId: account.ID,
+				Reason:    "customer request by phone",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.UpdateAccountStatusTxParams{
+					AccountID: account.ID,
+					Status:    db.AccountStatusClosed,
+					Reason:    "customer request by phone",
+					Actor:     db.AuditActor{Username: banker.Username},
+				}
+
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.UpdateAccountStatusTxResult{Account: closedAccount}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CloseAccountResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "BankerWithoutReason",
+			req:  &pb.CloseAccountRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CloseAccountResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "NotOwner",
+			req:  &pb.CloseAccountRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CloseAccountResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "BalanceNotZero",
+			req:  &pb.CloseAccountRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdateAccountStatusTxResult{}, fmt.Errorf("%w: balance is 10", db.ErrAccountBalanceNotZero))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CloseAccountResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "AlreadyClosed",
+			req:  &pb.CloseAccountRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(closedAccount, nil)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdateAccountStatusTxResult{}, fmt.Errorf("%w: account is closed", db.ErrAccountStatusTransition))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CloseAccountResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CloseAccount(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ConfirmTOTP_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmTOTPRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	credential, err := server.store.GetTOTPCredential(ctx, authPayload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "no TOTP secret to confirm, call SetupTOTP first")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get TOTP secret: %v", err)
+	}
+
+	if credential.ConfirmedAt.Valid {
+		return nil, status.Errorf(codes.AlreadyExists, "%s", db.ErrTwoFactorEnabled)
+	}
+
+	err = server.checkTwoFactorThrottle(ctx, authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := utils.ValidateTOTP(credential.Secret, req.GetTotpCode(), time.Now(), credential.LastUsedStep)
+
+	if !ok {
+		if err := server.recordTwoFactorFailure(ctx, authPayload.Username); err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid two-factor code")
+	}
+
+	recoveryCodes, err := utils.NewRecoveryCodes()
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate recovery codes: %v", err)
+	}
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+
+	_, err = server.store.EnableTwoFactorTx(ctx, db.EnableTwoFactorTxParams{
+		Username:           authPayload.Username,
+		Step:               step,
+		RecoveryCodeHashes: hashes,
+		Actor:              server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrTOTPCodeReused):
+			return nil, status.Errorf(codes.Unauthenticated, "invalid two-factor code")
+		case errors.Is(err, db.ErrTwoFactorEnabled):
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to enable two-factor authentication: %v", err)
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func validateConfirmTOTPRequest(req *pb.ConfirmTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateTOTPCode(req.GetTotpCode()); err != nil {
+		violations = append(violations, fieldViolation("totp_code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_CreateAccount_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateAccountRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+
+	if err := validateIdempotencyKey(mtdt); err != nil {
+		return nil, err
+	}
+
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Currency: req.GetCurrency(),
+			Balance:  0,
+		},
+		IdempotencyKey: mtdt.IdempotencyKey,
+		Actor:          server.auditActor(ctx, authPayload.Username),
+	}
+
+	txResult, err := server.store.CreateAccountTx(ctx, arg)
+
+	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "account with currency %s already exists", req.GetCurrency())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create account: %v", err)
+	}
+
+	resp := &pb.CreateAccountResponse{
+		Account: convertAccount(txResult.Account),
+	}
+
+	return resp, nil
+}
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateExchangeTransfer(ctx context.Context, req *pb.CreateExchangeTransferRequest) (*pb.CreateExchangeTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_CreateExchangeTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateExchangeTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	quoteID := uuid.MustParse(req.GetQuoteId())
+
+	quote, err := server.store.GetTransferQuote(ctx, quoteID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s", db.ErrQuoteNotFound)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transfer quote: %v", err)
+	}
+
+	// Checked again by the transaction, but first here so that a quote that
+	// cannot be used does not use up the two-factor code.
+	if err := db.CheckTransferQuote(quote, authPayload.Username); err != nil {
+		return nil, transferTxError(err)
+	}
+
+	err = server.requireStepUp(ctx, authPayload.Username, quote.FromAmount, req.GetTotpCode())
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.ExchangeTransferTx(ctx, db.ExchangeTransferTxParams{
+		QuoteID: quoteID,
+		Owner:   authPayload.Username,
+		Actor:   server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, transferTxError(err)
+	}
+
+	resp := &pb.CreateExchangeTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+
+	return resp, nil
+}
+
+func validateCreateExchangeTransferRequest(req *pb.CreateExchangeTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateQuoteID(req.GetQuoteId()); err != nil {
+		violations = append(violations, fieldViolation("quote_id", err))
+	}
+
+	if req.GetTotpCode() != "" {
+		if err := val.ValidateTOTPCode(req.GetTotpCode()); err != nil {
+			violations = append(violations, fieldViolation("totp_code", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,152 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateExchangeTransferStepUp(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	credential := randomTOTPCredential(t, user1.Username)
+
+	threshold := int64(1000)
+
+	quote := db.TransferQuote{
+		ID:            uuid.New(),
+		Username:      user1.Username,
+		FromAccountID: utils.RandomInt(1, 1000),
+		ToAccountID:   utils.RandomInt(1001, 2000),
+		FromAmount:    threshold + 1,
+		FromCurrency:  utils.USD,
+		ToAmount:      threshold,
+		ToCurrency:    utils.EUR,
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+
+	// The quote is checked before the step up, so a code is never used up on
+	// a quote that cannot be executed.
+	noStepUp := func(store *mockdb.MockStore) {
+		store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().ExchangeTransferTx(gomock.Any(), gomock.Any()).Times(0)
+	}
+
+	testCases := []struct {
+		name          string
+		quote         func() db.TransferQuote
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateExchangeTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			quote: func() db.TransferQuote {
+				return quote
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).Times(2).Return(credential, nil)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+				store.EXPECT().
+					ExchangeTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ExchangeTransferTxParams) (db.ExchangeTransferTxResult, error) {
+						require.Equal(t, quote.ID, arg.QuoteID)
+						require.Equal(t, user1.Username, arg.Owner)
+						return db.ExchangeTransferTxResult{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateExchangeTransferResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "OtherUsersQuote",
+			quote: func() db.TransferQuote {
+				other := quote
+				other.Username = user2.Username
+				return other
+			},
+			buildStubs: noStepUp,
+			checkResponse: func(t *testing.T, res *pb.CreateExchangeTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "UsedQuote",
+			quote: func() db.TransferQuote {
+				used := quote
+				used.IsUsed = true
+				return used
+			},
+			buildStubs: noStepUp,
+			checkResponse: func(t *testing.T, res *pb.CreateExchangeTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "ExpiredQuote",
+			quote: func() db.TransferQuote {
+				expired := quote
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				return expired
+			},
+			buildStubs: noStepUp,
+			checkResponse: func(t *testing.T, res *pb.CreateExchangeTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			store.EXPECT().GetTransferQuote(gomock.Any(), gomock.Eq(quote.ID)).Times(1).Return(tc.quote(), nil)
+			tc.buildStubs(store)
+			store.EXPECT().
+				GetLoginFailure(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+
+			server := newTestServer(t, store, nil)
+			server.config.TwoFactorTransferThreshold = threshold
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+
+			code, err := utils.TOTPCode(credential.Secret, time.Now())
+			require.NoError(t, err)
+
+			res, err := server.CreateExchangeTransfer(ctx, &pb.CreateExchangeTransferRequest{
+				QuoteId:  quote.ID.String(),
+				TotpCode: code,
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
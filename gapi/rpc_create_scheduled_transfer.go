@@ -0,0 +1,133 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_CreateScheduledTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Error(codes.PermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	_, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.requireStepUp(ctx, authPayload.Username, req.GetAmount(), req.GetTotpCode())
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := utils.ParseSchedule(req.GetCronExpression(), req.GetIntervalSeconds())
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	nextRunAt := schedule.Next(time.Now())
+
+	if req.StartAt != nil {
+		nextRunAt = req.GetStartAt().AsTime()
+	}
+
+	arg := db.CreateScheduledTransferParams{
+		Owner:         authPayload.Username,
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+		CronExpression: pgtype.Text{
+			String: req.GetCronExpression(),
+			Valid:  req.CronExpression != nil,
+		},
+		IntervalSeconds: pgtype.Int8{
+			Int64: req.GetIntervalSeconds(),
+			Valid: req.IntervalSeconds != nil,
+		},
+		NextRunAt: nextRunAt,
+	}
+
+	txResult, err := server.store.CreateScheduledTransferTx(ctx, db.CreateScheduledTransferTxParams{
+		CreateScheduledTransferParams: arg,
+		Actor:                         server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %v", err)
+	}
+
+	resp := &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(txResult.ScheduledTransfer),
+	}
+
+	return resp, nil
+}
+
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must differ from from_account_id")))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateSchedule(req.GetCronExpression(), req.GetIntervalSeconds()); err != nil {
+		violations = append(violations, fieldViolation("schedule", err))
+	}
+
+	if req.StartAt != nil && !req.GetStartAt().AsTime().After(time.Now()) {
+		violations = append(violations, fieldViolation("start_at", fmt.Errorf("must be in the future")))
+	}
+
+	if req.GetTotpCode() != "" {
+		if err := val.ValidateTOTPCode(req.GetTotpCode()); err != nil {
+			violations = append(violations, fieldViolation("totp_code", err))
+		}
+	}
+
+	return violations
+}
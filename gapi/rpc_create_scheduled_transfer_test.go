@@ -0,0 +1,175 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateScheduledTransferAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username, utils.USD)
+	account2 := randomAccount(user2.Username, utils.USD)
+	account2.ID = account1.ID + 1
+
+	amount := int64(100)
+	cronExpression := "0 9 1 * *"
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateScheduledTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:  account1.ID,
+				ToAccountId:    account2.ID,
+				Amount:         amount,
+				Currency:       utils.USD,
+				CronExpression: proto.String(cronExpression),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					CreateScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, txArg db.CreateScheduledTransferTxParams) (db.CreateScheduledTransferTxResult, error) {
+						arg := txArg.CreateScheduledTransferParams
+
+						require.Equal(t, user1.Username, txArg.Actor.Username)
+						require.Equal(t, user1.Username, arg.Owner)
+						require.Equal(t, cronExpression, arg.CronExpression.String)
+						require.False(t, arg.IntervalSeconds.Valid)
+						require.True(t, arg.NextRunAt.After(time.Now()))
+						require.Equal(t, 1, arg.NextRunAt.Day())
+
+						scheduledTransfer := db.ScheduledTransfer{
+							ID:             utils.RandomInt(1, 1000),
+							Owner:          arg.Owner,
+							FromAccountID:  arg.FromAccountID,
+							ToAccountID:    arg.ToAccountID,
+							Amount:         arg.Amount,
+							CronExpression: arg.CronExpression,
+							NextRunAt:      arg.NextRunAt,
+							IsActive:       true,
+						}
+
+						return db.CreateScheduledTransferTxResult{ScheduledTransfer: scheduledTransfer}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, cronExpression, res.GetScheduledTransfer().GetCronExpression())
+				require.True(t, res.GetScheduledTransfer().GetIsActive())
+			},
+		},
+		{
+			name: "BothSchedules",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account1.ID,
+				ToAccountId:     account2.ID,
+				Amount:          amount,
+				Currency:        utils.USD,
+				CronExpression:  proto.String(cronExpression),
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "IntervalTooShort",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account1.ID,
+				ToAccountId:     account2.ID,
+				Amount:          amount,
+				Currency:        utils.USD,
+				IntervalSeconds: proto.Int64(10),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NotAccountOwner",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account2.ID,
+				ToAccountId:     account1.ID,
+				Amount:          amount,
+				Currency:        utils.USD,
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateScheduledTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.CreateScheduledTransfer(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_CreateTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+
+	if err := validateIdempotencyKey(mtdt); err != nil {
+		return nil, err
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Error(codes.PermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	_, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID:  req.GetFromAccountId(),
+		ToAccountID:    req.GetToAccountId(),
+		Amount:         req.GetAmount(),
+		Owner:          authPayload.Username,
+		IdempotencyKey: mtdt.IdempotencyKey,
+		Actor:          server.auditActor(ctx, authPayload.Username),
+	}
+
+	var result db.TransferTxResult
+	replayed := false
+
+	// A retry of a completed transfer returns the original result without a
+	// new step-up, since the TOTP code of the first request is already used.
+	if arg.IdempotencyKey != "" {
+		result, replayed, err = server.store.ReplayTransferTx(ctx, arg)
+
+		if err != nil {
+			return nil, transferTxError(err)
+		}
+	}
+
+	if !replayed {
+		err = server.requireStepUp(ctx, authPayload.Username, req.GetAmount(), req.GetTotpCode())
+
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = server.store.TransferTx(ctx, arg)
+
+		if err != nil {
+			return nil, transferTxError(err)
+		}
+	}
+
+	resp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+
+	return resp, nil
+}
+
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account %d not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account %d currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.GetTotpCode() != "" {
+		if err := val.ValidateTOTPCode(req.GetTotpCode()); err != nil {
+			violations = append(violations, fieldViolation("totp_code", err))
+		}
+	}
+
+	return violations
+}
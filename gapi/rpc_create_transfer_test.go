@@ -0,0 +1,657 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func randomAccount(owner string, currency string) db.Account {
+	return db.Account{
+		ID:       utils.RandomInt(1, 1000),
+		Owner:    owner,
+		Balance:  utils.RandomMoney(),
+		Currency: currency,
+	}
+}
+
+func TestCreateTransferAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username, utils.USD)
+	account2 := randomAccount(user2.Username, utils.USD)
+	account3 := randomAccount(user2.Username, utils.EUR)
+
+	account2.ID = account1.ID + 1
+	account3.ID = account1.ID + 2
+
+	amount := int64(10)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				arg := db.TransferTxParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					Owner:         user1.Username,
+					Actor:         db.AuditActor{Username: user1.Username},
+				}
+
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.TransferTxResult{
+						Transfer: db.Transfer{
+							FromAccountID: account1.ID,
+							ToAccountID:   account2.ID,
+							Amount:        amount,
+						},
+						FromAccount: account1,
+						ToAccount:   account2,
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res)
+				require.Equal(t, account1.ID, res.GetTransfer().GetFromAccountId())
+				require.Equal(t, account2.ID, res.GetTransfer().GetToAccountId())
+				require.Equal(t, amount, res.GetTransfer().GetAmount())
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "ToAccountCurrencyMismatch",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account3.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "ToAccountFrozen",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, fmt.Errorf("%w: account %d is frozen", db.ErrAccountUnavailable, account2.ID))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "ToAccountNotFound",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(db.Account{}, db.ErrorRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        -amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "TransferTxError",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, sql.ErrTxDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "VelocityLimitExceeded",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, &db.TransferLimitError{
+					AccountID: account1.ID,
+					Violations: []db.TransferLimitViolation{
+						{Limit: db.TransferLimitDailyAmount, Max: 100, Value: 105},
+						{Limit: db.TransferLimitHourlyCount, Max: 3, Value: 4},
+					},
+				})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+				require.Contains(t, st.Message(), db.TransferLimitDailyAmount)
+
+				require.Len(t, st.Details(), 1)
+				quotaFailure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+				require.True(t, ok)
+				require.Len(t, quotaFailure.GetViolations(), 2)
+				require.Equal(t, fmt.Sprintf("account:%d/%s", account1.ID, db.TransferLimitDailyAmount), quotaFailure.GetViolations()[0].GetSubject())
+				require.Equal(t, fmt.Sprintf("account:%d/%s", account1.ID, db.TransferLimitHourlyCount), quotaFailure.GetViolations()[1].GetSubject())
+			},
+		},
+		{
+			name: "PerTransferLimitExceeded",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, &db.TransferLimitError{
+					AccountID: account1.ID,
+					Violations: []db.TransferLimitViolation{
+						{Limit: db.TransferLimitPerTransfer, Max: 5, Value: amount},
+						{Limit: db.TransferLimitDailyAmount, Max: 5, Value: amount},
+					},
+				})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+				require.Contains(t, st.Message(), db.TransferLimitPerTransfer)
+			},
+		},
+		{
+			name: "NoAuthentication",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.CreateTransfer(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCreateTransferStepUp(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username, utils.USD)
+	account2 := randomAccount(user2.Username, utils.USD)
+	account2.ID = account1.ID + 1
+
+	credential := randomTOTPCredential(t, user1.Username)
+
+	threshold := int64(1000)
+
+	testCases := []struct {
+		name          string
+		amount        int64
+		totpCode      func(t *testing.T) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name:   "BelowThreshold",
+			amount: threshold,
+			totpCode: func(t *testing.T) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "ValidCode",
+			amount: threshold + 1,
+			totpCode: func(t *testing.T) string {
+				code, err := utils.TOTPCode(credential.Secret, time.Now())
+				require.NoError(t, err)
+				return code
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).Times(2).Return(credential, nil)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "InvalidCode",
+			amount: threshold + 1,
+			totpCode: func(t *testing.T) string {
+				code, err := utils.TOTPCode(credential.Secret, time.Now().Add(-time.Hour))
+				require.NoError(t, err)
+				return code
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).Times(2).Return(credential, nil)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), loginFailureScope(db.LoginFailureScopeTwoFactor)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:   "TwoFactorLocked",
+			amount: threshold + 1,
+			totpCode: func(t *testing.T) string {
+				code, err := utils.TOTPCode(credential.Secret, time.Now())
+				require.NoError(t, err)
+				return code
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).Times(1).Return(credential, nil)
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(db.GetLoginFailureParams{
+						Scope: db.LoginFailureScopeTwoFactor,
+						Key:   user1.Username,
+					})).
+					Times(1).
+					Return(db.LoginFailure{
+						FailedCount: 5,
+						LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+					}, nil)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+			},
+		},
+		{
+			name:   "MissingCode",
+			amount: threshold + 1,
+			totpCode: func(t *testing.T) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).Times(1).Return(credential, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name:   "TwoFactorNotEnabled",
+			amount: threshold + 1,
+			totpCode: func(t *testing.T) string {
+				return "123456"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).Times(1).Return(db.TotpCredential{}, db.ErrorRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			tc.buildStubs(store)
+			store.EXPECT().
+				GetLoginFailure(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+
+			server := newTestServer(t, store, nil)
+			server.config.TwoFactorTransferThreshold = threshold
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+
+			res, err := server.CreateTransfer(ctx, &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        tc.amount,
+				Currency:      utils.USD,
+				TotpCode:      tc.totpCode(t),
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCreateTransferIdempotentReplay(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username, utils.USD)
+	account2 := randomAccount(user2.Username, utils.USD)
+	account2.ID = account1.ID + 1
+
+	threshold := int64(1000)
+	amount := threshold + 1
+	idempotencyKey := utils.RandomString(16)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name: "Replayed",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.TransferTxParams{
+					FromAccountID:  account1.ID,
+					ToAccountID:    account2.ID,
+					Amount:         amount,
+					Owner:          user1.Username,
+					IdempotencyKey: idempotencyKey,
+					Actor:          db.AuditActor{Username: user1.Username},
+				}
+
+				result := db.TransferTxResult{
+					Transfer: db.Transfer{
+						ID:            utils.RandomInt(1, 1000),
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					},
+				}
+
+				store.EXPECT().
+					ReplayTransferTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(result, true, nil)
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, amount, res.GetTransfer().GetAmount())
+			},
+		},
+		{
+			name: "NotReplayedNeedsStepUp",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReplayTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, false, nil)
+				store.EXPECT().
+					GetTOTPCredential(gomock.Any(), gomock.Eq(user1.Username)).
+					Times(1).
+					Return(randomTOTPCredential(t, user1.Username), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "KeyConflict",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReplayTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, false, db.ErrIdempotencyKeyConflict)
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			server.config.TwoFactorTransferThreshold = threshold
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			md, _ := metadata.FromIncomingContext(ctx)
+			ctx = metadata.NewIncomingContext(ctx, metadata.Join(md, metadata.Pairs(idempotencyKeyHeader, idempotencyKey)))
+
+			res, err := server.CreateTransfer(ctx, &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      utils.USD,
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
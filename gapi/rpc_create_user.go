@@ -7,7 +7,6 @@ import (
 	"github.com/hibiken/asynq"
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/pb"
-	"github.com/starjardin/simplebank/utils"
 	"github.com/starjardin/simplebank/val"
 	"github.com/starjardin/simplebank/worker"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -17,14 +16,14 @@ import (
 
 func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 
-	violations := validateCreateUserRequest(req)
+	violations := validateCreateUserRequest(req, server.passwordPolicy)
 
 	if violations != nil {
 		return nil, inValidArgumentError(violations)
 
 	}
 
-	hashedPassword, err := utils.HashedPassword(req.GetPassword())
+	hashedPassword, err := server.passwordHasher.Hash(req.GetPassword())
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
@@ -37,6 +36,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 			Email:          req.GetEmail(),
 			HashedPassword: hashedPassword,
 		},
+		Actor: server.auditActor(ctx, ""),
 		AfterCreate: func(user db.User) error {
 			taskPayload := worker.PayloadSendVerifyEmail{
 				Username: user.Username,
@@ -70,12 +70,12 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	return resp, nil
 }
 
-func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+func validateCreateUserRequest(req *pb.CreateUserRequest, passwordPolicy val.PasswordPolicy) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := val.ValidateUsername(req.GetUsername()); err != nil {
 		violations = append(violations, fieldViolation("username", err))
 	}
 
-	if err := val.ValidatePassword(req.GetPassword()); err != nil {
+	if err := passwordPolicy.Validate(req.GetPassword(), req.GetUsername(), req.GetEmail()); err != nil {
 		violations = append(violations, fieldViolation("password", err))
 	}
 
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteScheduledTransfer(ctx context.Context, req *pb.DeleteScheduledTransferRequest) (*pb.DeleteScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_DeleteScheduledTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.ownedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.DeleteScheduledTransferTx(ctx, db.DeleteScheduledTransferTxParams{
+		ID:    scheduledTransfer.ID,
+		Actor: server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete scheduled transfer: %v", err)
+	}
+
+	return &pb.DeleteScheduledTransferResponse{}, nil
+}
+
+func validateDeleteScheduledTransferRequest(req *pb.DeleteScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
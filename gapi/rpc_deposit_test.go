@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDepositAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	depositor, _ := randomUser(t)
+	account := randomAccount(depositor.Username, utils.USD)
+
+	amount := int64(100)
+	reference := utils.RandomString(12)
+
+	req := &pb.DepositRequest{
+		AccountId:         account.ID,
+		Amount:            amount,
+		Currency:          utils.USD,
+		ExternalReference: reference,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.DepositRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.DepositResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.DepositTxParams{
+					AccountID:         account.ID,
+					Amount:            amount,
+					ExternalReference: reference,
+					Actor:             db.AuditActor{Username: banker.Username},
+				}
+
+				updatedAccount := account
+				updatedAccount.Balance += amount
+
+				store.EXPECT().
+					DepositTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.DepositTxResult{
+						Account: updatedAccount,
+						Entry: db.Entry{
+							AccountID: account.ID,
+							Amount:    amount,
+							Type:      db.EntryTypeDeposit,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.Balance+amount, res.GetAccount().GetBalance())
+				require.Equal(t, db.EntryTypeDeposit, res.GetEntry().GetType())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "DuplicateReference",
+			req:  req,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					DepositTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DepositTxResult{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "MissingReference",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.Deposit(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_DisableTOTP_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDisableTOTPRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	err = server.verifySecondFactor(ctx, authPayload.Username, req.GetTotpCode(), req.GetRecoveryCode())
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.DisableTwoFactorTx(ctx, db.DisableTwoFactorTxParams{
+		Username: authPayload.Username,
+		Actor:    server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to disable two-factor authentication: %v", err)
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
+
+func validateDisableTOTPRequest(req *pb.DisableTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	return validateSecondFactor(req.GetTotpCode(), req.GetRecoveryCode())
+}
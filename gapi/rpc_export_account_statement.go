@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/statement"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"github.com/starjardin/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ExportAccountStatement(ctx context.Context, req *pb.ExportAccountStatementRequest) (*pb.ExportAccountStatementResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ExportAccountStatement_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateExportAccountStatementRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username && !utils.HasPermission(authPayload.Role, utils.PermissionStatementsReadAny) {
+		return nil, status.Error(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	// The statement always goes to the owner, also when a banker asks for it.
+	taskPayload := worker.PayloadSendAccountStatement{
+		Username:    account.Owner,
+		RequestedBy: authPayload.Username,
+		AccountID:   account.ID,
+		StartTime:   req.GetStartTime().AsTime(),
+		EndTime:     exportEndTime(req),
+		Format:      req.GetFormat(),
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(5),
+		asynq.Queue(worker.QueueDefault),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendAccountStatement(ctx, taskPayload, opts...)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to schedule statement export: %v", err)
+	}
+
+	return &pb.ExportAccountStatementResponse{}, nil
+}
+
+func exportEndTime(req *pb.ExportAccountStatementRequest) time.Time {
+	if req.EndTime == nil {
+		return time.Now()
+	}
+	return req.GetEndTime().AsTime()
+}
+
+func validateExportAccountStatementRequest(req *pb.ExportAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.StartTime == nil {
+		violations = append(violations, fieldViolation("start_time", errors.New("must be provided")))
+	} else if !exportEndTime(req).After(req.GetStartTime().AsTime()) {
+		violations = append(violations, fieldViolation("end_time", errors.New("must be after start_time")))
+	}
+
+	if !statement.IsSupportedFormat(req.GetFormat()) {
+		violations = append(violations, fieldViolation("format", fmt.Errorf("must be one of %s, %s, %s, %s", statement.FormatCSV, statement.FormatOFX, statement.FormatQFX, statement.FormatPDF)))
+	}
+
+	return violations
+}
@@ -0,0 +1,168 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/statement"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/worker"
+	mockworker "github.com/starjardin/simplebank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExportAccountStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username, utils.USD)
+
+	startTime := time.Now().Add(-30 * 24 * time.Hour).UTC()
+	endTime := time.Now().UTC()
+
+	testCases := []struct {
+		name          string
+		req           *pb.ExportAccountStatementRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ExportAccountStatementResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ExportAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(endTime),
+				Format:    statement.FormatOFX,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				payload := worker.PayloadSendAccountStatement{
+					Username:    user.Username,
+					RequestedBy: user.Username,
+					AccountID:   account.ID,
+					StartTime:   startTime,
+					EndTime:     endTime,
+					Format:      statement.FormatOFX,
+				}
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountStatement(gomock.Any(), gomock.Eq(payload), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportAccountStatementResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "UnsupportedFormat",
+			req: &pb.ExportAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				Format:    "xls",
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				taskDistributor.EXPECT().DistributeTaskSendAccountStatement(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportAccountStatementResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "BankerSendsToOwner",
+			req: &pb.ExportAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(endTime),
+				Format:    statement.FormatCSV,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				payload := worker.PayloadSendAccountStatement{
+					Username:    user.Username,
+					RequestedBy: otherUser.Username,
+					AccountID:   account.ID,
+					StartTime:   startTime,
+					EndTime:     endTime,
+					Format:      statement.FormatCSV,
+				}
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountStatement(gomock.Any(), gomock.Eq(payload), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportAccountStatementResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "NotAccountOwner",
+			req: &pb.ExportAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				Format:    statement.FormatPDF,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				taskDistributor.EXPECT().DistributeTaskSendAccountStatement(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportAccountStatementResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockworker.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.ExportAccountStatement(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+)
+
+func (server *Server) FreezeAccount(ctx context.Context, req *pb.FreezeAccountRequest) (*pb.FreezeAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_FreezeAccount_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountStatusRequest(req.GetAccountId(), req.GetReason())
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	txResult, err := server.updateAccountStatus(ctx, authPayload.Username, req.GetAccountId(), "", db.AccountStatusFrozen, req.GetReason())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FreezeAccountResponse{Account: convertAccount(txResult.Account)}, nil
+}
+
+func (server *Server) UnfreezeAccount(ctx context.Context, req *pb.UnfreezeAccountRequest) (*pb.UnfreezeAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_UnfreezeAccount_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountStatusRequest(req.GetAccountId(), req.GetReason())
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	txResult, err := server.updateAccountStatus(ctx, authPayload.Username, req.GetAccountId(), db.AccountStatusFrozen, db.AccountStatusActive, req.GetReason())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UnfreezeAccountResponse{Account: convertAccount(txResult.Account)}, nil
+}
@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) FreezeUser(ctx context.Context, req *pb.FreezeUserRequest) (*pb.FreezeUserResponse, error) {
+	txResult, err := server.freezeUser(ctx, pb.SimpleBank_FreezeUser_FullMethodName, req.GetUsername(), true, req.GetReason())
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.FreezeUserResponse{
+		User:                          convertUser(txResult.User),
+		RevokedSessions:               txResult.RevokedSessions,
+		DeactivatedScheduledTransfers: txResult.DeactivatedScheduledTransfers,
+	}
+
+	return resp, nil
+}
+
+func (server *Server) UnfreezeUser(ctx context.Context, req *pb.UnfreezeUserRequest) (*pb.UnfreezeUserResponse, error) {
+	txResult, err := server.freezeUser(ctx, pb.SimpleBank_UnfreezeUser_FullMethodName, req.GetUsername(), false, req.GetReason())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UnfreezeUserResponse{User: convertUser(txResult.User)}, nil
+}
+
+func (server *Server) freezeUser(ctx context.Context, method string, username string, frozen bool, reason string) (db.FreezeUserTxResult, error) {
+	authPayload, err := server.authorizeUser(ctx, method)
+
+	if err != nil {
+		return db.FreezeUserTxResult{}, unauthenticatedError(err)
+	}
+
+	violations := validateFreezeUserRequest(username, reason)
+
+	if violations != nil {
+		return db.FreezeUserTxResult{}, inValidArgumentError(violations)
+	}
+
+	if username == authPayload.Username {
+		return db.FreezeUserTxResult{}, status.Error(codes.PermissionDenied, "cannot freeze or unfreeze yourself")
+	}
+
+	txResult, err := server.store.FreezeUserTx(ctx, db.FreezeUserTxParams{
+		Username: username,
+		Frozen:   frozen,
+		Reason:   reason,
+		Actor:    server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrorRecordNotFound):
+			return txResult, status.Errorf(codes.NotFound, "user not found")
+		case errors.Is(err, db.ErrAlreadyFrozen), errors.Is(err, db.ErrNotFrozen):
+			return txResult, status.Errorf(codes.FailedPrecondition, "user is %s", err)
+		}
+		return txResult, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	}
+
+	// Access tokens issued before the freeze are rejected from now on.
+	server.revocations.UserChanged(username)
+
+	return txResult, nil
+}
+
+func validateFreezeUserRequest(username string, reason string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(username); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidateReason(reason); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,169 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFreezeUserAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	user, _ := randomUser(t)
+	user.Role = utils.DepositorRole
+
+	reason := "suspected account takeover"
+
+	testCases := []struct {
+		name          string
+		req           *pb.FreezeUserRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.FreezeUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.FreezeUserRequest{
+				Username: user.Username,
+				Reason:   reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.FreezeUserTxParams{
+					Username: user.Username,
+					Frozen:   true,
+					Reason:   reason,
+					Actor:    db.AuditActor{Username: banker.Username},
+				}
+
+				frozen := user
+				frozen.FrozenAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+				store.EXPECT().
+					FreezeUserTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.FreezeUserTxResult{User: frozen, RevokedSessions: 2, DeactivatedScheduledTransfers: 1}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.FreezeUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res.GetUser().GetFrozenAt())
+				require.Equal(t, int64(2), res.GetRevokedSessions())
+				require.Equal(t, int64(1), res.GetDeactivatedScheduledTransfers())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.FreezeUserRequest{
+				Username: user.Username,
+				Reason:   reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FreezeUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.FreezeUserResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "Self",
+			req: &pb.FreezeUserRequest{
+				Username: banker.Username,
+				Reason:   reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FreezeUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.FreezeUserResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "MissingReason",
+			req: &pb.FreezeUserRequest{
+				Username: user.Username,
+				Reason:   "  ",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FreezeUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.FreezeUserResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "AlreadyFrozen",
+			req: &pb.FreezeUserRequest{
+				Username: user.Username,
+				Reason:   reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FreezeUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FreezeUserTxResult{}, db.ErrAlreadyFrozen)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.FreezeUserResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "UserNotFound",
+			req: &pb.FreezeUserRequest{
+				Username: user.Username,
+				Reason:   reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FreezeUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FreezeUserTxResult{}, db.ErrorRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.FreezeUserResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.FreezeUser(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_GetAccount_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	if account.Owner != authPayload.Username && !utils.HasPermission(authPayload.Role, utils.PermissionAccountsReadAny) {
+		return nil, status.Error(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	resp := &pb.GetAccountResponse{
+		Account: convertAccount(account),
+	}
+
+	return resp, nil
+}
+
+func validateGetAccountRequest(req *pb.GetAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
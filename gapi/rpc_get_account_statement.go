@@ -0,0 +1,187 @@
+package gapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statementCursor is the position of the last entry of a statement page.
+type statementCursor struct {
+	CreatedAt time.Time
+	EntryID   int64
+}
+
+func (cursor statementCursor) encode() string {
+	value := fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.EntryID)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeStatementCursor(token string) (statementCursor, error) {
+	var cursor statementCursor
+
+	value, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, fmt.Errorf("invalid page token")
+	}
+
+	parts := strings.Split(string(value), ":")
+	if len(parts) != 2 {
+		return cursor, fmt.Errorf("invalid page token")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return cursor, fmt.Errorf("invalid page token")
+	}
+
+	cursor.EntryID, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return cursor, fmt.Errorf("invalid page token")
+	}
+
+	cursor.CreatedAt = time.Unix(0, nanos).UTC()
+	return cursor, nil
+}
+
+func (server *Server) GetAccountStatement(ctx context.Context, req *pb.GetAccountStatementRequest) (*pb.GetAccountStatementResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_GetAccountStatement_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountStatementRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username && !utils.HasPermission(authPayload.Role, utils.PermissionStatementsReadAny) {
+		return nil, status.Error(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	startTime := req.GetStartTime().AsTime()
+	endTime := statementEndTime(req)
+
+	cursor := statementCursor{CreatedAt: startTime}
+
+	if req.GetPageToken() != "" {
+		cursor, _ = decodeStatementCursor(req.GetPageToken())
+	}
+
+	rows, err := server.store.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+		AccountID:      account.ID,
+		StartTime:      statementTimestamp(startTime),
+		EndTime:        statementTimestamp(endTime),
+		AfterCreatedAt: statementTimestamp(cursor.CreatedAt),
+		AfterID:        cursor.EntryID,
+		LimitCount:     req.GetPageSize() + 1,
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list statement entries: %v", err)
+	}
+
+	openingBalance, err := server.store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		AccountID: account.ID,
+		At:        statementTimestamp(startTime),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get opening balance: %v", err)
+	}
+
+	closingBalance, err := server.store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		AccountID: account.ID,
+		At:        statementTimestamp(endTime),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get closing balance: %v", err)
+	}
+
+	resp := &pb.GetAccountStatementResponse{
+		Account:        convertAccount(account),
+		OpeningBalance: openingBalance,
+		ClosingBalance: closingBalance,
+	}
+
+	if len(rows) > int(req.GetPageSize()) {
+		rows = rows[:req.GetPageSize()]
+		last := rows[len(rows)-1].Entry
+
+		resp.NextPageToken = statementCursor{
+			CreatedAt: last.CreatedAt.Time,
+			EntryID:   last.ID,
+		}.encode()
+	}
+
+	for _, row := range rows {
+		resp.Entries = append(resp.Entries, &pb.StatementEntry{
+			Entry:                 convertEntry(row.Entry),
+			CounterpartyAccountId: row.CounterpartyAccountID,
+			CounterpartyOwner:     row.CounterpartyOwner,
+		})
+	}
+
+	return resp, nil
+}
+
+func statementEndTime(req *pb.GetAccountStatementRequest) time.Time {
+	if req.EndTime == nil {
+		return time.Now()
+	}
+	return req.GetEndTime().AsTime()
+}
+
+// Entry times are stored without a time zone, in UTC.
+func statementTimestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{
+		Time:  t.UTC(),
+		Valid: true,
+	}
+}
+
+func validateGetAccountStatementRequest(req *pb.GetAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.StartTime == nil {
+		violations = append(violations, fieldViolation("start_time", errors.New("must be provided")))
+	} else if !statementEndTime(req).After(req.GetStartTime().AsTime()) {
+		violations = append(violations, fieldViolation("end_time", errors.New("must be after start_time")))
+	}
+
+	if err := val.ValidateStatementPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if req.GetPageToken() != "" {
+		if _, err := decodeStatementCursor(req.GetPageToken()); err != nil {
+			violations = append(violations, fieldViolation("page_token", err))
+		}
+	}
+
+	return violations
+}
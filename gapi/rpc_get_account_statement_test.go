@@ -0,0 +1,216 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func randomStatementRows(account db.Account, n int, start time.Time) []db.ListStatementEntriesRow {
+	rows := make([]db.ListStatementEntriesRow, n)
+	balance := account.Balance
+
+	for i := range rows {
+		amount := utils.RandomMoney()
+		balance += amount
+
+		rows[i] = db.ListStatementEntriesRow{
+			Entry: db.Entry{
+				ID:           int64(i + 1),
+				AccountID:    account.ID,
+				Amount:       amount,
+				Type:         db.EntryTypeTransfer,
+				BalanceAfter: balance,
+				CreatedAt: pgtype.Timestamp{
+					Time:  start.Add(time.Duration(i) * time.Minute),
+					Valid: true,
+				},
+			},
+			CounterpartyAccountID: account.ID + 1,
+			CounterpartyOwner:     utils.RandomOwner(),
+		}
+	}
+
+	return rows
+}
+
+func TestGetAccountStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username, utils.USD)
+
+	startTime := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Microsecond)
+	pageSize := int32(5)
+	rows := randomStatementRows(account, int(pageSize)+1, startTime)
+
+	testCases := []struct {
+		name          string
+		req           *pb.GetAccountStatementRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.GetAccountStatementResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.GetAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				PageSize:  pageSize,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListStatementEntries(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
+						require.Equal(t, pageSize+1, arg.LimitCount)
+						require.Equal(t, startTime, arg.AfterCreatedAt.Time)
+						require.Zero(t, arg.AfterID)
+						return rows, nil
+					})
+				store.EXPECT().
+					GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(account.Balance, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), int(pageSize))
+				require.Equal(t, rows[pageSize-1].Entry.BalanceAfter, res.GetEntries()[pageSize-1].GetEntry().GetBalanceAfter())
+				require.Equal(t, rows[0].CounterpartyOwner, res.GetEntries()[0].GetCounterpartyOwner())
+
+				cursor, err := decodeStatementCursor(res.GetNextPageToken())
+				require.NoError(t, err)
+				require.Equal(t, rows[pageSize-1].Entry.ID, cursor.EntryID)
+				require.True(t, rows[pageSize-1].Entry.CreatedAt.Time.Equal(cursor.CreatedAt))
+			},
+		},
+		{
+			name: "LastPage",
+			req: &pb.GetAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				PageSize:  pageSize,
+				PageToken: statementCursor{CreatedAt: rows[0].Entry.CreatedAt.Time, EntryID: rows[0].Entry.ID}.encode(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListStatementEntries(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
+						require.Equal(t, rows[0].Entry.ID, arg.AfterID)
+						return rows[1:], nil
+					})
+				store.EXPECT().
+					GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(account.Balance, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), int(pageSize))
+				require.Empty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			name: "NotAccountOwner",
+			req: &pb.GetAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				PageSize:  pageSize,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "InvalidDateRange",
+			req: &pb.GetAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(startTime.Add(-time.Hour)),
+				PageSize:  pageSize,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidPageToken",
+			req: &pb.GetAccountStatementRequest{
+				AccountId: account.ID,
+				StartTime: timestamppb.New(startTime),
+				PageSize:  pageSize,
+				PageToken: "not-a-token",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.GetAccountStatement(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
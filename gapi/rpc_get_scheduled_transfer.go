@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const recentScheduledTransferRuns = 10
+
+func (server *Server) GetScheduledTransfer(ctx context.Context, req *pb.GetScheduledTransferRequest) (*pb.GetScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_GetScheduledTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.ownedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := server.store.ListScheduledTransferRuns(ctx, db.ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               recentScheduledTransferRuns,
+		Offset:              0,
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfer runs: %v", err)
+	}
+
+	resp := &pb.GetScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+		RecentRuns:        convertScheduledTransferRuns(runs),
+	}
+
+	return resp, nil
+}
+
+func (server *Server) ownedScheduledTransfer(ctx context.Context, id int64, owner string) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := server.store.GetScheduledTransfer(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return scheduledTransfer, status.Errorf(codes.NotFound, "scheduled transfer %d not found", id)
+		}
+		return scheduledTransfer, status.Errorf(codes.Internal, "failed to get scheduled transfer: %v", err)
+	}
+
+	if scheduledTransfer.Owner != owner {
+		return scheduledTransfer, status.Error(codes.PermissionDenied, "scheduled transfer does not belong to the authenticated user")
+	}
+
+	return scheduledTransfer, nil
+}
+
+func validateGetScheduledTransferRequest(req *pb.GetScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.GetTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_GetTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, req.GetId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "transfer not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transfer: %v", err)
+	}
+
+	if utils.HasPermission(authPayload.Role, utils.PermissionTransfersReadAny) {
+		return &pb.GetTransferResponse{
+			Transfer: convertTransfer(transfer),
+		}, nil
+	}
+
+	fromAccount, err := server.store.GetAccount(ctx, transfer.FromAccountID)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		toAccount, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+		}
+
+		if toAccount.Owner != authPayload.Username {
+			return nil, status.Error(codes.PermissionDenied, "transfer does not involve an account of the authenticated user")
+		}
+	}
+
+	resp := &pb.GetTransferResponse{
+		Transfer: convertTransfer(transfer),
+	}
+
+	return resp, nil
+}
+
+func validateGetTransferRequest(req *pb.GetTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
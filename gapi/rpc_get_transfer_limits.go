@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetTransferLimits(ctx context.Context, req *pb.GetTransferLimitsRequest) (*pb.GetTransferLimitsResponse, error) {
+	_, err := server.authorizeUser(ctx, pb.SimpleBank_GetTransferLimits_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetTransferLimitsRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := server.store.GetAccountTransferLimits(ctx, account)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transfer limits: %v", err)
+	}
+
+	resp := &pb.GetTransferLimitsResponse{
+		AccountId:       limits.AccountID,
+		Role:            limits.Role,
+		RoleLimits:      convertTransferLimits(limits.RoleLimits),
+		AccountLimits:   convertTransferLimits(limits.AccountLimits),
+		EffectiveLimits: convertTransferLimits(limits.Effective),
+		Usage:           convertTransferUsage(limits.Usage),
+		OwnerUsage:      convertTransferUsage(limits.OwnerUsage),
+	}
+
+	return resp, nil
+}
+
+func validateGetTransferLimitsRequest(req *pb.GetTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return violations
+}
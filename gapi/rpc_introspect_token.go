@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	accessTokenType             = "access_token"
+	refreshTokenType            = "refresh_token"
+	twoFactorChallengeTokenType = "two_factor_challenge"
+)
+
+// IntrospectToken reports whether a token is active, in the style of RFC 7662.
+// It needs no authorization since the caller already holds the token.
+func (server *Server) IntrospectToken(ctx context.Context, req *pb.IntrospectTokenRequest) (*pb.IntrospectTokenResponse, error) {
+	violations := validateIntrospectTokenRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(req.GetToken())
+
+	if err != nil {
+		return &pb.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	resp := &pb.IntrospectTokenResponse{
+		TokenId:   payload.ID.String(),
+		Username:  payload.Username,
+		Role:      payload.Role,
+		IssuedAt:  timestamppb.New(payload.IssuedAt),
+		ExpiresAt: timestamppb.New(payload.ExpiredAt),
+	}
+
+	// A challenge token only completes a login, it never grants access.
+	switch payload.Type {
+	case token.TypeAccess:
+		resp.TokenType = accessTokenType
+	case token.TypeRefresh:
+		resp.TokenType = refreshTokenType
+	case token.TypeTwoFactorChallenge:
+		resp.TokenType = twoFactorChallengeTokenType
+		return resp, nil
+	default:
+		return resp, nil
+	}
+
+	err = server.revocations.Check(ctx, payload)
+
+	if err != nil {
+		if !errors.Is(err, token.ErrRevokedToken) {
+			return nil, status.Errorf(codes.Internal, "failed to check token revocation: %v", err)
+		}
+		resp.Revoked = true
+	}
+
+	if payload.Type == token.TypeRefresh {
+		// Refresh tokens share their id with the session they belong to.
+		session, err := server.store.GetSession(ctx, payload.ID)
+
+		if err != nil && !errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+		}
+
+		// A refresh token without its session cannot be renewed.
+		if err != nil || session.RefreshToken != req.GetToken() {
+			resp.SessionBlocked = true
+		} else {
+			resp.SessionId = session.ID.String()
+			resp.SessionBlocked = session.IsBlocked || session.IsUsed
+		}
+	}
+
+	resp.Active = !resp.Revoked && !resp.SessionBlocked
+
+	return resp, nil
+}
+
+func validateIntrospectTokenRequest(req *pb.IntrospectTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetToken(), 1, 1024); err != nil {
+		violations = append(violations, fieldViolation("token", err))
+	}
+
+	return violations
+}
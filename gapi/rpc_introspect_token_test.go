@@ -0,0 +1,187 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIntrospectTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildToken    func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload)
+		buildStubs    func(store *mockdb.MockStore, tk string, payload *token.Payload)
+		checkResponse func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error)
+	}{
+		{
+			name: "ActiveAccessToken",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload) {
+				tk, payload, err := tokenMaker.CreateToken(token.Claims{
+					Username: user.Username,
+					Role:     user.Role,
+					Type:     token.TypeAccess,
+				}, time.Minute)
+				require.NoError(t, err)
+				return tk, payload
+			},
+			buildStubs: func(store *mockdb.MockStore, tk string, payload *token.Payload) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetActive())
+				require.Equal(t, payload.ID.String(), res.GetTokenId())
+				require.Equal(t, user.Username, res.GetUsername())
+				require.Equal(t, user.Role, res.GetRole())
+				require.Equal(t, accessTokenType, res.GetTokenType())
+				require.Empty(t, res.GetSessionId())
+			},
+		},
+		{
+			name: "RevokedAccessToken",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload) {
+				tk, payload, err := tokenMaker.CreateToken(token.Claims{
+					Username: user.Username,
+					Role:     user.Role,
+					Type:     token.TypeAccess,
+				}, time.Minute)
+				require.NoError(t, err)
+				return tk, payload
+			},
+			buildStubs: func(store *mockdb.MockStore, tk string, payload *token.Payload) {
+				store.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Eq(payload.ID)).Times(1).Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetActive())
+				require.True(t, res.GetRevoked())
+			},
+		},
+		{
+			name: "BlockedRefreshToken",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload) {
+				tk, payload, err := tokenMaker.CreateToken(token.Claims{
+					Username: user.Username,
+					Role:     user.Role,
+					Type:     token.TypeRefresh,
+				}, time.Hour)
+				require.NoError(t, err)
+				return tk, payload
+			},
+			buildStubs: func(store *mockdb.MockStore, tk string, payload *token.Payload) {
+				session := randomSession(user.Username)
+				session.ID = payload.ID
+				session.RefreshToken = tk
+				session.IsBlocked = true
+
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(payload.ID)).Times(1).Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetActive())
+				require.False(t, res.GetRevoked())
+				require.Equal(t, refreshTokenType, res.GetTokenType())
+				require.Equal(t, payload.ID.String(), res.GetSessionId())
+				require.True(t, res.GetSessionBlocked())
+			},
+		},
+		{
+			name: "RefreshTokenWithoutSession",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload) {
+				tk, payload, err := tokenMaker.CreateToken(token.Claims{
+					Username: user.Username,
+					Role:     user.Role,
+					Type:     token.TypeRefresh,
+				}, time.Hour)
+				require.NoError(t, err)
+				return tk, payload
+			},
+			buildStubs: func(store *mockdb.MockStore, tk string, payload *token.Payload) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(payload.ID)).Times(1).Return(db.Session{}, db.ErrorRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetActive())
+				require.Equal(t, refreshTokenType, res.GetTokenType())
+				require.Empty(t, res.GetSessionId())
+				require.True(t, res.GetSessionBlocked())
+			},
+		},
+		{
+			name: "TwoFactorChallengeToken",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload) {
+				tk, payload, err := tokenMaker.CreateToken(token.Claims{
+					Username: user.Username,
+					Role:     utils.TwoFactorChallengeRole,
+					Type:     token.TypeTwoFactorChallenge,
+				}, time.Minute)
+				require.NoError(t, err)
+				return tk, payload
+			},
+			buildStubs: func(store *mockdb.MockStore, tk string, payload *token.Payload) {
+				store.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Eq(payload.ID)).Times(0)
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetActive())
+				require.Equal(t, twoFactorChallengeTokenType, res.GetTokenType())
+			},
+		},
+		{
+			name: "ExpiredToken",
+			buildToken: func(t *testing.T, tokenMaker token.Maker) (string, *token.Payload) {
+				tk, payload, err := tokenMaker.CreateToken(token.Claims{
+					Username: user.Username,
+					Role:     user.Role,
+					Type:     token.TypeAccess,
+				}, -time.Minute)
+				require.NoError(t, err)
+				return tk, payload
+			},
+			buildStubs: func(store *mockdb.MockStore, tk string, payload *token.Payload) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, payload *token.Payload, res *pb.IntrospectTokenResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetActive())
+				require.Empty(t, res.GetUsername())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			// The token is needed for the stubs, which must be set up before
+			// the test server allows all other tokens.
+			tokenMaker, err := token.NewPasetoMaker(utils.RandomString(32))
+			require.NoError(t, err)
+
+			tk, payload := tc.buildToken(t, tokenMaker)
+			tc.buildStubs(store, tk, payload)
+
+			server := newTestServer(t, store, nil)
+			server.tokenMaker = tokenMaker
+
+			res, err := server.IntrospectToken(context.Background(), &pb.IntrospectTokenRequest{
+				Token: tk,
+			})
+			tc.checkResponse(t, payload, res, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	_, err := server.authorizeUser(ctx, pb.SimpleBank_ListAuditEvents_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAuditEventsRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	events, err := server.store.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		Actor: pgtype.Text{
+			String: req.GetActor(),
+			Valid:  req.Actor != nil,
+		},
+		Action: pgtype.Text{
+			String: req.GetAction(),
+			Valid:  req.Action != nil,
+		},
+		TargetType: pgtype.Text{
+			String: req.GetTargetType(),
+			Valid:  req.TargetType != nil,
+		},
+		TargetID: pgtype.Text{
+			String: req.GetTargetId(),
+			Valid:  req.TargetId != nil,
+		},
+		StartTime: optionalTime(req.GetStartTime()),
+		EndTime:   optionalTime(req.GetEndTime()),
+		Limit:     req.GetPageSize(),
+		Offset:    (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit events: %v", err)
+	}
+
+	resp := &pb.ListAuditEventsResponse{
+		Events: convertAuditEvents(events),
+	}
+
+	return resp, nil
+}
+
+// optionalTime returns a null time for an unset timestamp.
+func optionalTime(t *timestamppb.Timestamp) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{
+		Time:  t.AsTime(),
+		Valid: true,
+	}
+}
+
+func validateListAuditEventsRequest(req *pb.ListAuditEventsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Actor != nil {
+		if err := val.ValidateUsername(req.GetActor()); err != nil {
+			violations = append(violations, fieldViolation("actor", err))
+		}
+	}
+
+	if req.Action != nil {
+		if err := val.ValidateString(req.GetAction(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("action", err))
+		}
+	}
+
+	if req.TargetType != nil {
+		if err := val.ValidateString(req.GetTargetType(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("target_type", err))
+		}
+	}
+
+	if req.TargetId != nil {
+		if err := val.ValidateString(req.GetTargetId(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("target_id", err))
+		}
+	}
+
+	if req.StartTime != nil && req.EndTime != nil && !req.GetEndTime().AsTime().After(req.GetStartTime().AsTime()) {
+		violations = append(violations, fieldViolation("end_time", errors.New("must be after start_time")))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,174 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAuditEventsAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	depositor, _ := randomUser(t)
+
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	event := db.AuditEvent{
+		ID:         1,
+		Actor:      pgtype.Text{String: banker.Username, Valid: true},
+		Action:     db.AuditActionFreezeUser,
+		TargetType: db.AuditTargetUser,
+		TargetID:   depositor.Username,
+		Reason:     "suspected fraud",
+		Before:     []byte(`{"frozen_at": null}`),
+		CreatedAt:  time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListAuditEventsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListAuditEventsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListAuditEventsRequest{
+				Actor:     proto.String(banker.Username),
+				TargetId:  proto.String(depositor.Username),
+				StartTime: timestamppb.New(startTime),
+				PageId:    2,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAuditEventsParams{
+					Actor:     pgtype.Text{String: banker.Username, Valid: true},
+					TargetID:  pgtype.Text{String: depositor.Username, Valid: true},
+					StartTime: pgtype.Timestamptz{Time: startTime.UTC(), Valid: true},
+					Limit:     5,
+					Offset:    5,
+				}
+
+				store.EXPECT().
+					ListAuditEvents(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.AuditEvent{event}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAuditEventsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEvents(), 1)
+
+				got := res.GetEvents()[0]
+				require.Equal(t, banker.Username, got.GetActor())
+				require.Equal(t, db.AuditActionFreezeUser, got.GetAction())
+				require.Equal(t, `{"frozen_at": null}`, got.GetBefore())
+				require.Empty(t, got.GetAfter())
+			},
+		},
+		{
+			name: "SystemActor",
+			req: &pb.ListAuditEventsRequest{
+				PageId:   1,
+				PageSize: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				systemEvent := event
+				systemEvent.Actor = pgtype.Text{}
+
+				store.EXPECT().
+					ListAuditEvents(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditEvent{systemEvent}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAuditEventsResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetEvents()[0].GetActor())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.ListAuditEventsRequest{
+				PageId:   1,
+				PageSize: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAuditEventsResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "EndTimeBeforeStartTime",
+			req: &pb.ListAuditEventsRequest{
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(startTime.Add(-time.Minute)),
+				PageId:    1,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAuditEventsResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "InvalidActor",
+			req: &pb.ListAuditEventsRequest{
+				Actor:    proto.String("Not A User"),
+				PageId:   1,
+				PageSize: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAuditEventsResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ListAuditEvents(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCustomerAccounts(ctx context.Context, req *pb.ListCustomerAccountsRequest) (*pb.ListCustomerAccountsResponse, error) {
+	_, err := server.authorizeUser(ctx, pb.SimpleBank_ListCustomerAccounts_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListCustomerAccountsRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	if err := server.checkCustomerExists(ctx, req.GetUsername()); err != nil {
+		return nil, err
+	}
+
+	accounts, err := server.store.ListAccounts(ctx, db.ListAccountsParams{
+		Owner:  req.GetUsername(),
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %v", err)
+	}
+
+	resp := &pb.ListCustomerAccountsResponse{
+		Accounts: convertAccounts(accounts),
+	}
+
+	return resp, nil
+}
+
+// checkCustomerExists tells an unknown user apart from one without accounts
+// or transfers.
+func (server *Server) checkCustomerExists(ctx context.Context, username string) error {
+	_, err := server.store.GetUser(ctx, username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return status.Errorf(codes.NotFound, "user not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	return nil
+}
+
+func validateListCustomerAccountsRequest(req *pb.ListCustomerAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
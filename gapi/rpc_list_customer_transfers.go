@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCustomerTransfers(ctx context.Context, req *pb.ListCustomerTransfersRequest) (*pb.ListCustomerTransfersResponse, error) {
+	_, err := server.authorizeUser(ctx, pb.SimpleBank_ListCustomerTransfers_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListCustomerTransfersRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	if err := server.checkCustomerExists(ctx, req.GetUsername()); err != nil {
+		return nil, err
+	}
+
+	transfers, err := server.store.ListTransfersByOwner(ctx, db.ListTransfersByOwnerParams{
+		Owner:  req.GetUsername(),
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfers: %v", err)
+	}
+
+	resp := &pb.ListCustomerTransfersResponse{
+		Transfers: convertTransfers(transfers),
+	}
+
+	return resp, nil
+}
+
+func validateListCustomerTransfersRequest(req *pb.ListCustomerTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
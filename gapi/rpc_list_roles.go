@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+)
+
+func (server *Server) ListRoles(ctx context.Context, req *pb.ListRolesRequest) (*pb.ListRolesResponse, error) {
+	_, err := server.authorizeUser(ctx, pb.SimpleBank_ListRoles_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	resp := &pb.ListRolesResponse{}
+
+	for _, role := range utils.Roles() {
+		resp.Roles = append(resp.Roles, &pb.Role{
+			Name:        role,
+			Permissions: utils.RolePermissions(role),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListScheduledTransfers(ctx context.Context, req *pb.ListScheduledTransfersRequest) (*pb.ListScheduledTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ListScheduledTransfers_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListScheduledTransfersRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	arg := db.ListScheduledTransfersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+
+	scheduledTransfers, err := server.store.ListScheduledTransfers(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfers: %v", err)
+	}
+
+	resp := &pb.ListScheduledTransfersResponse{
+		ScheduledTransfers: convertScheduledTransfers(scheduledTransfers),
+	}
+
+	return resp, nil
+}
+
+func validateListScheduledTransfersRequest(req *pb.ListScheduledTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/starjardin/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ListSessions_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessions, err := server.store.ListActiveSessions(ctx, authPayload.Username)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sessions: %v", err)
+	}
+
+	resp := &pb.ListSessionsResponse{
+		Sessions: convertSessions(sessions),
+	}
+
+	return resp, nil
+}
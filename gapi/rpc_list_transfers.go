@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ListTransfers_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListTransfersRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	if account.Owner != authPayload.Username && !utils.HasPermission(authPayload.Role, utils.PermissionTransfersReadAny) {
+		return nil, status.Error(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	arg := db.ListTransfersParams{
+		AccountID: account.ID,
+		Limit:     req.GetPageSize(),
+		Offset:    (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfers: %v", err)
+	}
+
+	resp := &pb.ListTransfersResponse{
+		Transfers: convertTransfers(transfers),
+	}
+
+	return resp, nil
+}
+
+func validateListTransfersRequest(req *pb.ListTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"github.com/rs/zerolog/log"
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
 	"github.com/starjardin/simplebank/utils"
 	"github.com/starjardin/simplebank/val"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -23,47 +25,126 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 
 	}
 
-	user, err := server.store.GetUser(ctx, req.GetUsername())
+	mtdt := server.extractMetadata(ctx)
+
+	err := server.checkLoginThrottle(ctx, req.GetUsername(), mtdt.ClientIP)
 
 	if err != nil {
-		if errors.Is(err, db.ErrorRecordNotFound) {
-			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
-		}
+		return nil, err
+	}
+
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+
+	if err != nil && !errors.Is(err, db.ErrorRecordNotFound) {
 		return nil, status.Errorf(codes.Internal, "error getting user: %v", err)
+	}
 
+	userExists := err == nil
+	hashedPassword := user.HashedPassword
+
+	if !userExists {
+		hashedPassword = server.dummyPasswordHash()
 	}
 
-	err = utils.CheckPassword(req.GetPassword(), user.HashedPassword)
+	err = utils.CheckPassword(req.GetPassword(), hashedPassword)
 
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "password is incorrect: %v", err)
+	if err != nil || !userExists {
+		if err := server.recordLoginFailure(ctx, req.GetUsername(), mtdt.ClientIP, userExists); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err)
+		}
+		return nil, errInvalidCredentials
+	}
 
+	// Only checked once the password is right, so that it does not tell
+	// others which users are frozen.
+	if user.FrozenAt.Valid {
+		return nil, status.Error(codes.PermissionDenied, "user is frozen")
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.AccessTokenDuration)
+	server.rehashPassword(ctx, user, req.GetPassword())
+
+	_, twoFactorEnabled, err := server.twoFactorCredential(ctx, user.Username)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "error creating access token: %v", err)
+		return nil, err
+	}
+
+	if twoFactorEnabled {
+		challengeToken, challengePayload, err := server.tokenMaker.CreateToken(token.Claims{
+			Username: user.Username,
+			Role:     utils.TwoFactorChallengeRole,
+			Type:     token.TypeTwoFactorChallenge,
+		}, server.config.TwoFactorChallengeDuration)
+
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error creating challenge token: %v", err)
+		}
+
+		return &pb.LoginUserResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challengeToken,
+			ChallengeExpiresAt: timestamppb.New(challengePayload.ExpiredAt),
+		}, nil
+	}
+
+	return server.createLoginSession(ctx, user)
+}
+
+// createLoginSession issues the access and refresh tokens of a login and
+// stores its session. The failed attempts of the username are forgotten only
+// here, once every login step has passed.
+func (server *Server) createLoginSession(ctx context.Context, user db.User) (*pb.LoginUserResponse, error) {
+	err := server.store.DeleteLoginFailure(ctx, db.DeleteLoginFailureParams{
+		Scope: db.LoginFailureScopeUsername,
+		Key:   user.Username,
+	})
 
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset login failures: %v", err)
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username: user.Username,
+		Role:     user.Role,
+		Type:     token.TypeRefresh,
+	}, server.config.RefreshTokenDuration)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "error creating refresh token: %v", err)
 
 	}
 
+	// The refresh token starts a new session family.
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username:  user.Username,
+		Role:      user.Role,
+		Type:      token.TypeAccess,
+		SessionID: refreshPayload.ID,
+	}, server.config.AccessTokenDuration)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "error creating access token: %v", err)
+
+	}
+
 	mtdt := server.extractMetadata(ctx)
 
-	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    mtdt.UserAgent,
-		ClientIp:     mtdt.ClientIP,
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiredAt,
+	txResult, err := server.store.CreateSessionTx(ctx, db.CreateSessionTxParams{
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           refreshPayload.ID,
+			Username:     user.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    mtdt.UserAgent,
+			ClientIp:     mtdt.ClientIP,
+			IsBlocked:    false,
+			ExpiresAt:    refreshPayload.ExpiredAt,
+			FamilyID:     refreshPayload.ID,
+		},
+		Actor: db.AuditActor{
+			Username:  user.Username,
+			ClientIP:  mtdt.ClientIP,
+			UserAgent: mtdt.UserAgent,
+		},
 	})
 
 	if err != nil {
@@ -74,13 +155,40 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	return &pb.LoginUserResponse{
 		AccessToken:           accessToken,
 		User:                  convertUser(user),
-		SessionId:             session.ID.String(),
+		SessionId:             txResult.Session.ID.String(),
 		RefreshToken:          refreshToken,
 		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
 		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
 	}, nil
 }
 
+// rehashPassword upgrades the hash of a password that has just been checked
+// when it was made with another algorithm or weaker parameters than the
+// current ones. A failure only delays the upgrade to the next login.
+func (server *Server) rehashPassword(ctx context.Context, user db.User, password string) {
+	if !server.passwordHasher.NeedsRehash(user.HashedPassword) {
+		return
+	}
+
+	hashedPassword, err := server.passwordHasher.Hash(password)
+
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("failed to rehash password")
+		return
+	}
+
+	// The update is skipped if the password changed since it was read.
+	_, err = server.store.RehashUserPassword(ctx, db.RehashUserPasswordParams{
+		Username:          user.Username,
+		OldHashedPassword: user.HashedPassword,
+		NewHashedPassword: hashedPassword,
+	})
+
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("failed to store rehashed password")
+	}
+}
+
 func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := val.ValidateUsername(req.GetUsername()); err != nil {
 		violations = append(violations, fieldViolation("username", err))
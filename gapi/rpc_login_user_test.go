@@ -0,0 +1,216 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/worker"
+	mockworker "github.com/starjardin/simplebank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserAPI(t *testing.T) {
+	user, password := randomUser(t)
+
+	usernameFailure := db.GetLoginFailureParams{
+		Scope: db.LoginFailureScopeUsername,
+		Key:   user.Username,
+	}
+
+	testCases := []struct {
+		name          string
+		password      string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.LoginUserResponse, err error)
+	}{
+		{
+			name:     "OK",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Eq(usernameFailure)).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.TotpCredential{}, db.ErrorRecordNotFound)
+				store.EXPECT().DeleteLoginFailure(gomock.Any(), gomock.Eq(db.DeleteLoginFailureParams(usernameFailure))).Times(1).Return(nil)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSessionTxResult{Session: randomSession(user.Username)}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.False(t, res.GetTwoFactorRequired())
+			},
+		},
+		{
+			name:     "WrongPassword",
+			password: "wrong_password",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().RecordLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().LockLogin(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Equal(t, errInvalidCredentials, err)
+			},
+		},
+		{
+			name:     "UserNotFound",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, db.ErrorRecordNotFound)
+				store.EXPECT().RecordLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Equal(t, errInvalidCredentials, err)
+			},
+		},
+		{
+			name:     "Frozen",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				frozen := user
+				frozen.FrozenAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+				store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(frozen, nil)
+				store.EXPECT().RecordLoginFailure(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "ReachLockout",
+			password: "wrong_password",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: defaultLoginMaxAttempts}, nil)
+				store.EXPECT().
+					LockLogin(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.LockLoginParams) (db.LoginFailure, error) {
+						require.WithinDuration(t, time.Now().Add(defaultLoginLockoutDuration), arg.LockedUntil.Time, time.Second)
+						return db.LoginFailure{}, nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, payload worker.PayloadSendAccountLockedEmail, opts ...any) error {
+						require.Equal(t, user.Username, payload.Username)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Equal(t, errInvalidCredentials, err)
+			},
+		},
+		{
+			name:     "Locked",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				failure := db.LoginFailure{
+					FailedCount: defaultLoginMaxAttempts,
+					LockedUntil: pgtype.Timestamptz{
+						Time:  time.Now().Add(time.Minute),
+						Valid: true,
+					},
+				}
+
+				store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Eq(usernameFailure)).Times(1).Return(failure, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockworker.NewMockTaskDistributor(ctrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+
+			res, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: tc.password,
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestLoginBackoff(t *testing.T) {
+	lockout := 15 * time.Minute
+
+	delays := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second, lockout}
+
+	for i, expected := range delays {
+		delay, locked := loginBackoff(int32(i), 5, lockout)
+		require.Equal(t, expected, delay, "failures %d", i)
+		require.Equal(t, i >= 5, locked)
+	}
+
+	delay, locked := loginBackoff(30, 100, lockout)
+	require.Equal(t, lockout, delay)
+	require.False(t, locked)
+}
+
+func TestLoginUserRehash(t *testing.T) {
+	user, password := randomUser(t)
+	hasher := utils.Argon2idHasher{Time: 1, MemoryKiB: 1024, Threads: 1}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().
+		RehashUserPassword(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.RehashUserPasswordParams) (int64, error) {
+			require.Equal(t, user.Username, arg.Username)
+			require.Equal(t, user.HashedPassword, arg.OldHashedPassword)
+			require.False(t, hasher.NeedsRehash(arg.NewHashedPassword))
+			require.NoError(t, utils.CheckPassword(password, arg.NewHashedPassword))
+			return 1, nil
+		})
+	store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(1).Return(db.TotpCredential{}, db.ErrorRecordNotFound)
+	store.EXPECT().DeleteLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSessionTxResult{Session: randomSession(user.Username)}, nil)
+
+	server := newTestServer(t, store, nil)
+	server.passwordHasher = hasher
+
+	res, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+		Username: user.Username,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetAccessToken())
+}
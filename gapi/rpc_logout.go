@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_Logout_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateLogoutRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	_, err = server.revokeSession(ctx, refreshPayload.ID, authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.revokeAccessToken(ctx, authPayload)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke access token: %v", err)
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
+func validateLogoutRequest(req *pb.LogoutRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetRefreshToken(), 1, 1024); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,124 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) QuoteTransfer(ctx context.Context, req *pb.QuoteTransferRequest) (*pb.QuoteTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_QuoteTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateQuoteTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	fromAccount, err := server.getAccount(ctx, req.GetFromAccountId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Error(codes.PermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	toAccount, err := server.getAccount(ctx, req.GetToAccountId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Currency == toAccount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "accounts have the same currency, no conversion needed")
+	}
+
+	rate, err := server.store.GetLatestExchangeRate(ctx, db.GetLatestExchangeRateParams{
+		BaseCurrency:  fromAccount.Currency,
+		QuoteCurrency: toAccount.Currency,
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "no exchange rate from %s to %s", fromAccount.Currency, toAccount.Currency)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get exchange rate: %v", err)
+	}
+
+	toAmount, err := db.ConvertAmount(req.GetAmount(), rate.Rate)
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot convert amount: %v", err)
+	}
+
+	if toAmount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount is too small to convert")
+	}
+
+	quote, err := server.store.CreateTransferQuote(ctx, db.CreateTransferQuoteParams{
+		ID:             uuid.New(),
+		Username:       authPayload.Username,
+		FromAccountID:  fromAccount.ID,
+		ToAccountID:    toAccount.ID,
+		FromAmount:     req.GetAmount(),
+		FromCurrency:   fromAccount.Currency,
+		ToAmount:       toAmount,
+		ToCurrency:     toAccount.Currency,
+		ExchangeRateID: rate.ID,
+		Rate:           rate.Rate,
+		ExpiresAt:      time.Now().Add(server.config.FXQuoteDuration),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create quote: %v", err)
+	}
+
+	resp := &pb.QuoteTransferResponse{
+		Quote: convertTransferQuote(quote),
+	}
+
+	return resp, nil
+}
+
+func (server *Server) getAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account %d not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	return account, nil
+}
+
+func validateQuoteTransferRequest(req *pb.QuoteTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	return violations
+}
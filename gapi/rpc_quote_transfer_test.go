@@ -0,0 +1,188 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQuoteTransferAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username, utils.USD)
+	account2 := randomAccount(user2.Username, utils.EUR)
+	account2.ID = account1.ID + 1
+	account3 := randomAccount(user2.Username, utils.USD)
+	account3.ID = account1.ID + 2
+
+	rate, err := db.ParseRate("0.9")
+	require.NoError(t, err)
+
+	exchangeRate := db.ExchangeRate{
+		ID:            utils.RandomInt(1, 1000),
+		BaseCurrency:  utils.USD,
+		QuoteCurrency: utils.EUR,
+		Rate:          rate,
+	}
+
+	amount := int64(100)
+
+	testCases := []struct {
+		name          string
+		req           *pb.QuoteTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.QuoteTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.QuoteTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					GetLatestExchangeRate(gomock.Any(), gomock.Eq(db.GetLatestExchangeRateParams{
+						BaseCurrency:  utils.USD,
+						QuoteCurrency: utils.EUR,
+					})).
+					Times(1).
+					Return(exchangeRate, nil)
+				store.EXPECT().
+					CreateTransferQuote(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateTransferQuoteParams) (db.TransferQuote, error) {
+						require.Equal(t, user1.Username, arg.Username)
+						require.Equal(t, amount, arg.FromAmount)
+						require.Equal(t, int64(90), arg.ToAmount)
+						require.Equal(t, exchangeRate.ID, arg.ExchangeRateID)
+
+						return db.TransferQuote{
+							ID:             arg.ID,
+							Username:       arg.Username,
+							FromAccountID:  arg.FromAccountID,
+							ToAccountID:    arg.ToAccountID,
+							FromAmount:     arg.FromAmount,
+							FromCurrency:   arg.FromCurrency,
+							ToAmount:       arg.ToAmount,
+							ToCurrency:     arg.ToCurrency,
+							ExchangeRateID: arg.ExchangeRateID,
+							Rate:           arg.Rate,
+							ExpiresAt:      arg.ExpiresAt,
+						}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.QuoteTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(90), res.GetQuote().GetToAmount())
+				require.Equal(t, utils.EUR, res.GetQuote().GetToCurrency())
+				require.Equal(t, "0.9", res.GetQuote().GetRate())
+			},
+		},
+		{
+			name: "SameCurrency",
+			req: &pb.QuoteTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account3.ID,
+				Amount:        amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().GetLatestExchangeRate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.QuoteTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NotAccountOwner",
+			req: &pb.QuoteTransferRequest{
+				FromAccountId: account2.ID,
+				ToAccountId:   account1.ID,
+				Amount:        amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateTransferQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.QuoteTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NoExchangeRate",
+			req: &pb.QuoteTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					GetLatestExchangeRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExchangeRate{}, db.ErrorRecordNotFound)
+				store.EXPECT().CreateTransferQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.QuoteTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.QuoteTransfer(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
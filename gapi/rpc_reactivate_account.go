@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+)
+
+func (server *Server) ReactivateAccount(ctx context.Context, req *pb.ReactivateAccountRequest) (*pb.ReactivateAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_ReactivateAccount_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountStatusRequest(req.GetAccountId(), req.GetReason())
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	txResult, err := server.updateAccountStatus(ctx, authPayload.Username, req.GetAccountId(), db.AccountStatusDormant, db.AccountStatusActive, req.GetReason())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReactivateAccountResponse{Account: convertAccount(txResult.Account)}, nil
+}
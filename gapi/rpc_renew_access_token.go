@@ -0,0 +1,134 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	if refreshPayload.Type != token.TypeRefresh {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: not a refresh token")
+	}
+
+	// Refresh tokens issued before a password change or a freeze cannot mint
+	// new access tokens.
+	if err := server.revocations.Check(ctx, refreshPayload); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+
+	if session.IsBlocked {
+		return nil, status.Errorf(codes.Unauthenticated, "session is blocked")
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect session user")
+	}
+
+	if session.RefreshToken != req.GetRefreshToken() {
+		return nil, status.Errorf(codes.Unauthenticated, "mismatched session token")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "session expired")
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username:  refreshPayload.Username,
+		Role:      refreshPayload.Role,
+		Type:      token.TypeAccess,
+		SessionID: session.FamilyID,
+	}, server.config.AccessTokenDuration)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating access token: %v", err)
+	}
+
+	// The new refresh token expires with the session it replaces, so rotating
+	// does not extend a login.
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(token.Claims{
+		Username: refreshPayload.Username,
+		Role:     refreshPayload.Role,
+		Type:     token.TypeRefresh,
+	}, time.Until(session.ExpiresAt))
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating refresh token: %v", err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+
+	txResult, err := server.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		SessionID: session.ID,
+		NewSession: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    mtdt.UserAgent,
+			ClientIp:     mtdt.ClientIP,
+			IsBlocked:    false,
+			ExpiresAt:    newRefreshPayload.ExpiredAt,
+		},
+		Actor: db.AuditActor{
+			Username:  session.Username,
+			ClientIP:  mtdt.ClientIP,
+			UserAgent: mtdt.UserAgent,
+		},
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, status.Errorf(codes.Unauthenticated, "refresh token has already been used, the session has been revoked")
+		}
+		if errors.Is(err, db.ErrSessionBlocked) || errors.Is(err, db.ErrSessionExpired) {
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "error rotating session: %v", err)
+	}
+
+	return &pb.RenewAccessTokenResponse{
+		SessionId:             txResult.Session.ID.String(),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}, nil
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetRefreshToken(), 1, 1024); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,169 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, session db.Session)
+		checkResponse func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+						require.Equal(t, session.ID, arg.SessionID)
+						require.Equal(t, session.Username, arg.NewSession.Username)
+						require.NotEqual(t, session.ID, arg.NewSession.ID)
+						require.WithinDuration(t, session.ExpiresAt, arg.NewSession.ExpiresAt, time.Second)
+
+						newSession := db.Session{
+							ID:           arg.NewSession.ID,
+							Username:     arg.NewSession.Username,
+							RefreshToken: arg.NewSession.RefreshToken,
+							FamilyID:     session.FamilyID,
+							ExpiresAt:    arg.NewSession.ExpiresAt,
+						}
+						return db.RotateSessionTxResult{Session: newSession}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+				require.NotEqual(t, session.RefreshToken, res.GetRefreshToken())
+				require.NotEqual(t, session.ID.String(), res.GetSessionId())
+			},
+		},
+		{
+			name: "Reused",
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, db.ErrRefreshTokenReused)
+			},
+			checkResponse: func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "BlockedWhileRotating",
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, db.ErrSessionBlocked)
+			},
+			checkResponse: func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "Blocked",
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				session.IsBlocked = true
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "IssuedBeforePasswordChange",
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().
+					GetUserPasswordChangeAt(gomock.Any(), gomock.Eq(session.Username)).
+					Times(1).
+					Return(time.Now().Add(time.Minute), nil)
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RotateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "SessionNotFound",
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(db.Session{}, db.ErrorRecordNotFound)
+				store.EXPECT().RotateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, session db.Session, res *pb.RenewAccessTokenResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			// The session is needed for the stubs, which must be set up before
+			// the test server allows all tokens.
+			tokenMaker, err := token.NewPasetoMaker(utils.RandomString(32))
+			require.NoError(t, err)
+
+			refreshToken, refreshPayload, err := tokenMaker.CreateToken(token.Claims{
+				Username: user.Username,
+				Role:     utils.DepositorRole,
+				Type:     token.TypeRefresh,
+			}, time.Hour)
+			require.NoError(t, err)
+
+			session := randomSession(user.Username)
+			session.ID = refreshPayload.ID
+			session.FamilyID = refreshPayload.ID
+			session.RefreshToken = refreshToken
+			session.ExpiresAt = refreshPayload.ExpiredAt
+
+			tc.buildStubs(store, session)
+
+			server := newTestServer(t, store, nil)
+			server.tokenMaker = tokenMaker
+
+			res, err := server.RenewAccessToken(context.Background(), &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			})
+			tc.checkResponse(t, session, res, err)
+		})
+	}
+}
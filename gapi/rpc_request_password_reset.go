@@ -0,0 +1,101 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hibiken/asynq"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"github.com/starjardin/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestPasswordReset emails a reset code to the owner of the address. It
+// answers the same whether or not the address belongs to a user, so that it
+// cannot be used to find out which emails are registered. Requests are
+// throttled per email and per client IP like failed logins.
+func (server *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	violations := validateRequestPasswordResetRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	throttles := server.passwordResetThrottles(req.GetEmail(), mtdt.ClientIP)
+
+	err := server.checkThrottles(ctx, throttles, "password reset")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, throttle := range throttles {
+		if _, _, _, err := server.recordThrottleFailure(ctx, throttle); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err)
+		}
+	}
+
+	user, err := server.store.GetUserByEmail(ctx, req.GetEmail())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return &pb.RequestPasswordResetResponse{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	taskPayload := worker.PayloadSendPasswordResetEmail{
+		Username: user.Username,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendPasswordResetEmail(ctx, taskPayload, opts...)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to distribute password reset email: %v", err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+// passwordResetThrottles counts every reset request, whether or not the email
+// belongs to a user, so that throttling does not tell them apart either.
+func (server *Server) passwordResetThrottles(email string, clientIP string) []loginThrottle {
+	maxAttempts := server.loginMaxAttempts()
+
+	throttles := []loginThrottle{
+		{
+			scope:       db.LoginFailureScopePasswordResetEmail,
+			key:         strings.ToLower(email),
+			maxAttempts: maxAttempts,
+		},
+	}
+
+	if clientIP != "" {
+		throttles = append(throttles, loginThrottle{
+			scope:       db.LoginFailureScopePasswordResetClientIP,
+			key:         clientIP,
+			maxAttempts: maxAttempts * clientIPAttemptsFactor,
+		})
+	}
+
+	return throttles
+}
+
+func validateRequestPasswordResetRequest(req *pb.RequestPasswordResetRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+
+	return violations
+}
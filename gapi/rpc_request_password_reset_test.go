@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/worker"
+	mockworker "github.com/starjardin/simplebank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestPasswordResetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	throttle := db.GetLoginFailureParams{
+		Scope: db.LoginFailureScopePasswordResetEmail,
+		Key:   user.Email,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RequestPasswordResetResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(throttle)).
+					Times(1).
+					Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), loginFailureScope(db.LoginFailureScopePasswordResetEmail)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Eq(worker.PayloadSendPasswordResetEmail{Username: user.Username}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "UnknownEmail",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(throttle)).
+					Times(1).
+					Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), loginFailureScope(db.LoginFailureScopePasswordResetEmail)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, db.ErrorRecordNotFound)
+				taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				// Answers like OK so the email cannot be probed.
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "Throttled",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(throttle)).
+					Times(1).
+					Return(db.LoginFailure{
+						FailedCount: 5,
+						LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+					}, nil)
+				store.EXPECT().RecordLoginFailure(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+				taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+			},
+		},
+		{
+			name: "LocksAfterMaxRequests",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockworker.MockTaskDistributor) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(throttle)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 4}, nil)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), loginFailureScope(db.LoginFailureScopePasswordResetEmail)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 5}, nil)
+				store.EXPECT().
+					LockLogin(gomock.Any(), gomock.Cond(func(arg db.LockLoginParams) bool {
+						return arg.Scope == db.LoginFailureScopePasswordResetEmail && arg.LockedUntil.Valid
+					})).
+					Times(1).
+					Return(db.LoginFailure{}, nil)
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockworker.NewMockTaskDistributor(ctrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+
+			res, err := server.RequestPasswordReset(context.Background(), &pb.RequestPasswordResetRequest{
+				Email: user.Email,
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	violations := validateResetPasswordRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	txResult, err := server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		ResetId:        req.GetResetId(),
+		SecretCodeHash: utils.HashSecretCode(req.GetSecretCode()),
+		HashPassword: func(user db.User) (string, error) {
+			if err := server.checkNewPassword(ctx, "new_password", user, req.GetNewPassword()); err != nil {
+				return "", err
+			}
+
+			hashedPassword, err := server.passwordHasher.Hash(req.GetNewPassword())
+
+			if err != nil {
+				return "", status.Errorf(codes.Internal, "failed to hash password: %v", err)
+			}
+
+			return hashedPassword, nil
+		},
+		Actor: server.auditActor(ctx, ""),
+	})
+
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "password reset code is invalid, used or expired")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reset password: %v", err)
+	}
+
+	// Access tokens issued before the reset are rejected from now on.
+	server.revocations.UserChanged(txResult.User.Username)
+
+	resp := &pb.ResetPasswordResponse{
+		RevokedSessions: txResult.RevokedSessions,
+	}
+
+	return resp, nil
+}
+
+func validateResetPasswordRequest(req *pb.ResetPasswordRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetResetId()); err != nil {
+		violations = append(violations, fieldViolation("reset_id", err))
+	}
+
+	if err := val.ValidateSecretCode(req.GetSecretCode()); err != nil {
+		violations = append(violations, fieldViolation("secret_code", err))
+	}
+
+	if err := val.ValidatePassword(req.GetNewPassword()); err != nil {
+		violations = append(violations, fieldViolation("new_password", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,112 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResetPasswordAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	newPassword := utils.RandomString(8)
+	secretCode := utils.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		req           *pb.ResetPasswordRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ResetPasswordResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error) {
+						require.Equal(t, int64(1), arg.ResetId)
+						require.Equal(t, utils.HashSecretCode(secretCode), arg.SecretCodeHash)
+						hashedPassword, err := arg.HashPassword(user)
+						require.NoError(t, err)
+						require.NoError(t, utils.CheckPassword(newPassword, hashedPassword))
+
+						return db.ResetPasswordTxResult{
+							User:            user,
+							RevokedSessions: 2,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.ResetPasswordResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(2), res.GetRevokedSessions())
+			},
+		},
+		{
+			name: "InvalidCode",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResetPasswordTxResult{}, db.ErrorRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResetPasswordResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidArgument",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     0,
+				SecretCode:  "short",
+				NewPassword: newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResetPasswordTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResetPasswordResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			res, err := server.ResetPassword(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_RevokeAllSessions_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	txResult, err := server.store.RevokeUserSessionsTx(ctx, db.RevokeUserSessionsTxParams{
+		Username: authPayload.Username,
+		Actor:    server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %v", err)
+	}
+
+	for _, familyID := range txResult.FamilyIDs {
+		server.revocations.SessionBlocked(familyID)
+	}
+
+	resp := &pb.RevokeAllSessionsResponse{
+		RevokedCount: txResult.RevokedSessions,
+	}
+
+	return resp, nil
+}
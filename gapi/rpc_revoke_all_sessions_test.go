@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevokeAllSessionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	familyID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		RevokeUserSessionsTx(gomock.Any(), gomock.Eq(db.RevokeUserSessionsTxParams{
+			Username: user.Username,
+			Actor:    db.AuditActor{Username: user.Username},
+		})).
+		Times(1).
+		Return(db.RevokeUserSessionsTxResult{
+			RevokedSessions: 2,
+			FamilyIDs:       []uuid.UUID{familyID},
+		}, nil)
+
+	server := newTestServer(t, store, nil)
+	// The test config disables the cache, keep revocations for a minute.
+	server.revocations = token.NewRevocationList(store, time.Minute)
+
+	ctx := newContextWithClaims(t, server.tokenMaker, token.Claims{
+		Username:  user.Username,
+		Role:      utils.DepositorRole,
+		Type:      token.TypeAccess,
+		SessionID: familyID,
+	}, server.config.AccessTokenDuration)
+
+	res, err := server.RevokeAllSessions(ctx, &pb.RevokeAllSessionsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.GetRevokedCount())
+
+	// The access token of a revoked session is rejected right away.
+	_, err = server.RevokeAllSessions(ctx, &pb.RevokeAllSessionsRequest{})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+}
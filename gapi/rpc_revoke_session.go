@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_RevokeSession_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeSessionRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	session, err := server.revokeSession(ctx, uuid.MustParse(req.GetSessionId()), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.RevokeSessionResponse{
+		Session: convertSession(session),
+	}
+
+	return resp, nil
+}
+
+// revokeSession blocks a session of username so that neither its refresh token
+// nor the access tokens issued with it can be used any more.
+func (server *Server) revokeSession(ctx context.Context, sessionID uuid.UUID, username string) (db.Session, error) {
+	session, err := server.store.GetSession(ctx, sessionID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return session, status.Errorf(codes.NotFound, "session not found")
+		}
+		return session, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+
+	if session.Username != username {
+		return session, status.Error(codes.PermissionDenied, "session does not belong to the authenticated user")
+	}
+
+	txResult, err := server.store.RevokeSessionTx(ctx, db.RevokeSessionTxParams{
+		SessionID: session.ID,
+		Actor:     server.auditActor(ctx, username),
+	})
+
+	if err != nil {
+		return session, status.Errorf(codes.Internal, "failed to revoke session: %v", err)
+	}
+
+	server.revocations.SessionBlocked(txResult.Session.FamilyID)
+
+	return txResult.Session, nil
+}
+
+func validateRevokeSessionRequest(req *pb.RevokeSessionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateSessionID(req.GetSessionId()); err != nil {
+		violations = append(violations, fieldViolation("session_id", err))
+	}
+
+	return violations
+}
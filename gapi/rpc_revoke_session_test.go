@@ -0,0 +1,143 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomSession(username string) db.Session {
+	return db.Session{
+		ID:           uuid.New(),
+		Username:     username,
+		RefreshToken: utils.RandomString(32),
+		UserAgent:    "grpc-go",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+}
+
+func TestRevokeSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	session := randomSession(user.Username)
+
+	testCases := []struct {
+		name          string
+		req           *pb.RevokeSessionRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.RevokeSessionResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.RevokeSessionRequest{
+				SessionId: session.ID.String(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				blocked := session
+				blocked.IsBlocked = true
+
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					RevokeSessionTx(gomock.Any(), gomock.Cond(func(arg db.RevokeSessionTxParams) bool {
+						return arg.SessionID == session.ID && arg.Actor.Username == user.Username
+					})).
+					Times(1).
+					Return(db.RevokeSessionTxResult{Session: blocked}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetSession().GetIsBlocked())
+			},
+		},
+		{
+			name: "OtherUsersSession",
+			req: &pb.RevokeSessionRequest{
+				SessionId: session.ID.String(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RevokeSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.RevokeSessionRequest{
+				SessionId: session.ID.String(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(db.Session{}, db.ErrorRecordNotFound)
+				store.EXPECT().RevokeSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidSessionID",
+			req: &pb.RevokeSessionRequest{
+				SessionId: "invalid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.RevokeSession(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	_, err := server.authorizeUser(ctx, pb.SimpleBank_SearchUsers_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSearchUsersRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	users, err := server.store.SearchUsers(ctx, db.SearchUsersParams{
+		Pattern: db.ContainsPattern(req.GetQuery()),
+		Limit:   req.GetPageSize(),
+		Offset:  (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search users: %v", err)
+	}
+
+	resp := &pb.SearchUsersResponse{
+		Users: convertUsers(users),
+	}
+
+	return resp, nil
+}
+
+func validateSearchUsersRequest(req *pb.SearchUsersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateSearchQuery(req.GetQuery()); err != nil {
+		violations = append(violations, fieldViolation("query", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
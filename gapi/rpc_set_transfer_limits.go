@@ -0,0 +1,124 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetTransferLimits(ctx context.Context, req *pb.SetTransferLimitsRequest) (*pb.SetTransferLimitsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_SetTransferLimits_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSetTransferLimitsRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	scope, key := db.TransferLimitScopeRole, req.GetRole()
+
+	if req.GetAccountId() != 0 {
+		if _, err := server.getAccount(ctx, req.GetAccountId()); err != nil {
+			return nil, err
+		}
+
+		scope, key = db.TransferLimitScopeAccount, strconv.FormatInt(req.GetAccountId(), 10)
+	}
+
+	limits := requestTransferLimits(req)
+
+	txResult, err := server.store.SetTransferLimitsTx(ctx, db.SetTransferLimitsTxParams{
+		Scope: scope,
+		Key:   key,
+		Limits: db.TransferLimits{
+			PerTransfer:   optionalInt(limits.PerTransfer),
+			DailyAmount:   optionalInt(limits.DailyAmount),
+			MonthlyAmount: optionalInt(limits.MonthlyAmount),
+			HourlyCount:   optionalInt(limits.HourlyCount),
+		},
+		Reason: req.GetReason(),
+		Actor:  server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set transfer limits: %v", err)
+	}
+
+	resp := &pb.SetTransferLimitsResponse{
+		Limits: convertTransferLimits(txResult.TransferLimit.Limits()),
+	}
+
+	return resp, nil
+}
+
+// requestTransferLimits returns the limits of the request, all unset when it
+// has none.
+func requestTransferLimits(req *pb.SetTransferLimitsRequest) *pb.TransferLimits {
+	if req.GetLimits() == nil {
+		return &pb.TransferLimits{}
+	}
+	return req.GetLimits()
+}
+
+// optionalInt returns a null integer for an unset field.
+func optionalInt(i *int64) pgtype.Int8 {
+	if i == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{
+		Int64: *i,
+		Valid: true,
+	}
+}
+
+func validateSetTransferLimitsRequest(req *pb.SetTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	switch {
+	case req.GetRole() != "" && req.GetAccountId() != 0:
+		violations = append(violations, fieldViolation("account_id", fmt.Errorf("must not be set with role")))
+	case req.GetAccountId() != 0:
+		if err := val.ValidateID(req.GetAccountId()); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+	default:
+		if err := val.ValidateRole(req.GetRole()); err != nil {
+			violations = append(violations, fieldViolation("role", err))
+		}
+	}
+
+	limits := requestTransferLimits(req)
+	for _, limit := range []struct {
+		field string
+		value *int64
+	}{
+		{"limits.per_transfer", limits.PerTransfer},
+		{"limits.daily_amount", limits.DailyAmount},
+		{"limits.monthly_amount", limits.MonthlyAmount},
+		{"limits.hourly_count", limits.HourlyCount},
+	} {
+		if limit.value == nil {
+			continue
+		}
+
+		if err := val.ValidateTransferLimit(*limit.value); err != nil {
+			violations = append(violations, fieldViolation(limit.field, err))
+		}
+	}
+
+	if err := val.ValidateReason(req.GetReason()); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
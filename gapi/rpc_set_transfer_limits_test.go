@@ -0,0 +1,241 @@
+package gapi
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetTransferLimitsAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	depositor, _ := randomUser(t)
+	account := randomAccount(depositor.Username, utils.USD)
+	reason := "raised after income verification"
+
+	testCases := []struct {
+		name          string
+		req           *pb.SetTransferLimitsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.SetTransferLimitsResponse, err error)
+	}{
+		{
+			name: "AccountOverride",
+			req: &pb.SetTransferLimitsRequest{
+				AccountId: account.ID,
+				Limits: &pb.TransferLimits{
+					DailyAmount: proto.Int64(10000),
+				},
+				Reason: reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.SetTransferLimitsTxParams{
+					Scope: db.TransferLimitScopeAccount,
+					Key:   strconv.FormatInt(account.ID, 10),
+					Limits: db.TransferLimits{
+						DailyAmount: pgtype.Int8{Int64: 10000, Valid: true},
+					},
+					Reason: reason,
+					Actor:  db.AuditActor{Username: banker.Username},
+				}
+
+				store.EXPECT().
+					SetTransferLimitsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.SetTransferLimitsTxResult{
+						TransferLimit: db.TransferLimit{
+							Scope:       arg.Scope,
+							Key:         arg.Key,
+							DailyAmount: arg.Limits.DailyAmount,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(10000), res.GetLimits().GetDailyAmount())
+				require.Nil(t, res.GetLimits().PerTransfer)
+				require.Nil(t, res.GetLimits().HourlyCount)
+			},
+		},
+		{
+			name: "RoleLimits",
+			req: &pb.SetTransferLimitsRequest{
+				Role: utils.DepositorRole,
+				Limits: &pb.TransferLimits{
+					PerTransfer: proto.Int64(500),
+					HourlyCount: proto.Int64(0),
+				},
+				Reason: reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+
+				arg := db.SetTransferLimitsTxParams{
+					Scope: db.TransferLimitScopeRole,
+					Key:   utils.DepositorRole,
+					Limits: db.TransferLimits{
+						PerTransfer: pgtype.Int8{Int64: 500, Valid: true},
+						HourlyCount: pgtype.Int8{Int64: 0, Valid: true},
+					},
+					Reason: reason,
+					Actor:  db.AuditActor{Username: banker.Username},
+				}
+
+				store.EXPECT().
+					SetTransferLimitsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.SetTransferLimitsTxResult{
+						TransferLimit: db.TransferLimit{
+							Scope:       arg.Scope,
+							Key:         arg.Key,
+							PerTransfer: arg.Limits.PerTransfer,
+							HourlyCount: arg.Limits.HourlyCount,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(500), res.GetLimits().GetPerTransfer())
+				require.NotNil(t, res.GetLimits().HourlyCount)
+				require.Zero(t, res.GetLimits().GetHourlyCount())
+			},
+		},
+		{
+			name: "RoleAndAccount",
+			req: &pb.SetTransferLimitsRequest{
+				Role:      utils.DepositorRole,
+				AccountId: account.ID,
+				Reason:    reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTransferLimitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "UnknownRole",
+			req: &pb.SetTransferLimitsRequest{
+				Role:   "teller",
+				Reason: reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTransferLimitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "NegativeLimit",
+			req: &pb.SetTransferLimitsRequest{
+				AccountId: account.ID,
+				Limits: &pb.TransferLimits{
+					MonthlyAmount: proto.Int64(-1),
+				},
+				Reason: reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTransferLimitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "MissingReason",
+			req: &pb.SetTransferLimitsRequest{
+				AccountId: account.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTransferLimitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req: &pb.SetTransferLimitsRequest{
+				AccountId: account.ID,
+				Reason:    reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, db.ErrorRecordNotFound)
+				store.EXPECT().SetTransferLimitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.SetTransferLimitsRequest{
+				AccountId: account.ID,
+				Reason:    reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTransferLimitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitsResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.SetTransferLimits(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetupTOTP generates a new TOTP secret. It only takes effect once confirmed
+// with ConfirmTOTP, and calling it again before that replaces the secret.
+func (server *Server) SetupTOTP(ctx context.Context, req *pb.SetupTOTPRequest) (*pb.SetupTOTPResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_SetupTOTP_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	secret, uri, err := utils.NewTOTPSecret(authPayload.Username)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate TOTP secret: %v", err)
+	}
+
+	// The upsert leaves a confirmed credential untouched and returns no row.
+	_, err = server.store.UpsertTOTPCredential(ctx, db.UpsertTOTPCredentialParams{
+		Username: authPayload.Username,
+		Secret:   secret,
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", db.ErrTwoFactorEnabled)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to save TOTP secret: %v", err)
+	}
+
+	return &pb.SetupTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_UpdateScheduledTransfer_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.ownedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount != nil {
+		err = server.requireStepUp(ctx, authPayload.Username, req.GetAmount(), req.GetTotpCode())
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Amount: pgtype.Int8{
+			Int64: req.GetAmount(),
+			Valid: req.Amount != nil,
+		},
+		IsActive: pgtype.Bool{
+			Bool:  req.GetIsActive(),
+			Valid: req.IsActive != nil,
+		},
+	}
+
+	cronExpression := scheduledTransfer.CronExpression.String
+	intervalSeconds := scheduledTransfer.IntervalSeconds.Int64
+
+	if req.CronExpression != nil || req.IntervalSeconds != nil {
+		cronExpression = req.GetCronExpression()
+		intervalSeconds = req.GetIntervalSeconds()
+
+		arg.UpdateSchedule = true
+		arg.CronExpression = pgtype.Text{
+			String: cronExpression,
+			Valid:  req.CronExpression != nil,
+		}
+		arg.IntervalSeconds = pgtype.Int8{
+			Int64: intervalSeconds,
+			Valid: req.IntervalSeconds != nil,
+		}
+	}
+
+	resumed := req.GetIsActive() && !scheduledTransfer.IsActive
+
+	// A new schedule starts from now, and so does a resumed one, so that runs
+	// missed while it was paused are not executed.
+	if arg.UpdateSchedule || resumed {
+		schedule, err := utils.ParseSchedule(cronExpression, intervalSeconds)
+
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+
+		arg.NextRunAt = pgtype.Timestamptz{
+			Time:  schedule.Next(time.Now()),
+			Valid: true,
+		}
+	}
+
+	txResult, err := server.store.UpdateScheduledTransferTx(ctx, db.UpdateScheduledTransferTxParams{
+		UpdateScheduledTransferParams: arg,
+		Actor:                         server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update scheduled transfer: %v", err)
+	}
+
+	resp := &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(txResult.ScheduledTransfer),
+	}
+
+	return resp, nil
+}
+
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	if req.CronExpression != nil || req.IntervalSeconds != nil {
+		if err := val.ValidateSchedule(req.GetCronExpression(), req.GetIntervalSeconds()); err != nil {
+			violations = append(violations, fieldViolation("schedule", err))
+		}
+	}
+
+	if req.GetTotpCode() != "" {
+		if err := val.ValidateTOTPCode(req.GetTotpCode()); err != nil {
+			violations = append(violations, fieldViolation("totp_code", err))
+		}
+	}
+
+	return violations
+}
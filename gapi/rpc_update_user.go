@@ -16,10 +16,7 @@ import (
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	authPayload, err := server.authorizeUser(ctx, []string{
-		utils.BankerRole,
-		utils.DepositorRole,
-	})
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_UpdateUser_FullMethodName)
 
 	if err != nil {
 		return nil, unauthenticatedError(err)
@@ -31,7 +28,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, inValidArgumentError(violations)
 	}
 
-	if authPayload.Role != utils.BankerRole && authPayload.Username != req.GetUsername() {
+	if authPayload.Username != req.GetUsername() && !utils.HasPermission(authPayload.Role, utils.PermissionUsersUpdateAny) {
 		return nil, status.Error(codes.PermissionDenied, "cannot update other user's info")
 	}
 
@@ -48,7 +45,25 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	}
 
 	if req.Password != nil {
-		hashedPassword, err := utils.HashedPassword(req.GetPassword())
+		user, err := server.store.GetUser(ctx, req.GetUsername())
+
+		if err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "user not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+		}
+
+		// The password must not contain the email the user is changing to.
+		if req.Email != nil {
+			user.Email = req.GetEmail()
+		}
+
+		if err := server.checkNewPassword(ctx, "password", user, req.GetPassword()); err != nil {
+			return nil, err
+		}
+
+		hashedPassword, err := server.passwordHasher.Hash(req.GetPassword())
 
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
@@ -65,7 +80,10 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		}
 	}
 
-	user, err := server.store.UpdateUser(ctx, arg)
+	txResult, err := server.store.UpdateUserTx(ctx, db.UpdateUserTxParams{
+		UpdateUserParams: arg,
+		Actor:            server.auditActor(ctx, authPayload.Username),
+	})
 
 	if err != nil {
 		if errors.Is(err, db.ErrorRecordNotFound) {
@@ -75,8 +93,13 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 	}
 
+	if req.Password != nil {
+		// Access tokens issued before the change are rejected from now on.
+		server.revocations.UserChanged(txResult.User.Username)
+	}
+
 	resp := &pb.UpdateUserResponse{
-		User: convertUser(user),
+		User: convertUser(txResult.User),
 	}
 
 	return resp, nil
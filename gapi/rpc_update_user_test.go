@@ -65,9 +65,12 @@ func TestUpdateUserAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Eq(arg)).
+					UpdateUserTx(gomock.Any(), gomock.Eq(db.UpdateUserTxParams{
+						UpdateUserParams: arg,
+						Actor:            db.AuditActor{Username: user.Username},
+					})).
 					Times(1).
-					Return(updatedUser, nil)
+					Return(db.UpdateUserTxResult{User: updatedUser}, nil)
 			},
 
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -92,9 +95,9 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.User{}, db.ErrorRecordNotFound)
+					Return(db.UpdateUserTxResult{}, db.ErrorRecordNotFound)
 			},
 
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -118,7 +121,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 
@@ -142,7 +145,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 
@@ -166,7 +169,7 @@ func TestUpdateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Any()).
+					UpdateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 
@@ -201,3 +204,154 @@ func TestUpdateUserAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateUserPasswordAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = utils.DepositorRole
+
+	password := "Current-Passw0rd"
+	currentHash, err := utils.HashedPassword(password)
+	require.NoError(t, err)
+	user.HashedPassword = currentHash
+
+	previousPassword := "Older-Passw0rd"
+	previousHash, err := utils.HashedPassword(previousPassword)
+	require.NoError(t, err)
+
+	history := []db.PasswordHistory{
+		{
+			Username:       user.Username,
+			HashedPassword: previousHash,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		password      string
+		email         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.UpdateUserResponse, err error)
+	}{
+		{
+			name:     "OK",
+			password: "Fresh-Passw0rd",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					ListPasswordHistory(gomock.Any(), gomock.Eq(db.ListPasswordHistoryParams{Username: user.Username, Limit: 2})).
+					Times(1).
+					Return(history, nil)
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+						require.True(t, arg.HashedPassword.Valid)
+						require.NoError(t, utils.CheckPassword("Fresh-Passw0rd", arg.HashedPassword.String))
+						require.True(t, arg.PasswordChangeAt.Valid)
+						return db.UpdateUserTxResult{User: user}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res.GetUser())
+			},
+		},
+		{
+			name:     "CurrentPassword",
+			password: password,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListPasswordHistory(gomock.Any(), gomock.Any()).Times(1).Return(history, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name:     "PreviousPassword",
+			password: previousPassword,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListPasswordHistory(gomock.Any(), gomock.Any()).Times(1).Return(history, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name:     "BreachedPassword",
+			password: "P@ssw0rd",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListPasswordHistory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name:     "ContainsNewEmail",
+			password: "Gl4cier-Newmail",
+			email:    "newmail@example.com",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListPasswordHistory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name:     "WeakPassword",
+			password: "alllowercase",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+			server.config.PasswordHistorySize = 3
+			server.passwordPolicy.MinLength = 8
+			server.passwordPolicy.MinCharacterClasses = 3
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+
+			req := &pb.UpdateUserRequest{
+				Username: user.Username,
+				Password: &tc.password,
+			}
+
+			if tc.email != "" {
+				req.Email = &tc.email
+			}
+
+			res, err := server.UpdateUser(ctx, req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func requireInvalidArgument(t *testing.T, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
@@ -0,0 +1,88 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UploadExchangeRates(ctx context.Context, req *pb.UploadExchangeRatesRequest) (*pb.UploadExchangeRatesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_UploadExchangeRates_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUploadExchangeRatesRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	arg := db.CreateExchangeRatesTxParams{
+		Actor: server.auditActor(ctx, authPayload.Username),
+	}
+
+	for _, rate := range req.GetRates() {
+		parsedRate, err := db.ParseRate(rate.GetRate())
+
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+
+		arg.Rates = append(arg.Rates, db.CreateExchangeRateParams{
+			BaseCurrency:  rate.GetBaseCurrency(),
+			QuoteCurrency: rate.GetQuoteCurrency(),
+			Rate:          parsedRate,
+			CreatedBy:     authPayload.Username,
+		})
+	}
+
+	txResult, err := server.store.CreateExchangeRatesTx(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload exchange rates: %v", err)
+	}
+
+	resp := &pb.UploadExchangeRatesResponse{}
+
+	for _, rate := range txResult.Rates {
+		resp.Rates = append(resp.Rates, convertExchangeRate(rate))
+	}
+
+	return resp, nil
+}
+
+func validateUploadExchangeRatesRequest(req *pb.UploadExchangeRatesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if len(req.GetRates()) == 0 {
+		violations = append(violations, fieldViolation("rates", fmt.Errorf("must contain at least one rate")))
+	}
+
+	for i, rate := range req.GetRates() {
+		field := fmt.Sprintf("rates[%d]", i)
+
+		if err := val.ValidateCurrency(rate.GetBaseCurrency()); err != nil {
+			violations = append(violations, fieldViolation(field+".base_currency", err))
+		}
+
+		if err := val.ValidateCurrency(rate.GetQuoteCurrency()); err != nil {
+			violations = append(violations, fieldViolation(field+".quote_currency", err))
+		}
+
+		if rate.GetBaseCurrency() == rate.GetQuoteCurrency() {
+			violations = append(violations, fieldViolation(field+".quote_currency", fmt.Errorf("must differ from base currency")))
+		}
+
+		if err := val.ValidateExchangeRate(rate.GetRate()); err != nil {
+			violations = append(violations, fieldViolation(field+".rate", err))
+		}
+	}
+
+	return violations
+}
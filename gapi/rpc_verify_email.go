@@ -21,6 +21,7 @@ func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReques
 	txResult, err := server.store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
 		EmailId:    req.GetEmailId(),
 		SecretCode: req.GetSecretCode(),
+		Actor:      server.auditActor(ctx, ""),
 	})
 
 	if err != nil {
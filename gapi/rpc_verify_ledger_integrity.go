@@ -0,0 +1,73 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) VerifyLedgerIntegrity(ctx context.Context, req *pb.VerifyLedgerIntegrityRequest) (*pb.VerifyLedgerIntegrityResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_VerifyLedgerIntegrity_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateVerifyLedgerIntegrityRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	if req.GetAccountId() != 0 {
+		if _, err := server.getAccount(ctx, req.GetAccountId()); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := server.store.VerifyLedgerIntegrity(ctx, db.VerifyLedgerIntegrityParams{
+		AccountID: req.GetAccountId(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify ledger integrity: %v", err)
+	}
+
+	resp := &pb.VerifyLedgerIntegrityResponse{
+		Intact:         result.Break == nil,
+		CheckedEntries: result.CheckedEntries,
+	}
+
+	if result.Break != nil {
+		log.Error().
+			Str("username", authPayload.Username).
+			Int64("account_id", result.Break.AccountID).
+			Int64("entry_id", result.Break.EntryID).
+			Str("reason", result.Break.Reason).
+			Msg("ledger integrity check found a broken link")
+
+		resp.Break = &pb.LedgerBreak{
+			AccountId: result.Break.AccountID,
+			EntryId:   result.Break.EntryID,
+			Reason:    result.Break.Reason,
+		}
+	}
+
+	return resp, nil
+}
+
+func validateVerifyLedgerIntegrityRequest(req *pb.VerifyLedgerIntegrityRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetAccountId() != 0 {
+		if err := val.ValidateID(req.GetAccountId()); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,134 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyLedgerIntegrityAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	depositor, _ := randomUser(t)
+	account := randomAccount(depositor.Username, utils.USD)
+
+	testCases := []struct {
+		name          string
+		req           *pb.VerifyLedgerIntegrityRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.VerifyLedgerIntegrityResponse, err error)
+	}{
+		{
+			name: "Intact",
+			req:  &pb.VerifyLedgerIntegrityRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyLedgerIntegrity(gomock.Any(), gomock.Eq(db.VerifyLedgerIntegrityParams{})).
+					Times(1).
+					Return(db.VerifyLedgerIntegrityResult{CheckedEntries: 42}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLedgerIntegrityResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetIntact())
+				require.Equal(t, int64(42), res.GetCheckedEntries())
+				require.Nil(t, res.GetBreak())
+			},
+		},
+		{
+			name: "Broken",
+			req:  &pb.VerifyLedgerIntegrityRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					VerifyLedgerIntegrity(gomock.Any(), gomock.Eq(db.VerifyLedgerIntegrityParams{AccountID: account.ID})).
+					Times(1).
+					Return(db.VerifyLedgerIntegrityResult{
+						CheckedEntries: 3,
+						Break: &db.LedgerBreak{
+							AccountID: account.ID,
+							EntryID:   7,
+							Reason:    "hash does not match the content of the entry",
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLedgerIntegrityResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetIntact())
+				require.Equal(t, account.ID, res.GetBreak().GetAccountId())
+				require.Equal(t, int64(7), res.GetBreak().GetEntryId())
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req:  &pb.VerifyLedgerIntegrityRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, db.ErrorRecordNotFound)
+				store.EXPECT().VerifyLedgerIntegrity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLedgerIntegrityResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidAccountID",
+			req:  &pb.VerifyLedgerIntegrityRequest{AccountId: -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyLedgerIntegrity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, utils.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLedgerIntegrityResponse, err error) {
+				requireInvalidArgument(t, err)
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req:  &pb.VerifyLedgerIntegrityRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyLedgerIntegrity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, utils.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLedgerIntegrityResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.VerifyLedgerIntegrity(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) VerifyLoginTwoFactor(ctx context.Context, req *pb.VerifyLoginTwoFactorRequest) (*pb.VerifyLoginTwoFactorResponse, error) {
+	violations := validateVerifyLoginTwoFactorRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	challengePayload, err := server.tokenMaker.VerifyToken(req.GetChallengeToken())
+
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid challenge token: %v", err)
+	}
+
+	if challengePayload.Type != token.TypeTwoFactorChallenge || challengePayload.Role != utils.TwoFactorChallengeRole {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid challenge token: not a two-factor challenge")
+	}
+
+	if err := server.revocations.Check(ctx, challengePayload); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid challenge token: %v", err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+
+	err = server.checkLoginThrottle(ctx, challengePayload.Username, mtdt.ClientIP)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := server.store.GetUser(ctx, challengePayload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error getting user: %v", err)
+	}
+
+	err = server.verifySecondFactor(ctx, user.Username, req.GetTotpCode(), req.GetRecoveryCode())
+
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			if err := server.recordLoginFailure(ctx, user.Username, mtdt.ClientIP, true); err != nil {
+				return nil, status.Errorf(codes.Internal, "%s", err)
+			}
+		}
+		return nil, err
+	}
+
+	// A challenge completes a single login.
+	err = server.revokeAccessToken(ctx, challengePayload)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke challenge token: %v", err)
+	}
+
+	login, err := server.createLoginSession(ctx, user)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.VerifyLoginTwoFactorResponse{
+		Login: login,
+	}, nil
+}
+
+func validateVerifyLoginTwoFactorRequest(req *pb.VerifyLoginTwoFactorRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetChallengeToken(), 1, 1024); err != nil {
+		violations = append(violations, fieldViolation("challenge_token", err))
+	}
+
+	violations = append(violations, validateSecondFactor(req.GetTotpCode(), req.GetRecoveryCode())...)
+
+	return violations
+}
@@ -0,0 +1,245 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/starjardin/simplebank/db/mock"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/token"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomTOTPCredential(t *testing.T, username string) db.TotpCredential {
+	secret, _, err := utils.NewTOTPSecret(username)
+	require.NoError(t, err)
+
+	return db.TotpCredential{
+		Username: username,
+		Secret:   secret,
+		ConfirmedAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+	}
+}
+
+func TestLoginUserTwoFactorChallenge(t *testing.T) {
+	user, password := randomUser(t)
+	credential := randomTOTPCredential(t, user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(credential, nil)
+	store.EXPECT().DeleteLoginFailure(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store, nil)
+	server.config.TwoFactorChallengeDuration = time.Minute
+
+	res, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+		Username: user.Username,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.True(t, res.GetTwoFactorRequired())
+	require.Nil(t, res.GetUser())
+	require.Empty(t, res.GetAccessToken())
+	require.Empty(t, res.GetRefreshToken())
+
+	payload, err := server.tokenMaker.VerifyToken(res.GetChallengeToken())
+	require.NoError(t, err)
+	require.Equal(t, utils.TwoFactorChallengeRole, payload.Role)
+
+	// The challenge token is not an access token.
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, utils.TwoFactorChallengeRole, time.Minute)
+	_, err = server.authorizeUser(ctx, pb.SimpleBank_ListAccounts_FullMethodName)
+	require.Error(t, err)
+}
+
+func TestVerifyLoginTwoFactorAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	credential := randomTOTPCredential(t, user.Username)
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildReq      func(t *testing.T, challengeToken string) *pb.VerifyLoginTwoFactorRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.VerifyLoginTwoFactorResponse, err error)
+	}{
+		{
+			name: "TOTPCode",
+			role: utils.TwoFactorChallengeRole,
+			buildReq: func(t *testing.T, challengeToken string) *pb.VerifyLoginTwoFactorRequest {
+				code, err := utils.TOTPCode(credential.Secret, time.Now())
+				require.NoError(t, err)
+				return &pb.VerifyLoginTwoFactorRequest{
+					ChallengeToken: challengeToken,
+					TotpCode:       code,
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(credential, nil)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+				store.EXPECT().RevokeToken(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().DeleteLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSessionTxResult{Session: randomSession(user.Username)}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginTwoFactorResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetLogin().GetAccessToken())
+				require.NotEmpty(t, res.GetLogin().GetRefreshToken())
+				require.Equal(t, user.Username, res.GetLogin().GetUser().GetUsername())
+			},
+		},
+		{
+			name: "RecoveryCode",
+			role: utils.TwoFactorChallengeRole,
+			buildReq: func(t *testing.T, challengeToken string) *pb.VerifyLoginTwoFactorRequest {
+				return &pb.VerifyLoginTwoFactorRequest{
+					ChallengeToken: challengeToken,
+					RecoveryCode:   "abcdefgh-ijklmnop",
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UseRecoveryCodeParams{
+					Username: user.Username,
+					CodeHash: utils.HashRecoveryCode("abcdefgh-ijklmnop"),
+				}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(credential, nil)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.RecoveryCode{}, nil)
+				store.EXPECT().RevokeToken(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().DeleteLoginFailure(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSessionTxResult{Session: randomSession(user.Username)}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginTwoFactorResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetLogin().GetAccessToken())
+			},
+		},
+		{
+			name: "InvalidCode",
+			role: utils.TwoFactorChallengeRole,
+			buildReq: func(t *testing.T, challengeToken string) *pb.VerifyLoginTwoFactorRequest {
+				code, err := utils.TOTPCode(credential.Secret, time.Now().Add(-time.Hour))
+				require.NoError(t, err)
+				return &pb.VerifyLoginTwoFactorRequest{
+					ChallengeToken: challengeToken,
+					TotpCode:       code,
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(credential, nil)
+				store.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), loginFailureScope(db.LoginFailureScopeTwoFactor)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), loginFailureScope(db.LoginFailureScopeUsername)).
+					Times(1).
+					Return(db.LoginFailure{FailedCount: 1}, nil)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginTwoFactorResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "AccessTokenAsChallenge",
+			role: utils.DepositorRole,
+			buildReq: func(t *testing.T, challengeToken string) *pb.VerifyLoginTwoFactorRequest {
+				return &pb.VerifyLoginTwoFactorRequest{
+					ChallengeToken: challengeToken,
+					TotpCode:       "123456",
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginTwoFactorResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "BothCodes",
+			role: utils.TwoFactorChallengeRole,
+			buildReq: func(t *testing.T, challengeToken string) *pb.VerifyLoginTwoFactorRequest {
+				return &pb.VerifyLoginTwoFactorRequest{
+					ChallengeToken: challengeToken,
+					TotpCode:       "123456",
+					RecoveryCode:   "abcdefgh-ijklmnop",
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyLoginTwoFactorResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			store.EXPECT().
+				GetLoginFailure(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.LoginFailure{}, db.ErrorRecordNotFound)
+
+			server := newTestServer(t, store, nil)
+
+			challengeToken := newChallengeToken(t, server.tokenMaker, user.Username, tc.role)
+
+			res, err := server.VerifyLoginTwoFactor(context.Background(), tc.buildReq(t, challengeToken))
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func newChallengeToken(t *testing.T, tokenMaker token.Maker, username string, role string) string {
+	tokenType := token.TypeAccess
+	if role == utils.TwoFactorChallengeRole {
+		tokenType = token.TypeTwoFactorChallenge
+	}
+
+	challengeToken, _, err := tokenMaker.CreateToken(token.Claims{
+		Username: username,
+		Role:     role,
+		Type:     tokenType,
+	}, time.Minute)
+	require.NoError(t, err)
+	return challengeToken
+}
@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/pb"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) Withdraw(ctx context.Context, req *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, pb.SimpleBank_Withdraw_FullMethodName)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateWithdrawRequest(req)
+
+	if violations != nil {
+		return nil, inValidArgumentError(violations)
+	}
+
+	_, err = server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	txResult, err := server.store.WithdrawTx(ctx, db.WithdrawTxParams{
+		AccountID:         req.GetAccountId(),
+		Amount:            req.GetAmount(),
+		ExternalReference: req.GetExternalReference(),
+		Actor:             server.auditActor(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountUnavailable) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "external reference %s already used", req.GetExternalReference())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to withdraw: %v", err)
+	}
+
+	resp := &pb.WithdrawResponse{
+		Account: convertAccount(txResult.Account),
+		Entry:   convertEntry(txResult.Entry),
+	}
+
+	return resp, nil
+}
+
+func validateWithdrawRequest(req *pb.WithdrawRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateExternalReference(req.GetExternalReference()); err != nil {
+		violations = append(violations, fieldViolation("external_reference", err))
+	}
+
+	return violations
+}
@@ -2,11 +2,13 @@ package gapi
 
 import (
 	"fmt"
+	"sync"
 
 	db "github.com/starjardin/simplebank/db/sqlc"
 	"github.com/starjardin/simplebank/pb"
 	"github.com/starjardin/simplebank/token"
 	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
 	"github.com/starjardin/simplebank/worker"
 )
 
@@ -16,19 +18,46 @@ type Server struct {
 	tokenMaker      token.Maker
 	config          utils.Config
 	taskDistributor worker.TaskDistributor
+	revocations     *token.RevocationList
+	passwordPolicy  val.PasswordPolicy
+	passwordHasher  utils.PasswordHasher
+	// dummyPasswordHash is checked against when the user does not exist, so
+	// that the response takes as long as for a wrong password.
+	dummyPasswordHash func() string
 }
 
 func NewServer(config utils.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymetricKey)
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	breachedPasswords, err := val.LoadBreachedPasswords(config.BreachedPasswordsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load breached passwords: %w", err)
+	}
+
+	passwordHasher, err := utils.NewPasswordHasher(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create password hasher: %w", err)
+	}
+
 	server := &Server{
 		store:           store,
 		config:          config,
 		tokenMaker:      tokenMaker,
 		taskDistributor: taskDistributor,
+		revocations:     token.NewRevocationList(store, config.RevocationCacheDuration),
+		passwordPolicy: val.PasswordPolicy{
+			MinLength:           config.PasswordMinLength,
+			MinCharacterClasses: config.PasswordMinCharacterClasses,
+			Breached:            breachedPasswords,
+		},
+		passwordHasher: passwordHasher,
+		dummyPasswordHash: sync.OnceValue(func() string {
+			hash, _ := passwordHasher.Hash(utils.RandomString(16))
+			return hash
+		}),
 	}
 	return server, nil
 }
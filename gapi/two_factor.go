@@ -0,0 +1,170 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/starjardin/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// twoFactorCredential returns the confirmed TOTP credential of username, or
+// false if two-factor authentication is not enabled.
+func (server *Server) twoFactorCredential(ctx context.Context, username string) (db.TotpCredential, bool, error) {
+	credential, err := server.store.GetTOTPCredential(ctx, username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return credential, false, nil
+		}
+		return credential, false, status.Errorf(codes.Internal, "failed to get two-factor credential: %v", err)
+	}
+
+	return credential, credential.ConfirmedAt.Valid, nil
+}
+
+// twoFactorThrottle limits the TOTP and recovery codes a user can try. It
+// applies wherever a code is checked, on top of the login throttles.
+func (server *Server) twoFactorThrottle(username string) loginThrottle {
+	return loginThrottle{
+		scope:       db.LoginFailureScopeTwoFactor,
+		key:         username,
+		maxAttempts: server.loginMaxAttempts(),
+	}
+}
+
+// checkTwoFactorThrottle rejects the attempt while username has to wait after
+// wrong codes.
+func (server *Server) checkTwoFactorThrottle(ctx context.Context, username string) error {
+	return server.checkThrottles(ctx, []loginThrottle{server.twoFactorThrottle(username)}, "failed two-factor")
+}
+
+// recordTwoFactorFailure counts a wrong code and delays the next attempt.
+func (server *Server) recordTwoFactorFailure(ctx context.Context, username string) error {
+	_, _, _, err := server.recordThrottleFailure(ctx, server.twoFactorThrottle(username))
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s", err)
+	}
+
+	return nil
+}
+
+// verifySecondFactor checks a TOTP code or a recovery code of username and
+// consumes it so that it cannot be used again. Wrong codes are counted and
+// lock further attempts.
+func (server *Server) verifySecondFactor(ctx context.Context, username string, totpCode string, recoveryCode string) error {
+	err := server.checkTwoFactorThrottle(ctx, username)
+
+	if err != nil {
+		return err
+	}
+
+	err = server.useSecondFactor(ctx, username, totpCode, recoveryCode)
+
+	if status.Code(err) == codes.Unauthenticated {
+		if err := server.recordTwoFactorFailure(ctx, username); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (server *Server) useSecondFactor(ctx context.Context, username string, totpCode string, recoveryCode string) error {
+	credential, enabled, err := server.twoFactorCredential(ctx, username)
+
+	if err != nil {
+		return err
+	}
+
+	if !enabled {
+		return status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	if totpCode == "" {
+		_, err = server.store.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+			Username: username,
+			CodeHash: utils.HashRecoveryCode(recoveryCode),
+		})
+
+		if err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				return status.Errorf(codes.Unauthenticated, "invalid recovery code")
+			}
+			return status.Errorf(codes.Internal, "failed to use recovery code: %v", err)
+		}
+
+		return nil
+	}
+
+	step, ok := utils.ValidateTOTP(credential.Secret, totpCode, time.Now(), credential.LastUsedStep)
+
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "invalid two-factor code")
+	}
+
+	// The update only matches if no other request used this step first.
+	_, err = server.store.UseTOTPStep(ctx, db.UseTOTPStepParams{
+		Username: username,
+		Step:     step,
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return status.Errorf(codes.Unauthenticated, "invalid two-factor code")
+		}
+		return status.Errorf(codes.Internal, "failed to use two-factor code: %v", err)
+	}
+
+	return nil
+}
+
+// requireStepUp asks for a TOTP code when amount is above the two-factor
+// transfer threshold. Users without two-factor authentication cannot make
+// such transfers.
+func (server *Server) requireStepUp(ctx context.Context, username string, amount int64, totpCode string) error {
+	threshold := server.config.TwoFactorTransferThreshold
+
+	if threshold <= 0 || amount <= threshold {
+		return nil
+	}
+
+	_, enabled, err := server.twoFactorCredential(ctx, username)
+
+	if err != nil {
+		return err
+	}
+
+	if !enabled {
+		return status.Errorf(codes.FailedPrecondition, "two-factor authentication must be enabled for amounts above %d", threshold)
+	}
+
+	if totpCode == "" {
+		return status.Errorf(codes.Unauthenticated, "two-factor code is required for amounts above %d", threshold)
+	}
+
+	return server.verifySecondFactor(ctx, username, totpCode, "")
+}
+
+func validateSecondFactor(totpCode string, recoveryCode string) (violations []*errdetails.BadRequest_FieldViolation) {
+	switch {
+	case totpCode != "" && recoveryCode != "":
+		violations = append(violations, fieldViolation("recovery_code", errors.New("cannot be set with totp_code")))
+	case recoveryCode != "":
+		if err := val.ValidateRecoveryCode(recoveryCode); err != nil {
+			violations = append(violations, fieldViolation("recovery_code", err))
+		}
+	default:
+		if err := val.ValidateTOTPCode(totpCode); err != nil {
+			violations = append(violations, fieldViolation("totp_code", err))
+		}
+	}
+
+	return violations
+}
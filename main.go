@@ -64,6 +64,14 @@ func main() {
 
 	store := db.NewStore(connPool)
 
+	rolePermissions, err := store.GetRolePermissions(ctx)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load role permissions")
+	}
+
+	utils.LoadRolePermissions(rolePermissions)
+
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
@@ -73,7 +81,8 @@ func main() {
 	waitGroup, ctx := errgroup.WithContext(ctx)
 
 	runGatewayServer(ctx, waitGroup, config, store, taskDistributor)
-	runTaskProcessor(ctx, waitGroup, config, redisOpt, store)
+	runTaskProcessor(ctx, waitGroup, config, redisOpt, store, taskDistributor)
+	runTaskScheduler(ctx, waitGroup, redisOpt)
 	runGrpcServer(ctx, waitGroup, config, store, taskDistributor)
 
 	err = waitGroup.Wait()
@@ -89,6 +98,7 @@ func runTaskProcessor(
 	config utils.Config,
 	redisOpt asynq.RedisClientOpt,
 	store db.Store,
+	taskDistributor worker.TaskDistributor,
 ) {
 	mailer := mail.NewGmailSender(
 		config.EmailSenderName,
@@ -96,7 +106,7 @@ func runTaskProcessor(
 		config.EmailSenderPassword,
 	)
 
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, taskDistributor)
 
 	log.Info().Msg("starting task processor...")
 
@@ -119,6 +129,37 @@ func runTaskProcessor(
 
 }
 
+func runTaskScheduler(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	redisOpt asynq.RedisClientOpt,
+) {
+	taskScheduler, err := worker.NewRedisTaskScheduler(redisOpt)
+
+	if err != nil {
+		log.Info().Msg("cannot create task scheduler")
+		return
+	}
+
+	log.Info().Msg("starting task scheduler...")
+
+	if err := taskScheduler.Start(); err != nil {
+		log.Info().Msg("cannot start task scheduler")
+	}
+
+	log.Info().Msg("task scheduler started successfully")
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("shutting down task scheduler...")
+
+		taskScheduler.ShutDown()
+
+		log.Info().Msg("task scheduler stopped successfully")
+		return nil
+	})
+}
+
 func runDBMigration(migrationURL, dbSource string) {
 	migration, err := migrate.New(migrationURL, dbSource)
 
@@ -157,7 +198,9 @@ func runGatewayServer(
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	headerMatcher := runtime.WithIncomingHeaderMatcher(gapi.IncomingHeaderMatcher)
+
+	grpcMux := runtime.NewServeMux(jsonOption, headerMatcher)
 
 	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
 
@@ -167,6 +210,7 @@ func runGatewayServer(
 
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	mux.Handle(gapi.JWKSPath, server.JWKSHandler())
 
 	statikFS, err := fs.New()
 
@@ -224,9 +268,9 @@ func runGrpcServer(
 	if err != nil {
 		log.Info().Msg("cannot create server")
 	}
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
+	interceptors := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, server.AuthInterceptor)
 
-	grpcServer := grpc.NewServer(grpcLogger)
+	grpcServer := grpc.NewServer(interceptors)
 
 	pb.RegisterSimpleBankServer(grpcServer, server)
 
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_delete_scheduled_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DeleteScheduledTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduledTransferRequest) Reset() {
+	*x = DeleteScheduledTransferRequest{}
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduledTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledTransferRequest) ProtoMessage() {}
+
+func (x *DeleteScheduledTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduledTransferRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_delete_scheduled_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeleteScheduledTransferRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteScheduledTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduledTransferResponse) Reset() {
+	*x = DeleteScheduledTransferResponse{}
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduledTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledTransferResponse) ProtoMessage() {}
+
+func (x *DeleteScheduledTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduledTransferResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_delete_scheduled_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+var File_rpc_delete_scheduled_transfer_proto protoreflect.FileDescriptor
+
+var file_rpc_delete_scheduled_transfer_proto_rawDesc = string([]byte{
+	0x0a, 0x23, 0x72, 0x70, 0x63, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0x30, 0x0a, 0x1e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x21, 0x0a, 0x1f, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x25,
+	0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x61,
+	0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61,
+	0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_rpc_delete_scheduled_transfer_proto_rawDescOnce sync.Once
+	file_rpc_delete_scheduled_transfer_proto_rawDescData []byte
+)
+
+func file_rpc_delete_scheduled_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_delete_scheduled_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_delete_scheduled_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_delete_scheduled_transfer_proto_rawDesc), len(file_rpc_delete_scheduled_transfer_proto_rawDesc)))
+	})
+	return file_rpc_delete_scheduled_transfer_proto_rawDescData
+}
+
+var file_rpc_delete_scheduled_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_delete_scheduled_transfer_proto_goTypes = []any{
+	(*DeleteScheduledTransferRequest)(nil),  // 0: pb.DeleteScheduledTransferRequest
+	(*DeleteScheduledTransferResponse)(nil), // 1: pb.DeleteScheduledTransferResponse
+}
+var file_rpc_delete_scheduled_transfer_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_delete_scheduled_transfer_proto_init() }
+func file_rpc_delete_scheduled_transfer_proto_init() {
+	if File_rpc_delete_scheduled_transfer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_delete_scheduled_transfer_proto_rawDesc), len(file_rpc_delete_scheduled_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_delete_scheduled_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_delete_scheduled_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_delete_scheduled_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_delete_scheduled_transfer_proto = out.File
+	file_rpc_delete_scheduled_transfer_proto_goTypes = nil
+	file_rpc_delete_scheduled_transfer_proto_depIdxs = nil
+}
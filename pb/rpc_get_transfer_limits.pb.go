@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_get_transfer_limits.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetTransferLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferLimitsRequest) Reset() {
+	*x = GetTransferLimitsRequest{}
+	mi := &file_rpc_get_transfer_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferLimitsRequest) ProtoMessage() {}
+
+func (x *GetTransferLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_transfer_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_transfer_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTransferLimitsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type GetTransferLimitsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// The role of the owner of the account.
+	Role            string          `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	RoleLimits      *TransferLimits `protobuf:"bytes,3,opt,name=role_limits,json=roleLimits,proto3" json:"role_limits,omitempty"`
+	AccountLimits   *TransferLimits `protobuf:"bytes,4,opt,name=account_limits,json=accountLimits,proto3" json:"account_limits,omitempty"`
+	EffectiveLimits *TransferLimits `protobuf:"bytes,5,opt,name=effective_limits,json=effectiveLimits,proto3" json:"effective_limits,omitempty"`
+	// What was sent from this account, which account limits are held to.
+	Usage *TransferUsage `protobuf:"bytes,6,opt,name=usage,proto3" json:"usage,omitempty"`
+	// What was sent from all the accounts of the owner, which role limits are
+	// held to.
+	OwnerUsage    *TransferUsage `protobuf:"bytes,7,opt,name=owner_usage,json=ownerUsage,proto3" json:"owner_usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferLimitsResponse) Reset() {
+	*x = GetTransferLimitsResponse{}
+	mi := &file_rpc_get_transfer_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferLimitsResponse) ProtoMessage() {}
+
+func (x *GetTransferLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_transfer_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferLimitsResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_transfer_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTransferLimitsResponse) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *GetTransferLimitsResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GetTransferLimitsResponse) GetRoleLimits() *TransferLimits {
+	if x != nil {
+		return x.RoleLimits
+	}
+	return nil
+}
+
+func (x *GetTransferLimitsResponse) GetAccountLimits() *TransferLimits {
+	if x != nil {
+		return x.AccountLimits
+	}
+	return nil
+}
+
+func (x *GetTransferLimitsResponse) GetEffectiveLimits() *TransferLimits {
+	if x != nil {
+		return x.EffectiveLimits
+	}
+	return nil
+}
+
+func (x *GetTransferLimitsResponse) GetUsage() *TransferUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *GetTransferLimitsResponse) GetOwnerUsage() *TransferUsage {
+	if x != nil {
+		return x.OwnerUsage
+	}
+	return nil
+}
+
+var File_rpc_get_transfer_limits_proto protoreflect.FileDescriptor
+
+var file_rpc_get_transfer_limits_proto_rawDesc = string([]byte{
+	0x0a, 0x1d, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x02, 0x70, 0x62, 0x1a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x39, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0xda, 0x02, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x0a, 0x72, 0x6f, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x10, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x52, 0x0f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x32,
+	0x0a, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0a, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x74, 0x61, 0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+})
+
+var (
+	file_rpc_get_transfer_limits_proto_rawDescOnce sync.Once
+	file_rpc_get_transfer_limits_proto_rawDescData []byte
+)
+
+func file_rpc_get_transfer_limits_proto_rawDescGZIP() []byte {
+	file_rpc_get_transfer_limits_proto_rawDescOnce.Do(func() {
+		file_rpc_get_transfer_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_get_transfer_limits_proto_rawDesc), len(file_rpc_get_transfer_limits_proto_rawDesc)))
+	})
+	return file_rpc_get_transfer_limits_proto_rawDescData
+}
+
+var file_rpc_get_transfer_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_transfer_limits_proto_goTypes = []any{
+	(*GetTransferLimitsRequest)(nil),  // 0: pb.GetTransferLimitsRequest
+	(*GetTransferLimitsResponse)(nil), // 1: pb.GetTransferLimitsResponse
+	(*TransferLimits)(nil),            // 2: pb.TransferLimits
+	(*TransferUsage)(nil),             // 3: pb.TransferUsage
+}
+var file_rpc_get_transfer_limits_proto_depIdxs = []int32{
+	2, // 0: pb.GetTransferLimitsResponse.role_limits:type_name -> pb.TransferLimits
+	2, // 1: pb.GetTransferLimitsResponse.account_limits:type_name -> pb.TransferLimits
+	2, // 2: pb.GetTransferLimitsResponse.effective_limits:type_name -> pb.TransferLimits
+	3, // 3: pb.GetTransferLimitsResponse.usage:type_name -> pb.TransferUsage
+	3, // 4: pb.GetTransferLimitsResponse.owner_usage:type_name -> pb.TransferUsage
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_transfer_limits_proto_init() }
+func file_rpc_get_transfer_limits_proto_init() {
+	if File_rpc_get_transfer_limits_proto != nil {
+		return
+	}
+	file_transfer_limits_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_get_transfer_limits_proto_rawDesc), len(file_rpc_get_transfer_limits_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_transfer_limits_proto_goTypes,
+		DependencyIndexes: file_rpc_get_transfer_limits_proto_depIdxs,
+		MessageInfos:      file_rpc_get_transfer_limits_proto_msgTypes,
+	}.Build()
+	File_rpc_get_transfer_limits_proto = out.File
+	file_rpc_get_transfer_limits_proto_goTypes = nil
+	file_rpc_get_transfer_limits_proto_depIdxs = nil
+}
@@ -0,0 +1,202 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_quote_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type QuoteTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountId int64                  `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   int64                  `protobuf:"varint,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteTransferRequest) Reset() {
+	*x = QuoteTransferRequest{}
+	mi := &file_rpc_quote_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteTransferRequest) ProtoMessage() {}
+
+func (x *QuoteTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_quote_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteTransferRequest.ProtoReflect.Descriptor instead.
+func (*QuoteTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_quote_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *QuoteTransferRequest) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *QuoteTransferRequest) GetToAccountId() int64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *QuoteTransferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type QuoteTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *TransferQuote         `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteTransferResponse) Reset() {
+	*x = QuoteTransferResponse{}
+	mi := &file_rpc_quote_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteTransferResponse) ProtoMessage() {}
+
+func (x *QuoteTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_quote_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteTransferResponse.ProtoReflect.Descriptor instead.
+func (*QuoteTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_quote_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuoteTransferResponse) GetQuote() *TransferQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+var File_rpc_quote_transfer_proto protoreflect.FileDescriptor
+
+var file_rpc_quote_transfer_proto_rawDesc = string([]byte{
+	0x0a, 0x18, 0x72, 0x70, 0x63, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7a, 0x0a, 0x14, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x6f, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x40, 0x0a, 0x15, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x74, 0x61, 0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+})
+
+var (
+	file_rpc_quote_transfer_proto_rawDescOnce sync.Once
+	file_rpc_quote_transfer_proto_rawDescData []byte
+)
+
+func file_rpc_quote_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_quote_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_quote_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_quote_transfer_proto_rawDesc), len(file_rpc_quote_transfer_proto_rawDesc)))
+	})
+	return file_rpc_quote_transfer_proto_rawDescData
+}
+
+var file_rpc_quote_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_quote_transfer_proto_goTypes = []any{
+	(*QuoteTransferRequest)(nil),  // 0: pb.QuoteTransferRequest
+	(*QuoteTransferResponse)(nil), // 1: pb.QuoteTransferResponse
+	(*TransferQuote)(nil),         // 2: pb.TransferQuote
+}
+var file_rpc_quote_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.QuoteTransferResponse.quote:type_name -> pb.TransferQuote
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_quote_transfer_proto_init() }
+func file_rpc_quote_transfer_proto_init() {
+	if File_rpc_quote_transfer_proto != nil {
+		return
+	}
+	file_transfer_quote_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_quote_transfer_proto_rawDesc), len(file_rpc_quote_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_quote_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_quote_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_quote_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_quote_transfer_proto = out.File
+	file_rpc_quote_transfer_proto_goTypes = nil
+	file_rpc_quote_transfer_proto_depIdxs = nil
+}
@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_set_transfer_limits.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Exactly one of role and account_id must be set.
+type SetTransferLimitsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Role      string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	AccountId int64                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Replace the previous limits of the role or account.
+	Limits        *TransferLimits `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
+	Reason        string          `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTransferLimitsRequest) Reset() {
+	*x = SetTransferLimitsRequest{}
+	mi := &file_rpc_set_transfer_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTransferLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTransferLimitsRequest) ProtoMessage() {}
+
+func (x *SetTransferLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_transfer_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTransferLimitsRequest.ProtoReflect.Descriptor instead.
+func (*SetTransferLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_transfer_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetTransferLimitsRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SetTransferLimitsRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SetTransferLimitsRequest) GetLimits() *TransferLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *SetTransferLimitsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetTransferLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *TransferLimits        `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTransferLimitsResponse) Reset() {
+	*x = SetTransferLimitsResponse{}
+	mi := &file_rpc_set_transfer_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTransferLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTransferLimitsResponse) ProtoMessage() {}
+
+func (x *SetTransferLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_transfer_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTransferLimitsResponse.ProtoReflect.Descriptor instead.
+func (*SetTransferLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_transfer_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetTransferLimitsResponse) GetLimits() *TransferLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+var File_rpc_set_transfer_limits_proto protoreflect.FileDescriptor
+
+var file_rpc_set_transfer_limits_proto_rawDesc = string([]byte{
+	0x0a, 0x1d, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x02, 0x70, 0x62, 0x1a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x91, 0x01, 0x0a, 0x18, 0x53,
+	0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x47,
+	0x0a, 0x19, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x61, 0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_rpc_set_transfer_limits_proto_rawDescOnce sync.Once
+	file_rpc_set_transfer_limits_proto_rawDescData []byte
+)
+
+func file_rpc_set_transfer_limits_proto_rawDescGZIP() []byte {
+	file_rpc_set_transfer_limits_proto_rawDescOnce.Do(func() {
+		file_rpc_set_transfer_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_set_transfer_limits_proto_rawDesc), len(file_rpc_set_transfer_limits_proto_rawDesc)))
+	})
+	return file_rpc_set_transfer_limits_proto_rawDescData
+}
+
+var file_rpc_set_transfer_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_transfer_limits_proto_goTypes = []any{
+	(*SetTransferLimitsRequest)(nil),  // 0: pb.SetTransferLimitsRequest
+	(*SetTransferLimitsResponse)(nil), // 1: pb.SetTransferLimitsResponse
+	(*TransferLimits)(nil),            // 2: pb.TransferLimits
+}
+var file_rpc_set_transfer_limits_proto_depIdxs = []int32{
+	2, // 0: pb.SetTransferLimitsRequest.limits:type_name -> pb.TransferLimits
+	2, // 1: pb.SetTransferLimitsResponse.limits:type_name -> pb.TransferLimits
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_transfer_limits_proto_init() }
+func file_rpc_set_transfer_limits_proto_init() {
+	if File_rpc_set_transfer_limits_proto != nil {
+		return
+	}
+	file_transfer_limits_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_set_transfer_limits_proto_rawDesc), len(file_rpc_set_transfer_limits_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_transfer_limits_proto_goTypes,
+		DependencyIndexes: file_rpc_set_transfer_limits_proto_depIdxs,
+		MessageInfos:      file_rpc_set_transfer_limits_proto_msgTypes,
+	}.Build()
+	File_rpc_set_transfer_limits_proto = out.File
+	file_rpc_set_transfer_limits_proto_goTypes = nil
+	file_rpc_set_transfer_limits_proto_depIdxs = nil
+}
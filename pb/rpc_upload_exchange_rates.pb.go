@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_upload_exchange_rates.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NewExchangeRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string                 `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewExchangeRate) Reset() {
+	*x = NewExchangeRate{}
+	mi := &file_rpc_upload_exchange_rates_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewExchangeRate) ProtoMessage() {}
+
+func (x *NewExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_upload_exchange_rates_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewExchangeRate.ProtoReflect.Descriptor instead.
+func (*NewExchangeRate) Descriptor() ([]byte, []int) {
+	return file_rpc_upload_exchange_rates_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NewExchangeRate) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *NewExchangeRate) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *NewExchangeRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type UploadExchangeRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*NewExchangeRate     `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadExchangeRatesRequest) Reset() {
+	*x = UploadExchangeRatesRequest{}
+	mi := &file_rpc_upload_exchange_rates_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadExchangeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadExchangeRatesRequest) ProtoMessage() {}
+
+func (x *UploadExchangeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_upload_exchange_rates_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadExchangeRatesRequest.ProtoReflect.Descriptor instead.
+func (*UploadExchangeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_upload_exchange_rates_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadExchangeRatesRequest) GetRates() []*NewExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type UploadExchangeRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*ExchangeRate        `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadExchangeRatesResponse) Reset() {
+	*x = UploadExchangeRatesResponse{}
+	mi := &file_rpc_upload_exchange_rates_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadExchangeRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadExchangeRatesResponse) ProtoMessage() {}
+
+func (x *UploadExchangeRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_upload_exchange_rates_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadExchangeRatesResponse.ProtoReflect.Descriptor instead.
+func (*UploadExchangeRatesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_upload_exchange_rates_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadExchangeRatesResponse) GetRates() []*ExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+var File_rpc_upload_exchange_rates_proto protoreflect.FileDescriptor
+
+var file_rpc_upload_exchange_rates_proto_rawDesc = string([]byte{
+	0x0a, 0x1f, 0x72, 0x70, 0x63, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x13, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x71, 0x0a, 0x0f, 0x4e, 0x65,
+	0x77, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x74,
+	0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x47, 0x0a,
+	0x1a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x72,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e,
+	0x4e, 0x65, 0x77, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x52,
+	0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x1b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x42, 0x25, 0x5a,
+	0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x61, 0x72,
+	0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e,
+	0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_rpc_upload_exchange_rates_proto_rawDescOnce sync.Once
+	file_rpc_upload_exchange_rates_proto_rawDescData []byte
+)
+
+func file_rpc_upload_exchange_rates_proto_rawDescGZIP() []byte {
+	file_rpc_upload_exchange_rates_proto_rawDescOnce.Do(func() {
+		file_rpc_upload_exchange_rates_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_upload_exchange_rates_proto_rawDesc), len(file_rpc_upload_exchange_rates_proto_rawDesc)))
+	})
+	return file_rpc_upload_exchange_rates_proto_rawDescData
+}
+
+var file_rpc_upload_exchange_rates_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rpc_upload_exchange_rates_proto_goTypes = []any{
+	(*NewExchangeRate)(nil),             // 0: pb.NewExchangeRate
+	(*UploadExchangeRatesRequest)(nil),  // 1: pb.UploadExchangeRatesRequest
+	(*UploadExchangeRatesResponse)(nil), // 2: pb.UploadExchangeRatesResponse
+	(*ExchangeRate)(nil),                // 3: pb.ExchangeRate
+}
+var file_rpc_upload_exchange_rates_proto_depIdxs = []int32{
+	0, // 0: pb.UploadExchangeRatesRequest.rates:type_name -> pb.NewExchangeRate
+	3, // 1: pb.UploadExchangeRatesResponse.rates:type_name -> pb.ExchangeRate
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_upload_exchange_rates_proto_init() }
+func file_rpc_upload_exchange_rates_proto_init() {
+	if File_rpc_upload_exchange_rates_proto != nil {
+		return
+	}
+	file_exchange_rate_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_upload_exchange_rates_proto_rawDesc), len(file_rpc_upload_exchange_rates_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_upload_exchange_rates_proto_goTypes,
+		DependencyIndexes: file_rpc_upload_exchange_rates_proto_depIdxs,
+		MessageInfos:      file_rpc_upload_exchange_rates_proto_msgTypes,
+	}.Build()
+	File_rpc_upload_exchange_rates_proto = out.File
+	file_rpc_upload_exchange_rates_proto_goTypes = nil
+	file_rpc_upload_exchange_rates_proto_depIdxs = nil
+}
@@ -0,0 +1,269 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_verify_ledger_integrity.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VerifyLedgerIntegrityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limits the check to one account when set.
+	AccountId     int64 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLedgerIntegrityRequest) Reset() {
+	*x = VerifyLedgerIntegrityRequest{}
+	mi := &file_rpc_verify_ledger_integrity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLedgerIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLedgerIntegrityRequest) ProtoMessage() {}
+
+func (x *VerifyLedgerIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_ledger_integrity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLedgerIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*VerifyLedgerIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_ledger_integrity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VerifyLedgerIntegrityRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type LedgerBreak struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Not set when the break is a transfer missing its entries.
+	EntryId       int64  `protobuf:"varint,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerBreak) Reset() {
+	*x = LedgerBreak{}
+	mi := &file_rpc_verify_ledger_integrity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerBreak) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerBreak) ProtoMessage() {}
+
+func (x *LedgerBreak) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_ledger_integrity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerBreak.ProtoReflect.Descriptor instead.
+func (*LedgerBreak) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_ledger_integrity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LedgerBreak) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *LedgerBreak) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *LedgerBreak) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type VerifyLedgerIntegrityResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Intact         bool                   `protobuf:"varint,1,opt,name=intact,proto3" json:"intact,omitempty"`
+	CheckedEntries int64                  `protobuf:"varint,2,opt,name=checked_entries,json=checkedEntries,proto3" json:"checked_entries,omitempty"`
+	// The first broken link, not set when the ledger is intact.
+	Break         *LedgerBreak `protobuf:"bytes,3,opt,name=break,proto3" json:"break,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLedgerIntegrityResponse) Reset() {
+	*x = VerifyLedgerIntegrityResponse{}
+	mi := &file_rpc_verify_ledger_integrity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLedgerIntegrityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLedgerIntegrityResponse) ProtoMessage() {}
+
+func (x *VerifyLedgerIntegrityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_ledger_integrity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLedgerIntegrityResponse.ProtoReflect.Descriptor instead.
+func (*VerifyLedgerIntegrityResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_ledger_integrity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VerifyLedgerIntegrityResponse) GetIntact() bool {
+	if x != nil {
+		return x.Intact
+	}
+	return false
+}
+
+func (x *VerifyLedgerIntegrityResponse) GetCheckedEntries() int64 {
+	if x != nil {
+		return x.CheckedEntries
+	}
+	return 0
+}
+
+func (x *VerifyLedgerIntegrityResponse) GetBreak() *LedgerBreak {
+	if x != nil {
+		return x.Break
+	}
+	return nil
+}
+
+var File_rpc_verify_ledger_integrity_proto protoreflect.FileDescriptor
+
+var file_rpc_verify_ledger_integrity_proto_rawDesc = string([]byte{
+	0x0a, 0x21, 0x72, 0x70, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0x3d, 0x0a, 0x1c, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x0b, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x42, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x87, 0x01, 0x0a, 0x1d, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x62, 0x72,
+	0x65, 0x61, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x52, 0x05, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x74, 0x61, 0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_rpc_verify_ledger_integrity_proto_rawDescOnce sync.Once
+	file_rpc_verify_ledger_integrity_proto_rawDescData []byte
+)
+
+func file_rpc_verify_ledger_integrity_proto_rawDescGZIP() []byte {
+	file_rpc_verify_ledger_integrity_proto_rawDescOnce.Do(func() {
+		file_rpc_verify_ledger_integrity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_verify_ledger_integrity_proto_rawDesc), len(file_rpc_verify_ledger_integrity_proto_rawDesc)))
+	})
+	return file_rpc_verify_ledger_integrity_proto_rawDescData
+}
+
+var file_rpc_verify_ledger_integrity_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rpc_verify_ledger_integrity_proto_goTypes = []any{
+	(*VerifyLedgerIntegrityRequest)(nil),  // 0: pb.VerifyLedgerIntegrityRequest
+	(*LedgerBreak)(nil),                   // 1: pb.LedgerBreak
+	(*VerifyLedgerIntegrityResponse)(nil), // 2: pb.VerifyLedgerIntegrityResponse
+}
+var file_rpc_verify_ledger_integrity_proto_depIdxs = []int32{
+	1, // 0: pb.VerifyLedgerIntegrityResponse.break:type_name -> pb.LedgerBreak
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_verify_ledger_integrity_proto_init() }
+func file_rpc_verify_ledger_integrity_proto_init() {
+	if File_rpc_verify_ledger_integrity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_verify_ledger_integrity_proto_rawDesc), len(file_rpc_verify_ledger_integrity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_verify_ledger_integrity_proto_goTypes,
+		DependencyIndexes: file_rpc_verify_ledger_integrity_proto_depIdxs,
+		MessageInfos:      file_rpc_verify_ledger_integrity_proto_msgTypes,
+	}.Build()
+	File_rpc_verify_ledger_integrity_proto = out.File
+	file_rpc_verify_ledger_integrity_proto_goTypes = nil
+	file_rpc_verify_ledger_integrity_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: rpc_verify_login_two_factor.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VerifyLoginTwoFactorRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	// Exactly one of totp_code and recovery_code must be set.
+	TotpCode      string `protobuf:"bytes,2,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"`
+	RecoveryCode  string `protobuf:"bytes,3,opt,name=recovery_code,json=recoveryCode,proto3" json:"recovery_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLoginTwoFactorRequest) Reset() {
+	*x = VerifyLoginTwoFactorRequest{}
+	mi := &file_rpc_verify_login_two_factor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginTwoFactorRequest) ProtoMessage() {}
+
+func (x *VerifyLoginTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_login_two_factor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*VerifyLoginTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_login_two_factor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VerifyLoginTwoFactorRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *VerifyLoginTwoFactorRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
+func (x *VerifyLoginTwoFactorRequest) GetRecoveryCode() string {
+	if x != nil {
+		return x.RecoveryCode
+	}
+	return ""
+}
+
+type VerifyLoginTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         *LoginUserResponse     `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLoginTwoFactorResponse) Reset() {
+	*x = VerifyLoginTwoFactorResponse{}
+	mi := &file_rpc_verify_login_two_factor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginTwoFactorResponse) ProtoMessage() {}
+
+func (x *VerifyLoginTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_login_two_factor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*VerifyLoginTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_login_two_factor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VerifyLoginTwoFactorResponse) GetLogin() *LoginUserResponse {
+	if x != nil {
+		return x.Login
+	}
+	return nil
+}
+
+var File_rpc_verify_login_two_factor_proto protoreflect.FileDescriptor
+
+var file_rpc_verify_login_two_factor_proto_rawDesc = string([]byte{
+	0x0a, 0x21, 0x72, 0x70, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x5f, 0x74, 0x77, 0x6f, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0f, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x88, 0x01, 0x0a, 0x1b, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x54, 0x77, 0x6f, 0x46, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x70, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x74, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43,
+	0x6f, 0x64, 0x65, 0x22, 0x4b, 0x0a, 0x1c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x54, 0x77, 0x6f, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
+	0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
+	0x74, 0x61, 0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_rpc_verify_login_two_factor_proto_rawDescOnce sync.Once
+	file_rpc_verify_login_two_factor_proto_rawDescData []byte
+)
+
+func file_rpc_verify_login_two_factor_proto_rawDescGZIP() []byte {
+	file_rpc_verify_login_two_factor_proto_rawDescOnce.Do(func() {
+		file_rpc_verify_login_two_factor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_verify_login_two_factor_proto_rawDesc), len(file_rpc_verify_login_two_factor_proto_rawDesc)))
+	})
+	return file_rpc_verify_login_two_factor_proto_rawDescData
+}
+
+var file_rpc_verify_login_two_factor_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_verify_login_two_factor_proto_goTypes = []any{
+	(*VerifyLoginTwoFactorRequest)(nil),  // 0: pb.VerifyLoginTwoFactorRequest
+	(*VerifyLoginTwoFactorResponse)(nil), // 1: pb.VerifyLoginTwoFactorResponse
+	(*LoginUserResponse)(nil),            // 2: pb.LoginUserResponse
+}
+var file_rpc_verify_login_two_factor_proto_depIdxs = []int32{
+	2, // 0: pb.VerifyLoginTwoFactorResponse.login:type_name -> pb.LoginUserResponse
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_verify_login_two_factor_proto_init() }
+func file_rpc_verify_login_two_factor_proto_init() {
+	if File_rpc_verify_login_two_factor_proto != nil {
+		return
+	}
+	file_rpc_login_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_verify_login_two_factor_proto_rawDesc), len(file_rpc_verify_login_two_factor_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_verify_login_two_factor_proto_goTypes,
+		DependencyIndexes: file_rpc_verify_login_two_factor_proto_depIdxs,
+		MessageInfos:      file_rpc_verify_login_two_factor_proto_msgTypes,
+	}.Build()
+	File_rpc_verify_login_two_factor_proto = out.File
+	file_rpc_verify_login_two_factor_proto_goTypes = nil
+	file_rpc_verify_login_two_factor_proto_depIdxs = nil
+}
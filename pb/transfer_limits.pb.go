@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v6.30.2
+// source: transfer_limits.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// An unset role limit is unlimited, an unset account limit falls back to the
+// role. Amounts are in the currency of the account. A role limit holds for
+// all the accounts of the owner together, an account limit for the account on
+// its own.
+type TransferLimits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PerTransfer   *int64                 `protobuf:"varint,1,opt,name=per_transfer,json=perTransfer,proto3,oneof" json:"per_transfer,omitempty"`
+	DailyAmount   *int64                 `protobuf:"varint,2,opt,name=daily_amount,json=dailyAmount,proto3,oneof" json:"daily_amount,omitempty"`
+	MonthlyAmount *int64                 `protobuf:"varint,3,opt,name=monthly_amount,json=monthlyAmount,proto3,oneof" json:"monthly_amount,omitempty"`
+	HourlyCount   *int64                 `protobuf:"varint,4,opt,name=hourly_count,json=hourlyCount,proto3,oneof" json:"hourly_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLimits) Reset() {
+	*x = TransferLimits{}
+	mi := &file_transfer_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLimits) ProtoMessage() {}
+
+func (x *TransferLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLimits.ProtoReflect.Descriptor instead.
+func (*TransferLimits) Descriptor() ([]byte, []int) {
+	return file_transfer_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferLimits) GetPerTransfer() int64 {
+	if x != nil && x.PerTransfer != nil {
+		return *x.PerTransfer
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetDailyAmount() int64 {
+	if x != nil && x.DailyAmount != nil {
+		return *x.DailyAmount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetMonthlyAmount() int64 {
+	if x != nil && x.MonthlyAmount != nil {
+		return *x.MonthlyAmount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetHourlyCount() int64 {
+	if x != nil && x.HourlyCount != nil {
+		return *x.HourlyCount
+	}
+	return 0
+}
+
+// What was sent in the last 24 hours, 30 days and hour.
+type TransferUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DailyAmount   int64                  `protobuf:"varint,1,opt,name=daily_amount,json=dailyAmount,proto3" json:"daily_amount,omitempty"`
+	MonthlyAmount int64                  `protobuf:"varint,2,opt,name=monthly_amount,json=monthlyAmount,proto3" json:"monthly_amount,omitempty"`
+	HourlyCount   int64                  `protobuf:"varint,3,opt,name=hourly_count,json=hourlyCount,proto3" json:"hourly_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferUsage) Reset() {
+	*x = TransferUsage{}
+	mi := &file_transfer_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferUsage) ProtoMessage() {}
+
+func (x *TransferUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferUsage.ProtoReflect.Descriptor instead.
+func (*TransferUsage) Descriptor() ([]byte, []int) {
+	return file_transfer_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransferUsage) GetDailyAmount() int64 {
+	if x != nil {
+		return x.DailyAmount
+	}
+	return 0
+}
+
+func (x *TransferUsage) GetMonthlyAmount() int64 {
+	if x != nil {
+		return x.MonthlyAmount
+	}
+	return 0
+}
+
+func (x *TransferUsage) GetHourlyCount() int64 {
+	if x != nil {
+		return x.HourlyCount
+	}
+	return 0
+}
+
+var File_transfer_limits_proto protoreflect.FileDescriptor
+
+var file_transfer_limits_proto_rawDesc = string([]byte{
+	0x0a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0xfa, 0x01, 0x0a, 0x0e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0c, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x0b,
+	0x64, 0x61, 0x69, 0x6c, 0x79, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2a,
+	0x0a, 0x0e, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x0d, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c,
+	0x79, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x68, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x48, 0x03, 0x52, 0x0b, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88,
+	0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x68, 0x6f, 0x75, 0x72,
+	0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x7c, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x61, 0x69,
+	0x6c, 0x79, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x68, 0x6f, 0x75, 0x72, 0x6c,
+	0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x61, 0x72, 0x6a, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x2f,
+	0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_transfer_limits_proto_rawDescOnce sync.Once
+	file_transfer_limits_proto_rawDescData []byte
+)
+
+func file_transfer_limits_proto_rawDescGZIP() []byte {
+	file_transfer_limits_proto_rawDescOnce.Do(func() {
+		file_transfer_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transfer_limits_proto_rawDesc), len(file_transfer_limits_proto_rawDesc)))
+	})
+	return file_transfer_limits_proto_rawDescData
+}
+
+var file_transfer_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_transfer_limits_proto_goTypes = []any{
+	(*TransferLimits)(nil), // 0: pb.TransferLimits
+	(*TransferUsage)(nil),  // 1: pb.TransferUsage
+}
+var file_transfer_limits_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_transfer_limits_proto_init() }
+func file_transfer_limits_proto_init() {
+	if File_transfer_limits_proto != nil {
+		return
+	}
+	file_transfer_limits_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transfer_limits_proto_rawDesc), len(file_transfer_limits_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_transfer_limits_proto_goTypes,
+		DependencyIndexes: file_transfer_limits_proto_depIdxs,
+		MessageInfos:      file_transfer_limits_proto_msgTypes,
+	}.Build()
+	File_transfer_limits_proto = out.File
+	file_transfer_limits_proto_goTypes = nil
+	file_transfer_limits_proto_depIdxs = nil
+}
@@ -0,0 +1,56 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+func renderCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{
+		"entry_id",
+		"date",
+		"type",
+		"description",
+		"amount",
+		"balance",
+		"transfer_id",
+		"counterparty_account_id",
+		"external_reference",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, row := range statement.Entries {
+		entry := row.Entry
+
+		err := writer.Write([]string{
+			strconv.FormatInt(entry.ID, 10),
+			entry.CreatedAt.Time.Format(time.RFC3339),
+			entry.Type,
+			description(row),
+			strconv.FormatInt(entry.Amount, 10),
+			strconv.FormatInt(entry.BalanceAfter, 10),
+			optionalID(entry.TransferID.Int64),
+			optionalID(row.CounterpartyAccountID),
+			entry.ExternalReference.String,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func optionalID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,83 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	ofxTimeLayout = "20060102150405"
+	// Quicken only imports files from known institutions.
+	qfxIntuitBankID = "3000"
+)
+
+var ofxEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// renderOFX writes an OFX 1.0.2 (SGML) bank statement. A QFX file is the same
+// document with the Intuit bank id in the sign-on response.
+func renderOFX(w io.Writer, statement Statement, qfx bool) error {
+	var b strings.Builder
+
+	now := time.Now().UTC().Format(ofxTimeLayout)
+
+	b.WriteString("OFXHEADER:100\r\nDATA:OFXSGML\r\nVERSION:102\r\nSECURITY:NONE\r\nENCODING:USASCII\r\nCHARSET:1252\r\nCOMPRESSION:NONE\r\nOLDFILEUID:NONE\r\nNEWFILEUID:NONE\r\n\r\n")
+	b.WriteString("<OFX>\n")
+	b.WriteString("<SIGNONMSGSRSV1><SONRS>\n")
+	b.WriteString("<STATUS><CODE>0<SEVERITY>INFO</STATUS>\n")
+	fmt.Fprintf(&b, "<DTSERVER>%s\n<LANGUAGE>ENG\n", now)
+	if qfx {
+		fmt.Fprintf(&b, "<INTU.BID>%s\n", qfxIntuitBankID)
+	}
+	b.WriteString("</SONRS></SIGNONMSGSRSV1>\n")
+
+	b.WriteString("<BANKMSGSRSV1><STMTTRNRS>\n")
+	b.WriteString("<TRNUID>0\n<STATUS><CODE>0<SEVERITY>INFO</STATUS>\n")
+	b.WriteString("<STMTRS>\n")
+	fmt.Fprintf(&b, "<CURDEF>%s\n", statement.Account.Currency)
+	fmt.Fprintf(&b, "<BANKACCTFROM><BANKID>SIMPLEBANK<ACCTID>%d<ACCTTYPE>CHECKING</BANKACCTFROM>\n", statement.Account.ID)
+
+	b.WriteString("<BANKTRANLIST>\n")
+	fmt.Fprintf(&b, "<DTSTART>%s\n<DTEND>%s\n",
+		statement.StartTime.UTC().Format(ofxTimeLayout),
+		statement.EndTime.UTC().Format(ofxTimeLayout),
+	)
+
+	for _, row := range statement.Entries {
+		entry := row.Entry
+
+		transactionType := "CREDIT"
+		if entry.Amount < 0 {
+			transactionType = "DEBIT"
+		}
+
+		b.WriteString("<STMTTRN>\n")
+		fmt.Fprintf(&b, "<TRNTYPE>%s\n", transactionType)
+		fmt.Fprintf(&b, "<DTPOSTED>%s\n", entry.CreatedAt.Time.Format(ofxTimeLayout))
+		fmt.Fprintf(&b, "<TRNAMT>%d\n", entry.Amount)
+		fmt.Fprintf(&b, "<FITID>%d\n", entry.ID)
+		fmt.Fprintf(&b, "<NAME>%s\n", ofxEscaper.Replace(truncate(description(row), 32)))
+		if entry.ExternalReference.Valid {
+			fmt.Fprintf(&b, "<MEMO>%s\n", ofxEscaper.Replace(entry.ExternalReference.String))
+		}
+		b.WriteString("</STMTTRN>\n")
+	}
+
+	b.WriteString("</BANKTRANLIST>\n")
+	fmt.Fprintf(&b, "<LEDGERBAL><BALAMT>%d<DTASOF>%s</LEDGERBAL>\n",
+		statement.ClosingBalance,
+		statement.EndTime.UTC().Format(ofxTimeLayout),
+	)
+	b.WriteString("</STMTRS>\n</STMTTRNRS></BANKMSGSRSV1>\n</OFX>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
@@ -0,0 +1,88 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+var pdfColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date", 38, "L"},
+	{"Description", 82, "L"},
+	{"Amount", 30, "R"},
+	{"Balance", 30, "R"},
+}
+
+func renderPDF(w io.Writer, statement Statement) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Statement for account #%d", statement.Account.ID), false)
+	pdf.SetAutoPageBreak(true, 15)
+
+	tableHeader := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		for _, column := range pdfColumns {
+			pdf.CellFormat(column.width, 7, column.title, "B", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+
+	// The first page starts with the summary, later pages repeat the table header.
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() > 1 {
+			tableHeader()
+		}
+	})
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "Simple Bank account statement", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	summary := []string{
+		fmt.Sprintf("Account #%d (%s), owner %s", statement.Account.ID, statement.Account.Currency, statement.Account.Owner),
+		fmt.Sprintf("Period: %s to %s", statement.StartTime.UTC().Format(time.DateTime), statement.EndTime.UTC().Format(time.DateTime)),
+		fmt.Sprintf("Opening balance: %d", statement.OpeningBalance),
+		fmt.Sprintf("Closing balance: %d", statement.ClosingBalance),
+	}
+	for _, line := range summary {
+		pdf.CellFormat(0, 6, line, "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	tableHeader()
+
+	if len(statement.Entries) == 0 {
+		pdf.CellFormat(0, 6, "No entries in this period.", "", 1, "L", false, 0, "")
+	}
+
+	for _, row := range statement.Entries {
+		values := []string{
+			row.Entry.CreatedAt.Time.Format(time.DateTime),
+			truncate(description(row), 48),
+			strconv.FormatInt(row.Entry.Amount, 10),
+			strconv.FormatInt(row.Entry.BalanceAfter, 10),
+		}
+
+		for i, column := range pdfColumns {
+			pdf.CellFormat(column.width, 6, values[i], "", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,137 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+	FormatQFX = "qfx"
+	FormatPDF = "pdf"
+)
+
+const loadPageSize = 500
+
+type Statement struct {
+	Account        db.Account
+	StartTime      time.Time
+	EndTime        time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Entries        []db.ListStatementEntriesRow
+}
+
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatOFX, FormatQFX, FormatPDF:
+		return true
+	default:
+		return false
+	}
+}
+
+// Load reads all the entries of account between startTime and endTime.
+func Load(ctx context.Context, store db.Store, account db.Account, startTime, endTime time.Time) (Statement, error) {
+	statement := Statement{
+		Account:   account,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+
+	var err error
+
+	statement.OpeningBalance, err = store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		AccountID: account.ID,
+		At:        timestamp(startTime),
+	})
+	if err != nil {
+		return statement, fmt.Errorf("failed to get opening balance: %w", err)
+	}
+
+	statement.ClosingBalance, err = store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		AccountID: account.ID,
+		At:        timestamp(endTime),
+	})
+	if err != nil {
+		return statement, fmt.Errorf("failed to get closing balance: %w", err)
+	}
+
+	arg := db.ListStatementEntriesParams{
+		AccountID:      account.ID,
+		StartTime:      timestamp(startTime),
+		EndTime:        timestamp(endTime),
+		AfterCreatedAt: timestamp(startTime),
+		LimitCount:     loadPageSize,
+	}
+
+	for {
+		rows, err := store.ListStatementEntries(ctx, arg)
+		if err != nil {
+			return statement, fmt.Errorf("failed to list statement entries: %w", err)
+		}
+
+		statement.Entries = append(statement.Entries, rows...)
+
+		if len(rows) < loadPageSize {
+			return statement, nil
+		}
+
+		last := rows[len(rows)-1].Entry
+		arg.AfterCreatedAt = last.CreatedAt
+		arg.AfterID = last.ID
+	}
+}
+
+// Render writes statement to w in the given format.
+func Render(w io.Writer, format string, statement Statement) error {
+	switch format {
+	case FormatCSV:
+		return renderCSV(w, statement)
+	case FormatOFX:
+		return renderOFX(w, statement, false)
+	case FormatQFX:
+		return renderOFX(w, statement, true)
+	case FormatPDF:
+		return renderPDF(w, statement)
+	default:
+		return fmt.Errorf("unsupported statement format %q", format)
+	}
+}
+
+// FileName returns the name of the file a statement is exported to.
+func FileName(format string, statement Statement) string {
+	return fmt.Sprintf("statement-%d-%s-%s.%s",
+		statement.Account.ID,
+		statement.StartTime.Format(time.DateOnly),
+		statement.EndTime.Format(time.DateOnly),
+		format,
+	)
+}
+
+func description(row db.ListStatementEntriesRow) string {
+	if row.CounterpartyAccountID == 0 {
+		return row.Entry.Type
+	}
+
+	direction := "to"
+	if row.Entry.Amount > 0 {
+		direction = "from"
+	}
+
+	return fmt.Sprintf("%s %s account #%d (%s)", row.Entry.Type, direction, row.CounterpartyAccountID, row.CounterpartyOwner)
+}
+
+// Entry times are stored without a time zone, in UTC.
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{
+		Time:  t.UTC(),
+		Valid: true,
+	}
+}
@@ -0,0 +1,93 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatement(n int) Statement {
+	account := db.Account{
+		ID:       utils.RandomInt(1, 1000),
+		Owner:    utils.RandomOwner(),
+		Balance:  utils.RandomMoney(),
+		Currency: utils.USD,
+	}
+
+	startTime := time.Now().Add(-24 * time.Hour).UTC()
+
+	statement := Statement{
+		Account:        account,
+		StartTime:      startTime,
+		EndTime:        time.Now().UTC(),
+		OpeningBalance: account.Balance,
+		ClosingBalance: account.Balance,
+	}
+
+	for i := 0; i < n; i++ {
+		amount := utils.RandomInt(-100, 100)
+		statement.ClosingBalance += amount
+
+		statement.Entries = append(statement.Entries, db.ListStatementEntriesRow{
+			Entry: db.Entry{
+				ID:           int64(i + 1),
+				AccountID:    account.ID,
+				Amount:       amount,
+				Type:         db.EntryTypeTransfer,
+				BalanceAfter: statement.ClosingBalance,
+				TransferID:   pgtype.Int8{Int64: int64(i + 1), Valid: true},
+				CreatedAt:    pgtype.Timestamp{Time: startTime.Add(time.Duration(i) * time.Minute), Valid: true},
+			},
+			CounterpartyAccountID: account.ID + 1,
+			CounterpartyOwner:     utils.RandomOwner(),
+		})
+	}
+
+	return statement
+}
+
+func TestRenderCSV(t *testing.T) {
+	statement := randomStatement(3)
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatCSV, statement))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(statement.Entries)+1)
+	require.Equal(t, "entry_id", records[0][0])
+	require.Equal(t, "1", records[1][0])
+}
+
+func TestRenderOFX(t *testing.T) {
+	statement := randomStatement(3)
+
+	var ofx bytes.Buffer
+	require.NoError(t, Render(&ofx, FormatOFX, statement))
+	require.Equal(t, 3, bytes.Count(ofx.Bytes(), []byte("<STMTTRN>")))
+	require.NotContains(t, ofx.String(), "INTU.BID")
+
+	var qfx bytes.Buffer
+	require.NoError(t, Render(&qfx, FormatQFX, statement))
+	require.Contains(t, qfx.String(), "<INTU.BID>")
+}
+
+func TestRenderPDF(t *testing.T) {
+	statement := randomStatement(200)
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatPDF, statement))
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.Error(t, Render(&buf, "xls", randomStatement(1)))
+	require.False(t, IsSupportedFormat("xls"))
+}
@@ -0,0 +1,58 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JSONWebKey is the public part of a key as described in RFC 7517.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// Curve and X are set for Ed25519 keys.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	// Modulus and Exponent are set for RSA keys.
+	Modulus  string `json:"n,omitempty"`
+	Exponent string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns every public key of the key set, including the ones that are
+// no longer used for signing, so tokens they signed can still be verified.
+func (keys *KeySet) JWKS() JSONWebKeySet {
+	jwks := JSONWebKeySet{
+		Keys: []JSONWebKey{},
+	}
+
+	for _, id := range keys.KeyIDs() {
+		jwk := JSONWebKey{
+			KeyID: id,
+			Use:   "sig",
+		}
+
+		switch key := keys.publicKeys[id].(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Algorithm = "EdDSA"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Algorithm = "RS256"
+			jwk.Modulus = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+			jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
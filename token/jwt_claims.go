@@ -0,0 +1,82 @@
+package token
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jwtClaims is how a payload is carried in a JWT. iat and exp are NumericDate
+// values, as RFC 7519 requires, so that standard JWT libraries can verify the
+// tokens with the published keys.
+type jwtClaims struct {
+	*Payload
+	IssuedAt  numericDate `json:"iat"`
+	ExpiredAt numericDate `json:"exp"`
+}
+
+func newJWTClaims(payload *Payload) *jwtClaims {
+	return &jwtClaims{
+		Payload:   payload,
+		IssuedAt:  numericDate(payload.IssuedAt),
+		ExpiredAt: numericDate(payload.ExpiredAt),
+	}
+}
+
+func (claims *jwtClaims) payload() *Payload {
+	payload := claims.Payload
+	if payload == nil {
+		payload = &Payload{}
+	}
+
+	payload.IssuedAt = time.Time(claims.IssuedAt)
+	payload.ExpiredAt = time.Time(claims.ExpiredAt)
+	return payload
+}
+
+func (claims *jwtClaims) Valid() error {
+	return claims.payload().Valid()
+}
+
+// numericDate is a time in seconds since the epoch. It keeps microseconds as
+// a fraction so that a token issued right after a password change is not
+// taken for one issued before it.
+type numericDate time.Time
+
+func (date numericDate) MarshalJSON() ([]byte, error) {
+	t := time.Time(date).Truncate(time.Microsecond)
+	return []byte(fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/int(time.Microsecond))), nil
+}
+
+func (date *numericDate) UnmarshalJSON(data []byte) error {
+	value := string(data)
+
+	seconds, fraction, _ := strings.Cut(value, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		// Not written by MarshalJSON, such as an exponent form.
+		float, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid numeric date %s", value)
+		}
+		*date = numericDate(time.UnixMicro(int64(float * 1e6)))
+		return nil
+	}
+
+	var nsec uint64
+	if fraction != "" {
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+		}
+		fraction += strings.Repeat("0", 9-len(fraction))
+
+		nsec, err = strconv.ParseUint(fraction, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid numeric date %s", value)
+		}
+	}
+
+	*date = numericDate(time.Unix(sec, int64(nsec)))
+	return nil
+}
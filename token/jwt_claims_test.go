@@ -0,0 +1,26 @@
+package token
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericDate(t *testing.T) {
+	issuedAt := time.Date(2026, 10, 18, 12, 0, 0, 123456789, time.UTC)
+
+	data, err := json.Marshal(numericDate(issuedAt))
+	require.NoError(t, err)
+	require.Equal(t, "1792324800.123456", string(data))
+
+	var date numericDate
+	require.NoError(t, json.Unmarshal(data, &date))
+	require.True(t, issuedAt.Truncate(time.Microsecond).Equal(time.Time(date)))
+
+	require.NoError(t, json.Unmarshal([]byte("1792324800"), &date))
+	require.True(t, issuedAt.Truncate(time.Second).Equal(time.Time(date)))
+
+	require.Error(t, json.Unmarshal([]byte(`"2026-10-18T12:00:00Z"`), &date))
+}
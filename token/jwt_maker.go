@@ -24,13 +24,13 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 
 	return &JWTMaker{secretKey}, nil
 }
-func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
+func (maker *JWTMaker) CreateToken(claims Claims, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(claims, duration)
 	if err != nil {
 		return "", nil, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newJWTClaims(payload))
 	tk, err := token.SignedString([]byte(maker.secretKey))
 
 	return tk, payload, err
@@ -48,7 +48,7 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 		return []byte(maker.secretKey), nil
 	}
 
-	parsedToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	parsedToken, err := jwt.ParseWithClaims(token, &jwtClaims{}, keyFunc)
 
 	if err != nil {
 		verr, ok := err.(*jwt.ValidationError)
@@ -58,10 +58,10 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 		return nil, ErrInvalidToken
 	}
 
-	payload, ok := parsedToken.Claims.(*Payload)
+	claims, ok := parsedToken.Claims.(*jwtClaims)
 	if !ok {
 		return nil, ErrInvalidToken
 	}
 
-	return payload, nil
+	return claims.payload(), nil
 }
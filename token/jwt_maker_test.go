@@ -20,7 +20,7 @@ func JWTMakerTest(t *testing.T) {
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, payload, err := maker.CreateToken(username, role, duration)
+	token, payload, err := maker.CreateToken(Claims{Username: username, Role: role, Type: TypeAccess}, duration)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
@@ -32,6 +32,7 @@ func JWTMakerTest(t *testing.T) {
 	require.NotZero(t, payload.ID)
 	require.Equal(t, username, payload.Username)
 	require.Equal(t, role, payload.Role)
+	require.Equal(t, TypeAccess, payload.Type)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
@@ -46,7 +47,7 @@ func TestExpiredJWTToken(t *testing.T) {
 	username := utils.RandomOwner()
 	duration := time.Minute
 
-	token, payload, err := maker.CreateToken(username, role, -duration)
+	token, payload, err := maker.CreateToken(Claims{Username: username, Role: role, Type: TypeAccess}, -duration)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
@@ -60,7 +61,7 @@ func TestExpiredJWTToken(t *testing.T) {
 }
 
 func TestInvalidJWTTokenAlgNone(t *testing.T) {
-	payload, err := NewPayload(utils.RandomOwner(), utils.DepositorRole, time.Minute)
+	payload, err := NewPayload(randomAccessClaims(), time.Minute)
 
 	require.NoError(t, err)
 
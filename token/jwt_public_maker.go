@@ -0,0 +1,96 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const keyIDHeader = "kid"
+
+// JWTPublicMaker signs JWTs with RS256 or EdDSA depending on the type of the
+// signing key, so services only need the public keys to verify them.
+type JWTPublicMaker struct {
+	keys *KeySet
+}
+
+func NewJWTPublicMaker(keys *KeySet) (Maker, error) {
+	return &JWTPublicMaker{keys}, nil
+}
+
+func (maker *JWTPublicMaker) KeySet() *KeySet {
+	return maker.keys
+}
+
+func (maker *JWTPublicMaker) CreateToken(claims Claims, duration time.Duration) (string, *Payload, error) {
+	keyID, signingKey, err := maker.keys.SigningKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload, err := NewPayload(claims, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := jwt.NewWithClaims(signingMethod(signingKey.Public()), newJWTClaims(payload))
+	token.Header[keyIDHeader] = keyID
+
+	tk, err := token.SignedString(signingKey)
+
+	return tk, payload, err
+}
+
+// VerifyToken verifies the token with the key named in its header and returns
+// the payload if valid
+func (maker *JWTPublicMaker) VerifyToken(token string) (*Payload, error) {
+
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header[keyIDHeader].(string)
+
+		publicKey, err := maker.keys.PublicKey(keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The algorithm comes from the token, so it must match the key to
+		// stop a token signed with one algorithm being checked with another.
+		if token.Method != signingMethod(publicKey) {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return publicKey, nil
+	}
+
+	parsedToken, err := jwt.ParseWithClaims(token, &jwtClaims{}, keyFunc)
+
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := parsedToken.Claims.(*jwtClaims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	return claims.payload(), nil
+}
+
+func signingMethod(publicKey crypto.PublicKey) jwt.SigningMethod {
+	switch publicKey.(type) {
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256
+	default:
+		return nil
+	}
+}
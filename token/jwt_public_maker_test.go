@@ -0,0 +1,181 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTPublicMaker(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  crypto.Signer
+		alg  string
+	}{
+		{
+			name: "RS256",
+			key:  randomRSAKey(t),
+			alg:  "RS256",
+		},
+		{
+			name: "EdDSA",
+			key:  randomEd25519Key(t),
+			alg:  "EdDSA",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": tc.key}, nil)
+			require.NoError(t, err)
+
+			maker, err := NewJWTPublicMaker(keys)
+			require.NoError(t, err)
+
+			username := utils.RandomOwner()
+
+			token, _, err := maker.CreateToken(Claims{Username: username, Role: utils.DepositorRole, Type: TypeAccess}, time.Minute)
+			require.NoError(t, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, parsed.Header["alg"])
+			require.Equal(t, "key1", parsed.Header["kid"])
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, username, payload.Username)
+		})
+	}
+}
+
+// The tokens are verified by other services with the published keys, so a
+// standard parser must accept them.
+func TestJWTPublicMakerStandardParser(t *testing.T) {
+	for _, key := range []crypto.Signer{randomRSAKey(t), randomEd25519Key(t)} {
+		keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": key}, nil)
+		require.NoError(t, err)
+
+		maker, err := NewJWTPublicMaker(keys)
+		require.NoError(t, err)
+
+		username := utils.RandomOwner()
+
+		token, payload, err := maker.CreateToken(Claims{Username: username, Role: utils.DepositorRole, Type: TypeAccess}, time.Minute)
+		require.NoError(t, err)
+
+		published, err := json.Marshal(keys.JWKS())
+		require.NoError(t, err)
+
+		var jwks JSONWebKeySet
+		require.NoError(t, json.Unmarshal(published, &jwks))
+
+		parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+			for _, jwk := range jwks.Keys {
+				if jwk.KeyID == token.Header["kid"] && jwk.Algorithm == token.Method.Alg() {
+					return publicKeyFromJWK(t, jwk), nil
+				}
+			}
+			return nil, fmt.Errorf("unknown key %v", token.Header["kid"])
+		})
+		require.NoError(t, err)
+		require.True(t, parsed.Valid)
+
+		claims := parsed.Claims.(jwt.MapClaims)
+		require.Equal(t, username, claims["username"])
+		require.Equal(t, float64(payload.ExpiredAt.Unix()), math.Floor(claims["exp"].(float64)))
+		require.Equal(t, float64(payload.IssuedAt.Unix()), math.Floor(claims["iat"].(float64)))
+	}
+}
+
+func TestJWTPublicMakerExpiredForStandardParser(t *testing.T) {
+	keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": randomEd25519Key(t)}, nil)
+	require.NoError(t, err)
+
+	maker, err := NewJWTPublicMaker(keys)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(randomAccessClaims(), -time.Minute)
+	require.NoError(t, err)
+
+	_, err = jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		return publicKeyFromJWK(t, keys.JWKS().Keys[0]), nil
+	})
+	var verr *jwt.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.NotZero(t, verr.Errors&jwt.ValidationErrorExpired)
+}
+
+func publicKeyFromJWK(t *testing.T, jwk JSONWebKey) crypto.PublicKey {
+	decode := func(value string) []byte {
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		require.NoError(t, err)
+		return data
+	}
+
+	switch jwk.KeyType {
+	case "OKP":
+		return ed25519.PublicKey(decode(jwk.X))
+	case "RSA":
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(decode(jwk.Modulus)),
+			E: int(new(big.Int).SetBytes(decode(jwk.Exponent)).Int64()),
+		}
+	}
+
+	t.Fatalf("unexpected key type %s", jwk.KeyType)
+	return nil
+}
+
+func TestJWTPublicMakerUnknownKey(t *testing.T) {
+	keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": randomEd25519Key(t)}, nil)
+	require.NoError(t, err)
+
+	maker, err := NewJWTPublicMaker(keys)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(Claims{Username: utils.RandomOwner(), Role: utils.DepositorRole, Type: TypeAccess}, time.Minute)
+	require.NoError(t, err)
+
+	otherKeys, err := NewKeySet("key2", map[string]crypto.Signer{"key2": randomEd25519Key(t)}, nil)
+	require.NoError(t, err)
+
+	otherMaker, err := NewJWTPublicMaker(otherKeys)
+	require.NoError(t, err)
+
+	payload, err := otherMaker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+}
+
+func TestJWTPublicMakerAlgNone(t *testing.T) {
+	keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": randomEd25519Key(t)}, nil)
+	require.NoError(t, err)
+
+	maker, err := NewJWTPublicMaker(keys)
+	require.NoError(t, err)
+
+	payload, err := NewPayload(randomAccessClaims(), time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken.Header["kid"] = "key1"
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Nil(t, payload)
+}
@@ -0,0 +1,158 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoSigningKey  = errors.New("no signing key")
+	ErrUnknownKeyID  = errors.New("unknown key id")
+	minRSAKeySize    = 2048
+	publicKeySuffix  = ".pub"
+	keyFileExtension = ".pem"
+)
+
+// KeySet holds the public keys tokens are verified with and, optionally, the
+// private key new tokens are signed with. Keys are identified by a key ID that
+// is written into every token, so a key can be rotated by adding a new one,
+// signing with it, and removing the old one once its tokens have expired.
+type KeySet struct {
+	signingKeyID string
+	signingKey   crypto.Signer
+	publicKeys   map[string]crypto.PublicKey
+}
+
+// NewKeySet creates a key set from private and public keys indexed by key ID.
+// The public key of each private key is added automatically. signingKeyID may
+// be empty for a key set that only verifies tokens.
+func NewKeySet(signingKeyID string, privateKeys map[string]crypto.Signer, publicKeys map[string]crypto.PublicKey) (*KeySet, error) {
+	keys := &KeySet{
+		signingKeyID: signingKeyID,
+		publicKeys:   make(map[string]crypto.PublicKey),
+	}
+
+	for id, publicKey := range publicKeys {
+		if err := validateKey(publicKey); err != nil {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+		keys.publicKeys[id] = publicKey
+	}
+
+	for id, privateKey := range privateKeys {
+		if err := validateKey(privateKey.Public()); err != nil {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+		keys.publicKeys[id] = privateKey.Public()
+	}
+
+	if signingKeyID != "" {
+		signingKey, ok := privateKeys[signingKeyID]
+		if !ok {
+			return nil, fmt.Errorf("%w: no private key with id %s", ErrNoSigningKey, signingKeyID)
+		}
+		keys.signingKey = signingKey
+	}
+
+	return keys, nil
+}
+
+// LoadKeySet loads every PEM file in dir. A file named <id>.pem holds a PKCS #8
+// private key and a file named <id>.pub.pem holds a PKIX public key.
+func LoadKeySet(dir string, signingKeyID string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeys := make(map[string]crypto.Signer)
+	publicKeys := make(map[string]crypto.PublicKey)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%s: no PEM data found", file)
+		}
+
+		id := strings.TrimSuffix(filepath.Base(file), keyFileExtension)
+
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("%s: unsupported private key type %T", file, key)
+			}
+			privateKeys[id] = signer
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			publicKeys[strings.TrimSuffix(id, publicKeySuffix)] = key
+		default:
+			return nil, fmt.Errorf("%s: unsupported PEM block type %s", file, block.Type)
+		}
+	}
+
+	return NewKeySet(signingKeyID, privateKeys, publicKeys)
+}
+
+// SigningKey returns the key new tokens are signed with and its ID.
+func (keys *KeySet) SigningKey() (string, crypto.Signer, error) {
+	if keys.signingKey == nil {
+		return "", nil, ErrNoSigningKey
+	}
+	return keys.signingKeyID, keys.signingKey, nil
+}
+
+// PublicKey returns the public key with the given ID.
+func (keys *KeySet) PublicKey(id string) (crypto.PublicKey, error) {
+	publicKey, ok := keys.publicKeys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, id)
+	}
+	return publicKey, nil
+}
+
+// KeyIDs returns the IDs of all public keys in ascending order.
+func (keys *KeySet) KeyIDs() []string {
+	ids := make([]string, 0, len(keys.publicKeys))
+	for id := range keys.publicKeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func validateKey(publicKey crypto.PublicKey) error {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return nil
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeySize {
+			return fmt.Errorf("RSA key must be at least %d bits", minRSAKeySize)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
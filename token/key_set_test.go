@@ -0,0 +1,84 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomEd25519Key(t *testing.T) ed25519.PrivateKey {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return privateKey
+}
+
+func randomRSAKey(t *testing.T) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return privateKey
+}
+
+func writeKeyFile(t *testing.T, dir string, name string, key any) {
+	var block *pem.Block
+
+	if privateKey, ok := key.(crypto.Signer); ok {
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	} else {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	}
+
+	err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600)
+	require.NoError(t, err)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	current := randomEd25519Key(t)
+	previous := randomEd25519Key(t)
+
+	writeKeyFile(t, dir, "current.pem", current)
+	writeKeyFile(t, dir, "previous.pub.pem", previous.Public())
+
+	keys, err := LoadKeySet(dir, "current")
+	require.NoError(t, err)
+	require.Equal(t, []string{"current", "previous"}, keys.KeyIDs())
+
+	keyID, signingKey, err := keys.SigningKey()
+	require.NoError(t, err)
+	require.Equal(t, "current", keyID)
+	require.Equal(t, current, signingKey)
+
+	publicKey, err := keys.PublicKey("previous")
+	require.NoError(t, err)
+	require.Equal(t, previous.Public(), publicKey)
+
+	_, err = keys.PublicKey("unknown")
+	require.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+func TestLoadKeySetVerifyOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyFile(t, dir, "current.pub.pem", randomEd25519Key(t).Public())
+
+	keys, err := LoadKeySet(dir, "")
+	require.NoError(t, err)
+
+	_, _, err = keys.SigningKey()
+	require.ErrorIs(t, err, ErrNoSigningKey)
+
+	_, err = LoadKeySet(dir, "current")
+	require.ErrorIs(t, err, ErrNoSigningKey)
+}
@@ -1,9 +1,52 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/starjardin/simplebank/utils"
+)
+
+const (
+	PasetoMakerType       = "paseto"
+	JWTMakerType          = "jwt"
+	PasetoPublicMakerType = "paseto_public"
+	JWTPublicMakerType    = "jwt_public"
+)
 
 type Maker interface {
-	CreateToken(username string, role string, duration time.Duration) (string, *Payload, error)
+	CreateToken(claims Claims, duration time.Duration) (string, *Payload, error)
 
 	VerifyToken(token string) (*Payload, error)
 }
+
+// PublicKeyMaker is a Maker whose tokens can be verified with public keys.
+type PublicKeyMaker interface {
+	Maker
+
+	KeySet() *KeySet
+}
+
+// NewMaker creates the maker selected by config.TokenMakerType. Symmetric
+// makers use config.TokenSymetricKey and public-key makers load their keys
+// from config.TokenKeysDir.
+func NewMaker(config utils.Config) (Maker, error) {
+	switch config.TokenMakerType {
+	case "", PasetoMakerType:
+		return NewPasetoMaker(config.TokenSymetricKey)
+	case JWTMakerType:
+		return NewJWTMaker(config.TokenSymetricKey)
+	case PasetoPublicMakerType, JWTPublicMakerType:
+		keys, err := LoadKeySet(config.TokenKeysDir, config.TokenSigningKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load token keys: %w", err)
+		}
+
+		if config.TokenMakerType == PasetoPublicMakerType {
+			return NewPasetoPublicMaker(keys)
+		}
+		return NewJWTPublicMaker(keys)
+	default:
+		return nil, fmt.Errorf("unsupported token maker type %s", config.TokenMakerType)
+	}
+}
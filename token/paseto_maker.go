@@ -27,9 +27,8 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	return maker, nil
 }
 
-func (maker *PasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
-	fmt.Println(role, "Here is the new payload")
+func (maker *PasetoMaker) CreateToken(claims Claims, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(claims, duration)
 	if err != nil {
 		return "", nil, err
 	}
@@ -21,7 +21,7 @@ func PasetoMakerTest(t *testing.T) {
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
-	token, payload, err := maker.CreateToken(username, role, duration)
+	token, payload, err := maker.CreateToken(Claims{Username: username, Role: role, Type: TypeAccess}, duration)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
@@ -33,6 +33,7 @@ func PasetoMakerTest(t *testing.T) {
 	require.NotZero(t, payload.ID)
 	require.Equal(t, username, payload.Username)
 	require.Equal(t, role, payload.Role)
+	require.Equal(t, TypeAccess, payload.Type)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
@@ -47,7 +48,7 @@ func TestExpiredPasetoToken(t *testing.T) {
 	username := utils.RandomOwner()
 	duration := time.Minute
 
-	token, payload, err := maker.CreateToken(username, role, -duration)
+	token, payload, err := maker.CreateToken(Claims{Username: username, Role: role, Type: TypeAccess}, -duration)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
@@ -0,0 +1,118 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// keyFooter is the footer of public tokens. It names the key the token was
+// signed with.
+type keyFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoPublicMaker signs v4.public PASETO tokens with Ed25519 keys, so
+// services only need the public keys to verify them.
+type PasetoPublicMaker struct {
+	keys       *KeySet
+	publicKeys map[string]paseto.V4AsymmetricPublicKey
+}
+
+func NewPasetoPublicMaker(keys *KeySet) (Maker, error) {
+	maker := &PasetoPublicMaker{
+		keys:       keys,
+		publicKeys: make(map[string]paseto.V4AsymmetricPublicKey),
+	}
+
+	for _, id := range keys.KeyIDs() {
+		publicKey, _ := keys.PublicKey(id)
+
+		edKey, ok := publicKey.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("key %s: PASETO v4.public requires an Ed25519 key", id)
+		}
+
+		maker.publicKeys[id], _ = paseto.NewV4AsymmetricPublicKeyFromEd25519(edKey)
+	}
+
+	return maker, nil
+}
+
+func (maker *PasetoPublicMaker) KeySet() *KeySet {
+	return maker.keys
+}
+
+func (maker *PasetoPublicMaker) CreateToken(claims Claims, duration time.Duration) (string, *Payload, error) {
+	keyID, signingKey, err := maker.keys.SigningKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	secretKey, err := paseto.NewV4AsymmetricSecretKeyFromEd25519(signingKey.(ed25519.PrivateKey))
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload, err := NewPayload(claims, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	claimsJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	footer, err := json.Marshal(keyFooter{KeyID: keyID})
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := paseto.NewTokenFromClaimsJSON(claimsJSON, footer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token.V4Sign(secretKey, nil), payload, nil
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	parser := paseto.NewParserWithoutExpiryCheck()
+
+	rawFooter, err := parser.UnsafeParseFooter(paseto.V4Public, token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var footer keyFooter
+	if err := json.Unmarshal(rawFooter, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := maker.publicKeys[footer.KeyID]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	parsed, err := parser.ParseV4Public(publicKey, token, nil)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(parsed.ClaimsJSON(), payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
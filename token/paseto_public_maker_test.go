@@ -0,0 +1,95 @@
+package token
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoPublicMaker(t *testing.T) {
+	keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": randomEd25519Key(t)}, nil)
+	require.NoError(t, err)
+
+	maker, err := NewPasetoPublicMaker(keys)
+	require.NoError(t, err)
+
+	username := utils.RandomOwner()
+	role := utils.DepositorRole
+	duration := time.Minute
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(Claims{Username: username, Role: role, Type: TypeAccess}, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, role, payload.Role)
+	require.Equal(t, TypeAccess, payload.Type)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestPasetoPublicMakerKeyRotation(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+	newKey := randomEd25519Key(t)
+
+	oldKeys, err := NewKeySet("old", map[string]crypto.Signer{"old": oldKey}, nil)
+	require.NoError(t, err)
+
+	oldMaker, err := NewPasetoPublicMaker(oldKeys)
+	require.NoError(t, err)
+
+	token, _, err := oldMaker.CreateToken(Claims{Username: utils.RandomOwner(), Role: utils.DepositorRole, Type: TypeAccess}, time.Minute)
+	require.NoError(t, err)
+
+	// Signing with the new key still accepts tokens signed with the old one.
+	rotatedKeys, err := NewKeySet("new", map[string]crypto.Signer{"new": newKey}, map[string]crypto.PublicKey{"old": oldKey.Public()})
+	require.NoError(t, err)
+
+	rotatedMaker, err := NewPasetoPublicMaker(rotatedKeys)
+	require.NoError(t, err)
+
+	_, err = rotatedMaker.VerifyToken(token)
+	require.NoError(t, err)
+
+	// Once the old key is removed its tokens are rejected.
+	newKeys, err := NewKeySet("new", map[string]crypto.Signer{"new": newKey}, nil)
+	require.NoError(t, err)
+
+	newMaker, err := NewPasetoPublicMaker(newKeys)
+	require.NoError(t, err)
+
+	_, err = newMaker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": randomEd25519Key(t)}, nil)
+	require.NoError(t, err)
+
+	maker, err := NewPasetoPublicMaker(keys)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(Claims{Username: utils.RandomOwner(), Role: utils.DepositorRole, Type: TypeAccess}, -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicMakerRejectsRSAKey(t *testing.T) {
+	keys, err := NewKeySet("key1", map[string]crypto.Signer{"key1": randomRSAKey(t)}, nil)
+	require.NoError(t, err)
+
+	_, err = NewPasetoPublicMaker(keys)
+	require.Error(t, err)
+}
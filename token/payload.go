@@ -9,15 +9,36 @@ import (
 
 var ErrExpiredToken = errors.New("token has expired")
 
+// Token types, carried in the typ claim so that a token can only be used for
+// what it was issued for.
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+	// TypeTwoFactorChallenge tokens only prove the password step of a login.
+	TypeTwoFactorChallenge = "two_factor_challenge"
+)
+
+// Claims are what a new token says about its holder.
+type Claims struct {
+	Username string
+	Role     string
+	Type     string
+	// SessionID is the family of the login session an access token was
+	// issued with, so that revoking the session revokes the token.
+	SessionID uuid.UUID
+}
+
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
 	IssuedAt  time.Time `json:"iat"`
 	ExpiredAt time.Time `json:"exp"`
 	Role      string    `json:"role,omitempty"`
+	Type      string    `json:"typ"`
+	SessionID uuid.UUID `json:"sid"`
 }
 
-func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
+func NewPayload(claims Claims, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -25,9 +46,11 @@ func NewPayload(username string, role string, duration time.Duration) (*Payload,
 
 	payload := &Payload{
 		ID:        tokenID,
-		Username:  username,
+		Username:  claims.Username,
 		IssuedAt:  time.Now(),
-		Role:      role,
+		Role:      claims.Role,
+		Type:      claims.Type,
+		SessionID: claims.SessionID,
 		ExpiredAt: time.Now().Add(duration),
 	}
 
@@ -0,0 +1,251 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrRevokedToken = errors.New("token has been revoked")
+
+// maxCachedEntries bounds each cache; expired entries are swept once it is
+// reached.
+const maxCachedEntries = 10000
+
+// RevocationStore looks up revoked token ids, blocked login sessions and the
+// time a user last changed their password or was frozen. db.Store implements
+// it.
+type RevocationStore interface {
+	IsTokenRevoked(ctx context.Context, id uuid.UUID) (bool, error)
+	IsSessionFamilyBlocked(ctx context.Context, familyID uuid.UUID) (bool, error)
+	GetUserPasswordChangeAt(ctx context.Context, username string) (time.Time, error)
+}
+
+type cachedRevocation struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+type cachedWatermark struct {
+	changedAt time.Time
+	expiresAt time.Time
+}
+
+// RevocationList rejects tokens that were revoked by id, belong to a blocked
+// session or were issued before the user's last password change, freeze or
+// role change. Lookups are cached for the given duration, so a revocation made
+// by another process can take that long to be seen.
+type RevocationList struct {
+	store    RevocationStore
+	duration time.Duration
+
+	mu         sync.Mutex
+	tokens     map[uuid.UUID]cachedRevocation
+	sessions   map[uuid.UUID]cachedRevocation
+	watermarks map[string]cachedWatermark
+}
+
+func NewRevocationList(store RevocationStore, duration time.Duration) *RevocationList {
+	return &RevocationList{
+		store:      store,
+		duration:   duration,
+		tokens:     make(map[uuid.UUID]cachedRevocation),
+		sessions:   make(map[uuid.UUID]cachedRevocation),
+		watermarks: make(map[string]cachedWatermark),
+	}
+}
+
+// Check returns ErrRevokedToken if the token must no longer be accepted.
+func (list *RevocationList) Check(ctx context.Context, payload *Payload) error {
+	revoked, err := list.isRevoked(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return ErrRevokedToken
+	}
+
+	if payload.SessionID != uuid.Nil {
+		blocked, err := list.isSessionBlocked(ctx, payload)
+		if err != nil {
+			return err
+		}
+
+		if blocked {
+			return ErrRevokedToken
+		}
+	}
+
+	changedAt, err := list.passwordChangeAt(ctx, payload.Username)
+	if err != nil {
+		return err
+	}
+
+	if payload.IssuedAt.Before(changedAt) {
+		return ErrRevokedToken
+	}
+
+	return nil
+}
+
+// Revoked records that the token was revoked so this process rejects it
+// without waiting for the cache to expire.
+func (list *RevocationList) Revoked(payload *Payload) {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	list.sweep(time.Now())
+	list.tokens[payload.ID] = cachedRevocation{
+		revoked:   true,
+		expiresAt: payload.ExpiredAt,
+	}
+}
+
+// SessionBlocked records that a session family was blocked so this process
+// rejects its tokens without waiting for the cache to expire.
+func (list *RevocationList) SessionBlocked(familyID uuid.UUID) {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	now := time.Now()
+	list.sweep(now)
+	list.sessions[familyID] = cachedRevocation{
+		revoked:   true,
+		expiresAt: now.Add(list.duration),
+	}
+}
+
+// UserChanged drops the cached watermark of the user so this process sees
+// the new one on the next check, after a password change or a freeze.
+func (list *RevocationList) UserChanged(username string) {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	delete(list.watermarks, username)
+}
+
+func (list *RevocationList) isRevoked(ctx context.Context, payload *Payload) (bool, error) {
+	now := time.Now()
+
+	list.mu.Lock()
+	cached, ok := list.tokens[payload.ID]
+	list.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.revoked, nil
+	}
+
+	revoked, err := list.store.IsTokenRevoked(ctx, payload.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	// A revoked token stays revoked, so only a negative answer needs to be
+	// looked up again.
+	expiresAt := payload.ExpiredAt
+	if !revoked && now.Add(list.duration).Before(expiresAt) {
+		expiresAt = now.Add(list.duration)
+	}
+
+	list.mu.Lock()
+	list.sweep(now)
+	list.tokens[payload.ID] = cachedRevocation{
+		revoked:   revoked,
+		expiresAt: expiresAt,
+	}
+	list.mu.Unlock()
+
+	return revoked, nil
+}
+
+func (list *RevocationList) isSessionBlocked(ctx context.Context, payload *Payload) (bool, error) {
+	now := time.Now()
+
+	list.mu.Lock()
+	cached, ok := list.sessions[payload.SessionID]
+	list.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.revoked, nil
+	}
+
+	blocked, err := list.store.IsSessionFamilyBlocked(ctx, payload.SessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+
+	// A blocked session stays blocked for at least as long as the token.
+	expiresAt := payload.ExpiredAt
+	if !blocked && now.Add(list.duration).Before(expiresAt) {
+		expiresAt = now.Add(list.duration)
+	}
+
+	list.mu.Lock()
+	list.sweep(now)
+	list.sessions[payload.SessionID] = cachedRevocation{
+		revoked:   blocked,
+		expiresAt: expiresAt,
+	}
+	list.mu.Unlock()
+
+	return blocked, nil
+}
+
+func (list *RevocationList) passwordChangeAt(ctx context.Context, username string) (time.Time, error) {
+	now := time.Now()
+
+	list.mu.Lock()
+	cached, ok := list.watermarks[username]
+	list.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.changedAt, nil
+	}
+
+	changedAt, err := list.store.GetUserPasswordChangeAt(ctx, username)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get password change time: %w", err)
+	}
+
+	list.mu.Lock()
+	list.sweep(now)
+	list.watermarks[username] = cachedWatermark{
+		changedAt: changedAt,
+		expiresAt: now.Add(list.duration),
+	}
+	list.mu.Unlock()
+
+	return changedAt, nil
+}
+
+// sweep drops expired entries once a cache is full. The caller holds mu.
+func (list *RevocationList) sweep(now time.Time) {
+	if len(list.tokens) >= maxCachedEntries {
+		for id, cached := range list.tokens {
+			if !now.Before(cached.expiresAt) {
+				delete(list.tokens, id)
+			}
+		}
+	}
+
+	if len(list.sessions) >= maxCachedEntries {
+		for id, cached := range list.sessions {
+			if !now.Before(cached.expiresAt) {
+				delete(list.sessions, id)
+			}
+		}
+	}
+
+	if len(list.watermarks) >= maxCachedEntries {
+		for username, cached := range list.watermarks {
+			if !now.Before(cached.expiresAt) {
+				delete(list.watermarks, username)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/starjardin/simplebank/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRevocationStore struct {
+	revoked   map[uuid.UUID]bool
+	blocked   map[uuid.UUID]bool
+	changedAt map[string]time.Time
+	lookups   int
+}
+
+func (store *fakeRevocationStore) IsTokenRevoked(ctx context.Context, id uuid.UUID) (bool, error) {
+	store.lookups++
+	return store.revoked[id], nil
+}
+
+func (store *fakeRevocationStore) IsSessionFamilyBlocked(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	store.lookups++
+	return store.blocked[familyID], nil
+}
+
+func (store *fakeRevocationStore) GetUserPasswordChangeAt(ctx context.Context, username string) (time.Time, error) {
+	store.lookups++
+	return store.changedAt[username], nil
+}
+
+func newFakeRevocationStore() *fakeRevocationStore {
+	return &fakeRevocationStore{
+		revoked:   make(map[uuid.UUID]bool),
+		blocked:   make(map[uuid.UUID]bool),
+		changedAt: make(map[string]time.Time),
+	}
+}
+
+func randomAccessClaims() Claims {
+	return Claims{
+		Username: utils.RandomOwner(),
+		Role:     utils.DepositorRole,
+		Type:     TypeAccess,
+	}
+}
+
+func TestRevocationListCheck(t *testing.T) {
+	store := newFakeRevocationStore()
+	list := NewRevocationList(store, time.Minute)
+
+	payload, err := NewPayload(randomAccessClaims(), time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, list.Check(context.Background(), payload))
+	require.Equal(t, 2, store.lookups)
+
+	// Both answers are cached.
+	require.NoError(t, list.Check(context.Background(), payload))
+	require.Equal(t, 2, store.lookups)
+
+	list.Revoked(payload)
+	require.ErrorIs(t, list.Check(context.Background(), payload), ErrRevokedToken)
+}
+
+func TestRevocationListRevokedInStore(t *testing.T) {
+	store := newFakeRevocationStore()
+	list := NewRevocationList(store, time.Minute)
+
+	payload, err := NewPayload(randomAccessClaims(), time.Minute)
+	require.NoError(t, err)
+
+	store.revoked[payload.ID] = true
+	require.ErrorIs(t, list.Check(context.Background(), payload), ErrRevokedToken)
+}
+
+func TestRevocationListUserChanged(t *testing.T) {
+	store := newFakeRevocationStore()
+	list := NewRevocationList(store, time.Minute)
+
+	payload, err := NewPayload(randomAccessClaims(), time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, list.Check(context.Background(), payload))
+
+	store.changedAt[payload.Username] = time.Now()
+
+	// The cached watermark is still used until it is dropped.
+	require.NoError(t, list.Check(context.Background(), payload))
+
+	list.UserChanged(payload.Username)
+	require.ErrorIs(t, list.Check(context.Background(), payload), ErrRevokedToken)
+
+	newPayload, err := NewPayload(Claims{
+		Username: payload.Username,
+		Role:     utils.DepositorRole,
+		Type:     TypeAccess,
+	}, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, list.Check(context.Background(), newPayload))
+}
+
+func TestRevocationListSessionBlocked(t *testing.T) {
+	store := newFakeRevocationStore()
+	list := NewRevocationList(store, time.Minute)
+
+	claims := randomAccessClaims()
+	claims.SessionID = uuid.New()
+
+	payload, err := NewPayload(claims, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, list.Check(context.Background(), payload))
+	require.Equal(t, 3, store.lookups)
+
+	store.blocked[claims.SessionID] = true
+
+	// The cached answer is still used until the block is recorded.
+	require.NoError(t, list.Check(context.Background(), payload))
+
+	list.SessionBlocked(claims.SessionID)
+	require.ErrorIs(t, list.Check(context.Background(), payload), ErrRevokedToken)
+
+	otherSession, err := NewPayload(randomAccessClaims(), time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, list.Check(context.Background(), otherSession))
+}
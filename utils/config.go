@@ -7,11 +7,16 @@ import (
 )
 
 type Config struct {
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	TokenSymetricKey     string        `mapstructure:"TOKEN_SYMETRIC_KEY"`
+	DBSource          string `mapstructure:"DB_SOURCE"`
+	MigrationURL      string `mapstructure:"MIGRATION_URL"`
+	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress string `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymetricKey  string `mapstructure:"TOKEN_SYMETRIC_KEY"`
+	// TokenMakerType is one of paseto, jwt, paseto_public or jwt_public. The
+	// public-key makers sign with TokenSigningKeyID from TokenKeysDir.
+	TokenMakerType       string        `mapstructure:"TOKEN_MAKER_TYPE"`
+	TokenKeysDir         string        `mapstructure:"TOKEN_KEYS_DIR"`
+	TokenSigningKeyID    string        `mapstructure:"TOKEN_SIGNING_KEY_ID"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 	Environment          string        `mapstructure:"ENVIRONMENT"`
@@ -19,6 +24,36 @@ type Config struct {
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	FXQuoteDuration      time.Duration `mapstructure:"FX_QUOTE_DURATION"`
+	// RevocationCacheDuration is how long token revocation lookups are cached.
+	RevocationCacheDuration time.Duration `mapstructure:"REVOCATION_CACHE_DURATION"`
+	// TwoFactorChallengeDuration is how long the second step of a login may
+	// take.
+	TwoFactorChallengeDuration time.Duration `mapstructure:"TWO_FACTOR_CHALLENGE_DURATION"`
+	// TwoFactorTransferThreshold is the amount above which transfers need a
+	// TOTP code. Zero disables the check.
+	TwoFactorTransferThreshold int64 `mapstructure:"TWO_FACTOR_TRANSFER_THRESHOLD"`
+	// LoginMaxAttempts is the number of failed logins after which a username
+	// is locked for LoginLockoutDuration. Client IPs get four times as many.
+	LoginMaxAttempts     int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginLockoutDuration time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	// PasswordMinLength and PasswordMinCharacterClasses tighten the rules for
+	// new passwords. Zero keeps the 3 character minimum.
+	PasswordMinLength           int `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMinCharacterClasses int `mapstructure:"PASSWORD_MIN_CHARACTER_CLASSES"`
+	// PasswordHistorySize is how many of the last passwords, the current one
+	// included, cannot be reused. Zero disables the check.
+	PasswordHistorySize int `mapstructure:"PASSWORD_HISTORY_SIZE"`
+	// BreachedPasswordsFile is a list of SHA-1 hashes of breached passwords.
+	// The list bundled with the binary is used when it is empty.
+	BreachedPasswordsFile string `mapstructure:"BREACHED_PASSWORDS_FILE"`
+	// PasswordHashAlgorithm is bcrypt or argon2id. Hashes made with another
+	// algorithm or other parameters are upgraded on the next login.
+	PasswordHashAlgorithm   string `mapstructure:"PASSWORD_HASH_ALGORITHM"`
+	PasswordBcryptCost      int    `mapstructure:"PASSWORD_BCRYPT_COST"`
+	PasswordArgon2Time      uint32 `mapstructure:"PASSWORD_ARGON2_TIME"`
+	PasswordArgon2MemoryKiB uint32 `mapstructure:"PASSWORD_ARGON2_MEMORY_KIB"`
+	PasswordArgon2Threads   uint8  `mapstructure:"PASSWORD_ARGON2_THREADS"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
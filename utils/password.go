@@ -1,15 +1,187 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	BcryptAlgorithm   = "bcrypt"
+	Argon2idAlgorithm = "argon2id"
+
+	defaultArgon2Time      = 3
+	defaultArgon2MemoryKiB = 64 * 1024
+	defaultArgon2Threads   = 4
+	argon2KeyLength        = 32
+	argon2SaltLength       = 16
+)
+
+// ErrMismatchedPassword is returned by CheckPassword for a wrong password,
+// whatever the algorithm of the hash.
+var ErrMismatchedPassword = bcrypt.ErrMismatchedHashAndPassword
+
+var ErrUnknownPasswordHash = errors.New("unknown password hash format")
+
+// PasswordHasher hashes new passwords with the configured algorithm. The
+// algorithm and its parameters are encoded in the hash, so CheckPassword can
+// verify hashes from any hasher.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// NeedsRehash reports whether hashedPassword was made with another
+	// algorithm or other parameters than the ones of the hasher.
+	NeedsRehash(hashedPassword string) bool
+}
+
+// NewPasswordHasher returns the hasher selected by the config. Bcrypt with the
+// default cost is used when no algorithm is set.
+func NewPasswordHasher(config Config) (PasswordHasher, error) {
+	switch config.PasswordHashAlgorithm {
+	case "", BcryptAlgorithm:
+		cost := config.PasswordBcryptCost
+		if cost == 0 {
+			cost = bcrypt.DefaultCost
+		}
+
+		if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be from %d-%d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+
+		return BcryptHasher{Cost: cost}, nil
+	case Argon2idAlgorithm:
+		hasher := Argon2idHasher{
+			Time:      config.PasswordArgon2Time,
+			MemoryKiB: config.PasswordArgon2MemoryKiB,
+			Threads:   config.PasswordArgon2Threads,
+		}
+
+		if hasher.Time == 0 {
+			hasher.Time = defaultArgon2Time
+		}
+		if hasher.MemoryKiB == 0 {
+			hasher.MemoryKiB = defaultArgon2MemoryKiB
+		}
+		if hasher.Threads == 0 {
+			hasher.Threads = defaultArgon2Threads
+		}
+
+		return hasher, nil
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", config.PasswordHashAlgorithm)
+	}
+}
 
 func HashedPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return BcryptHasher{Cost: bcrypt.DefaultCost}.Hash(password)
+}
+
+// CheckPassword checks password against a hash from any supported algorithm.
+func CheckPassword(password, hashedPassword string) error {
+	if strings.HasPrefix(hashedPassword, "$"+Argon2idAlgorithm+"$") {
+		return checkArgon2id(password, hashedPassword)
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
+
+type BcryptHasher struct {
+	Cost int
+}
+
+func (hasher BcryptHasher) Hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), hasher.Cost)
 	if err != nil {
 		return "", err
 	}
 	return string(hashedPassword), nil
 }
 
-func CheckPassword(password, hashedPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+func (hasher BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != hasher.Cost
+}
+
+// Argon2idHasher hashes passwords with argon2id and encodes them in the PHC
+// string format, e.g. $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>.
+type Argon2idHasher struct {
+	Time      uint32
+	MemoryKiB uint32
+	Threads   uint8
+}
+
+func (hasher Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, hasher.Time, hasher.MemoryKiB, hasher.Threads, argon2KeyLength)
+
+	return encodeArgon2id(hasher, salt, key), nil
+}
+
+func (hasher Argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	params, _, key, err := decodeArgon2id(hashedPassword)
+	return err != nil || params != hasher || len(key) != argon2KeyLength
+}
+
+func encodeArgon2id(params Argon2idHasher, salt []byte, key []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2idAlgorithm,
+		argon2.Version,
+		params.MemoryKiB,
+		params.Time,
+		params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeArgon2id(hashedPassword string) (params Argon2idHasher, salt []byte, key []byte, err error) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != Argon2idAlgorithm {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.MemoryKiB, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	return params, salt, key, nil
+}
+
+func checkArgon2id(password, hashedPassword string) error {
+	params, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Time, params.MemoryKiB, params.Threads, uint32(len(key)))
+
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatchedPassword
+	}
+
+	return nil
 }
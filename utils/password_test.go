@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -28,3 +29,49 @@ func TestPassword(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEqual(t, hashedPassword1, hashedPassword2)
 }
+
+func TestArgon2idPassword(t *testing.T) {
+	hasher := Argon2idHasher{Time: 1, MemoryKiB: 1024, Threads: 1}
+
+	password := RandomString(6)
+	hashedPassword, err := hasher.Hash(password)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	require.NoError(t, CheckPassword(password, hashedPassword))
+	require.ErrorIs(t, CheckPassword(RandomString(6), hashedPassword), ErrMismatchedPassword)
+	require.ErrorIs(t, CheckPassword(password, "$argon2id$v=19$broken"), ErrUnknownPasswordHash)
+
+	require.False(t, hasher.NeedsRehash(hashedPassword))
+	require.True(t, Argon2idHasher{Time: 2, MemoryKiB: 1024, Threads: 1}.NeedsRehash(hashedPassword))
+}
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	bcryptHash, err := BcryptHasher{Cost: bcrypt.MinCost}.Hash(RandomString(6))
+	require.NoError(t, err)
+
+	argon2Hasher := Argon2idHasher{Time: 1, MemoryKiB: 1024, Threads: 1}
+	argon2Hash, err := argon2Hasher.Hash(RandomString(6))
+	require.NoError(t, err)
+
+	require.False(t, BcryptHasher{Cost: bcrypt.MinCost}.NeedsRehash(bcryptHash))
+	require.True(t, BcryptHasher{Cost: bcrypt.MinCost + 1}.NeedsRehash(bcryptHash))
+	require.True(t, BcryptHasher{Cost: bcrypt.MinCost}.NeedsRehash(argon2Hash))
+	require.True(t, argon2Hasher.NeedsRehash(bcryptHash))
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	hasher, err := NewPasswordHasher(Config{})
+	require.NoError(t, err)
+	require.Equal(t, BcryptHasher{Cost: bcrypt.DefaultCost}, hasher)
+
+	hasher, err = NewPasswordHasher(Config{PasswordHashAlgorithm: Argon2idAlgorithm})
+	require.NoError(t, err)
+	require.Equal(t, Argon2idHasher{Time: defaultArgon2Time, MemoryKiB: defaultArgon2MemoryKiB, Threads: defaultArgon2Threads}, hasher)
+
+	_, err = NewPasswordHasher(Config{PasswordHashAlgorithm: "md5"})
+	require.Error(t, err)
+
+	_, err = NewPasswordHasher(Config{PasswordBcryptCost: 100})
+	require.Error(t, err)
+}
@@ -1,6 +1,107 @@
 package utils
 
+import (
+	"slices"
+	"sort"
+	"sync"
+)
+
 const (
 	DepositorRole = "depositor"
 	BankerRole    = "banker"
+	AdminRole     = "admin"
+	// TwoFactorChallengeRole is carried by the short-lived token issued after
+	// the password step of a login. It has no permissions.
+	TwoFactorChallengeRole = "two_factor_challenge"
+)
+
+// Permissions are named resource:action:scope. An own scope covers the
+// resources of the caller, an any scope those of every user.
+const (
+	PermissionAccountsCreateOwn           = "accounts:create:own"
+	PermissionAccountsReadOwn             = "accounts:read:own"
+	PermissionAccountsReadAny             = "accounts:read:any"
+	PermissionAccountsDepositAny          = "accounts:deposit:any"
+	PermissionAccountsWithdrawAny         = "accounts:withdraw:any"
+	PermissionAccountsFreezeAny           = "accounts:freeze:any"
+	PermissionAccountsReactivateAny       = "accounts:reactivate:any"
+	PermissionAccountsCloseOwn            = "accounts:close:own"
+	PermissionAccountsCloseAny            = "accounts:close:any"
+	PermissionAccountsAdjustAny           = "accounts:adjust:any"
+	PermissionAccountsChargeFeeAny        = "accounts:charge_fee:any"
+	PermissionTransfersCreateOwn          = "transfers:create:own"
+	PermissionTransfersReadOwn            = "transfers:read:own"
+	PermissionTransfersReadAny            = "transfers:read:any"
+	PermissionTransferLimitsReadAny       = "transfer_limits:read:any"
+	PermissionTransferLimitsWriteAny      = "transfer_limits:write:any"
+	PermissionScheduledTransfersManageOwn = "scheduled_transfers:manage:own"
+	PermissionStatementsReadOwn           = "statements:read:own"
+	PermissionStatementsReadAny           = "statements:read:any"
+	PermissionExchangeRatesWriteAny       = "exchange_rates:write:any"
+	PermissionSessionsManageOwn           = "sessions:manage:own"
+	PermissionTwoFactorManageOwn          = "two_factor:manage:own"
+	PermissionUsersUpdateOwn              = "users:update:own"
+	PermissionUsersUpdateAny              = "users:update:any"
+	PermissionUsersReadAny                = "users:read:any"
+	PermissionUsersFreezeAny              = "users:freeze:any"
+	PermissionAuditEventsReadAny          = "audit_events:read:any"
+	PermissionLedgerVerifyAny             = "ledger:verify:any"
+	PermissionRolesReadAny                = "roles:read:any"
+	PermissionRolesAssignAny              = "roles:assign:any"
 )
+
+var roles = struct {
+	sync.RWMutex
+	permissions map[string][]string
+}{}
+
+// LoadRolePermissions replaces the roles and their permissions with those kept
+// in the role_permissions table. Until they are loaded no role exists.
+func LoadRolePermissions(permissions map[string][]string) {
+	loaded := make(map[string][]string, len(permissions))
+	for role, rolePermissions := range permissions {
+		loaded[role] = slices.Clone(rolePermissions)
+	}
+
+	roles.Lock()
+	defer roles.Unlock()
+
+	roles.permissions = loaded
+}
+
+// Roles returns the roles that can be assigned to a user, sorted by name.
+func Roles() []string {
+	roles.RLock()
+	defer roles.RUnlock()
+
+	names := make([]string, 0, len(roles.permissions))
+	for role := range roles.permissions {
+		names = append(names, role)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func IsSupportedRole(role string) bool {
+	roles.RLock()
+	defer roles.RUnlock()
+
+	_, ok := roles.permissions[role]
+	return ok
+}
+
+// RolePermissions returns the permissions of role, or none for an unknown
+// role.
+func RolePermissions(role string) []string {
+	roles.RLock()
+	defer roles.RUnlock()
+
+	return slices.Clone(roles.permissions[role])
+}
+
+func HasPermission(role string, permission string) bool {
+	roles.RLock()
+	defer roles.RUnlock()
+
+	return slices.Contains(roles.permissions[role], permission)
+}
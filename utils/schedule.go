@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ParseSchedule returns the recurrence described by either a standard
+// five-field cron expression or a fixed interval in seconds.
+func ParseSchedule(cronExpression string, intervalSeconds int64) (cron.Schedule, error) {
+	switch {
+	case cronExpression != "" && intervalSeconds != 0:
+		return nil, fmt.Errorf("cannot use both a cron expression and an interval")
+	case cronExpression != "":
+		schedule, err := cron.ParseStandard(cronExpression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression: %w", err)
+		}
+		return schedule, nil
+	case intervalSeconds > 0:
+		return cron.Every(time.Duration(intervalSeconds) * time.Second), nil
+	default:
+		return nil, fmt.Errorf("must provide a cron expression or a positive interval")
+	}
+}
+
+// NextRunAfter returns the first activation of schedule that is after now,
+// starting from the previous activation so that missed runs are skipped.
+func NextRunAfter(schedule cron.Schedule, previous, now time.Time) time.Time {
+	next := schedule.Next(previous)
+	for !next.After(now) {
+		next = schedule.Next(next)
+	}
+	return next
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	_, err := ParseSchedule("", 0)
+	require.Error(t, err)
+
+	_, err = ParseSchedule("0 9 1 * *", 3600)
+	require.Error(t, err)
+
+	_, err = ParseSchedule("not a cron", 0)
+	require.Error(t, err)
+
+	start := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.Local)
+
+	monthly, err := ParseSchedule("0 9 1 * *", 0)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 1, 9, 0, 0, 0, time.Local), monthly.Next(start))
+
+	hourly, err := ParseSchedule("", 3600)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(time.Hour), hourly.Next(start))
+}
+
+func TestNextRunAfter(t *testing.T) {
+	schedule, err := ParseSchedule("", 3600)
+	require.NoError(t, err)
+
+	previous := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.Local)
+	now := previous.Add(150 * time.Minute)
+
+	require.Equal(t, previous.Add(3*time.Hour), NextRunAfter(schedule, previous, now))
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+)
+
+// secretCodeBytes gives 160 bits of entropy, encoded as 32 characters.
+const secretCodeBytes = 20
+
+// NewSecretCode generates a code that proves control of an email address,
+// such as a password reset code.
+func NewSecretCode() (string, error) {
+	b := make([]byte, secretCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)), nil
+}
+
+// HashSecretCode returns the hash a secret code is stored as. Codes are
+// random enough that a fast hash is sufficient.
+func HashSecretCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
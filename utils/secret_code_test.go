@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecretCode(t *testing.T) {
+	code1, err := NewSecretCode()
+	require.NoError(t, err)
+	require.Len(t, code1, 32)
+
+	code2, err := NewSecretCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code1, code2)
+
+	require.Len(t, HashSecretCode(code1), 64)
+	require.Equal(t, HashSecretCode(code1), HashSecretCode(code1))
+	require.NotEqual(t, HashSecretCode(code1), HashSecretCode(code2))
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	TOTPIssuer = "Simple Bank"
+
+	totpPeriod = 30
+	// totpSkew is the number of periods before and after the current one in
+	// which a code is still accepted, to allow for clock drift.
+	totpSkew = 1
+
+	RecoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// NewTOTPSecret generates a TOTP secret for username and the otpauth URI that
+// authenticator apps enroll it from.
+func NewTOTPSecret(username string) (secret string, uri string, err error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      TOTPIssuer,
+		AccountName: username,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return key.Secret(), key.URL(), nil
+}
+
+// TOTPCode returns the code for secret at t.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return totp.GenerateCodeCustom(secret, t, totpOpts)
+}
+
+// ValidateTOTP checks code against secret around now and returns the time
+// step it belongs to. Codes from steps up to lastStep are rejected so that a
+// code cannot be replayed.
+func ValidateTOTP(secret string, code string, now time.Time, lastStep int64) (int64, bool) {
+	currentStep := now.Unix() / totpPeriod
+
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		step := currentStep + int64(skew)
+		if step <= lastStep {
+			continue
+		}
+
+		expected, err := TOTPCode(secret, time.Unix(step*totpPeriod, 0))
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// NewRecoveryCodes generates single use codes that replace a TOTP code when
+// the authenticator is not available.
+func NewRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+
+	for i := range codes {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+		codes[i] = code[:8] + "-" + code[8:]
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash a recovery code is stored as. Codes are
+// random enough that a fast hash is sufficient.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTOTP(t *testing.T) {
+	secret, uri, err := NewTOTPSecret("alice")
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/"))
+	require.Contains(t, uri, "secret="+secret)
+
+	now := time.Now()
+
+	code, err := TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := ValidateTOTP(secret, code, now, 0)
+	require.True(t, ok)
+	require.Equal(t, now.Unix()/totpPeriod, step)
+
+	// A code from the previous period is still accepted.
+	_, ok = ValidateTOTP(secret, code, now.Add(totpPeriod*time.Second), 0)
+	require.True(t, ok)
+
+	// The same code cannot be used twice.
+	_, ok = ValidateTOTP(secret, code, now, step)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, code, now.Add(3*totpPeriod*time.Second), 0)
+	require.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "000000x", now, 0)
+	require.False(t, ok)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := NewRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.Len(t, code, 17)
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+
+	code := codes[0]
+	require.Equal(t, HashRecoveryCode(code), HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))+" "))
+	require.NotEqual(t, HashRecoveryCode(code), HashRecoveryCode(codes[1]))
+}
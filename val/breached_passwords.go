@@ -0,0 +1,97 @@
+package val
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// hashPrefixLength is the length of the SHA-1 prefix hashes are grouped by, as
+// in the k-anonymity range API of Have I Been Pwned.
+const hashPrefixLength = 5
+
+//go:embed breached_passwords.txt
+var bundledBreachedPasswords []byte
+
+// BreachedPasswords is an offline list of SHA-1 hashes of breached passwords,
+// grouped by hash prefix so a password is only compared with the hashes that
+// share its prefix.
+type BreachedPasswords struct {
+	ranges map[string][]string
+}
+
+// LoadBreachedPasswords reads a hash list in the Have I Been Pwned format from
+// path, or the list bundled with the binary when path is empty.
+func LoadBreachedPasswords(path string) (*BreachedPasswords, error) {
+	if path == "" {
+		return ParseBreachedPasswords(bytes.NewReader(bundledBreachedPasswords))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open breached passwords: %w", err)
+	}
+	defer file.Close()
+
+	return ParseBreachedPasswords(file)
+}
+
+// ParseBreachedPasswords reads one hex SHA-1 hash per line, optionally followed
+// by ":count". Empty lines and lines starting with # are skipped.
+func ParseBreachedPasswords(r io.Reader) (*BreachedPasswords, error) {
+	breached := &BreachedPasswords{
+		ranges: make(map[string][]string),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("invalid breached password hash on line %d", line)
+		}
+
+		prefix := hash[:hashPrefixLength]
+		breached.ranges[prefix] = append(breached.ranges[prefix], hash[hashPrefixLength:])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read breached passwords: %w", err)
+	}
+
+	for _, suffixes := range breached.ranges {
+		sort.Strings(suffixes)
+	}
+
+	return breached, nil
+}
+
+// Range returns the sorted hash suffixes that start with prefix.
+func (breached *BreachedPasswords) Range(prefix string) []string {
+	return breached.ranges[strings.ToUpper(prefix)]
+}
+
+// Contains reports whether password is in the list.
+func (breached *BreachedPasswords) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes := breached.Range(hash[:hashPrefixLength])
+	suffix := hash[hashPrefixLength:]
+
+	i := sort.SearchStrings(suffixes, suffix)
+	return i < len(suffixes) && suffixes[i] == suffix
+}
@@ -0,0 +1,85 @@
+package val
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	minPasswordLength = 3
+	maxPasswordLength = 100
+
+	// minEmailLocalPartLength is the shortest local part of an email that
+	// passwords are checked against. Shorter ones would reject too many
+	// passwords, such as any containing "jo" for jo@example.com.
+	minEmailLocalPartLength = 3
+)
+
+// PasswordPolicy is the set of rules new passwords must follow. The zero
+// value only checks the length bounds and that the password does not contain
+// the username or email.
+type PasswordPolicy struct {
+	MinLength int
+	// MinCharacterClasses is how many of lowercase letters, uppercase letters,
+	// digits and symbols the password must contain.
+	MinCharacterClasses int
+	// Breached rejects known breached passwords when set.
+	Breached *BreachedPasswords
+}
+
+// Validate checks a new password of the user with the given username and
+// email.
+func (policy PasswordPolicy) Validate(password string, username string, email string) error {
+	minLength := max(policy.MinLength, minPasswordLength)
+
+	if err := ValidateString(password, minLength, maxPasswordLength); err != nil {
+		return err
+	}
+
+	if classes := characterClasses(password); classes < policy.MinCharacterClasses {
+		return fmt.Errorf("must contain at least %d of lowercase letters, uppercase letters, digits and symbols", policy.MinCharacterClasses)
+	}
+
+	lower := strings.ToLower(password)
+
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		return fmt.Errorf("must not contain the username")
+	}
+
+	if local, _, _ := strings.Cut(email, "@"); len(local) >= minEmailLocalPartLength && strings.Contains(lower, strings.ToLower(local)) {
+		return fmt.Errorf("must not contain the email address")
+	}
+
+	if policy.Breached != nil && policy.Breached.Contains(password) {
+		return fmt.Errorf("has appeared in a data breach, choose another one")
+	}
+
+	return nil
+}
+
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+
+	return classes
+}
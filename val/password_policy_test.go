@@ -0,0 +1,72 @@
+package val
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	breached, err := LoadBreachedPasswords("")
+	require.NoError(t, err)
+
+	policy := PasswordPolicy{
+		MinLength:           8,
+		MinCharacterClasses: 3,
+		Breached:            breached,
+	}
+
+	testCases := []struct {
+		name     string
+		password string
+		ok       bool
+	}{
+		{"OK", "Tr0mbone-Glacier", true},
+		{"TooShort", "Ab1!", false},
+		{"TooFewClasses", "onlylowercase", false},
+		{"ContainsUsername", "Xx-Alice-2024", false},
+		{"ContainsEmail", "Wonder1and!", false},
+		{"Breached", "Password1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Validate(tc.password, "alice", "wonder1and@example.com")
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	// Short email local parts are not checked.
+	require.NoError(t, PasswordPolicy{}.Validate("Ojo-Rojo", "alice", "jo@example.com"))
+	require.Error(t, PasswordPolicy{}.Validate("Ojo-Rojo", "alice", "ojo@example.com"))
+
+	// The zero policy keeps the old length rule.
+	require.NoError(t, PasswordPolicy{}.Validate("abc", "alice", "alice@example.com"))
+	require.Error(t, PasswordPolicy{}.Validate("ab", "alice", "alice@example.com"))
+}
+
+func TestBreachedPasswords(t *testing.T) {
+	breached, err := ParseBreachedPasswords(strings.NewReader(`
+# comment
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493
+7c4a8d09ca3762af61e59520943dc26494f8941b
+`))
+	require.NoError(t, err)
+
+	require.True(t, breached.Contains("password"))
+	require.True(t, breached.Contains("123456"))
+	require.False(t, breached.Contains("correct horse battery staple"))
+	require.Len(t, breached.Range("5baa6"), 1)
+
+	_, err = ParseBreachedPasswords(strings.NewReader("not-a-hash\n"))
+	require.Error(t, err)
+
+	bundled, err := LoadBreachedPasswords("")
+	require.NoError(t, err)
+	require.True(t, bundled.Contains("qwerty"))
+}
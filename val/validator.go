@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/starjardin/simplebank/utils"
 )
 
 var (
 	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidRate     = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,8})?$`).MatchString
+	isZeroRate      = regexp.MustCompile(`^0+(\.0+)?$`).MatchString
+	isValidTOTPCode = regexp.MustCompile(`^[0-9]{6}$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -34,7 +41,7 @@ func ValidateUsername(value string) error {
 }
 
 func ValidatePassword(value string) error {
-	return ValidateString(value, 3, 100)
+	return ValidateString(value, minPasswordLength, maxPasswordLength)
 }
 
 func ValidateEmail(value string) error {
@@ -75,3 +82,130 @@ func ValidateSecretCode(value string) error {
 
 	return nil
 }
+
+func ValidateID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !utils.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency")
+	}
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be at least 1")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be from 5-10")
+	}
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidateExternalReference(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidateExchangeRate(value string) error {
+	if !isValidRate(value) {
+		return fmt.Errorf("must be a decimal number with at most 8 decimal places")
+	}
+
+	if isZeroRate(value) {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateUUID(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+	return nil
+}
+
+func ValidateQuoteID(value string) error {
+	return ValidateUUID(value)
+}
+
+func ValidateSessionID(value string) error {
+	return ValidateUUID(value)
+}
+
+func ValidateSchedule(cronExpression string, intervalSeconds int64) error {
+	if intervalSeconds != 0 && intervalSeconds < 60 {
+		return fmt.Errorf("interval must be at least 60 seconds")
+	}
+
+	_, err := utils.ParseSchedule(cronExpression, intervalSeconds)
+	return err
+}
+
+func ValidateStatementPageSize(value int32) error {
+	if value < 1 || value > 100 {
+		return fmt.Errorf("must be from 1-100")
+	}
+	return nil
+}
+
+func ValidateTOTPCode(value string) error {
+	if !isValidTOTPCode(value) {
+		return fmt.Errorf("must be a 6 digit code")
+	}
+	return nil
+}
+
+func ValidateRecoveryCode(value string) error {
+	return ValidateString(value, 16, 32)
+}
+
+func ValidateRole(value string) error {
+	if !utils.IsSupportedRole(value) {
+		return fmt.Errorf("unsupported role")
+	}
+	return nil
+}
+
+func ValidateReason(value string) error {
+	return ValidateString(strings.TrimSpace(value), 3, 500)
+}
+
+func ValidateSearchQuery(value string) error {
+	return ValidateString(value, 2, 100)
+}
+
+// ValidateAdjustment checks a signed amount added to a balance.
+func ValidateAdjustment(value int64) error {
+	if value == 0 {
+		return fmt.Errorf("must not be 0")
+	}
+	return nil
+}
+
+// ValidateTransferLimit checks a transfer limit, where 0 allows nothing.
+func ValidateTransferLimit(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
@@ -13,6 +13,26 @@ type TaskDistributor interface {
 		opts ...asynq.Option,
 
 	) error
+	DistributeTaskExecuteScheduledTransfer(
+		ctx context.Context,
+		payload PayloadExecuteScheduledTransfer,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendAccountStatement(
+		ctx context.Context,
+		payload PayloadSendAccountStatement,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendAccountLockedEmail(
+		ctx context.Context,
+		payload PayloadSendAccountLockedEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendPasswordResetEmail(
+		ctx context.Context,
+		payload PayloadSendPasswordResetEmail,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -42,6 +42,82 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskExecuteScheduledTransfer mocks base method.
+func (m *MockTaskDistributor) DistributeTaskExecuteScheduledTransfer(ctx context.Context, payload worker.PayloadExecuteScheduledTransfer, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskExecuteScheduledTransfer", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskExecuteScheduledTransfer indicates an expected call of DistributeTaskExecuteScheduledTransfer.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskExecuteScheduledTransfer(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskExecuteScheduledTransfer", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskExecuteScheduledTransfer), varargs...)
+}
+
+// DistributeTaskSendAccountLockedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountLockedEmail(ctx context.Context, payload worker.PayloadSendAccountLockedEmail, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendAccountLockedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendAccountLockedEmail indicates an expected call of DistributeTaskSendAccountLockedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountLockedEmail(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountLockedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountLockedEmail), varargs...)
+}
+
+// DistributeTaskSendAccountStatement mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountStatement(ctx context.Context, payload worker.PayloadSendAccountStatement, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendAccountStatement", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendAccountStatement indicates an expected call of DistributeTaskSendAccountStatement.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountStatement(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountStatement", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountStatement), varargs...)
+}
+
+// DistributeTaskSendPasswordResetEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPasswordResetEmail(ctx context.Context, payload worker.PayloadSendPasswordResetEmail, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPasswordResetEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPasswordResetEmail indicates an expected call of DistributeTaskSendPasswordResetEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPasswordResetEmail(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPasswordResetEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPasswordResetEmail), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload worker.PayloadSendVerifyEmail, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -22,15 +23,48 @@ type TaskProcessor interface {
 		ctx context.Context,
 		task *asynq.Task,
 	) error
+	ProcessTaskDispatchScheduledTransfers(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskExecuteScheduledTransfer(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskSendAccountStatement(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskPurgeRevokedTokens(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskSendAccountLockedEmail(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskSendPasswordResetEmail(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskMarkDormantAccounts(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskVerifyLedgerIntegrity(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
-	mailer mail.EmailSender
+	server      *asynq.Server
+	store       db.Store
+	mailer      mail.EmailSender
+	distributor TaskDistributor
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender, distributor TaskDistributor) TaskProcessor {
 	server := asynq.NewServer(redisOpt, asynq.Config{
 		Queues: map[string]int{
 			QueueCritical: 10,
@@ -42,12 +76,20 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 				Bytes("payload", task.Payload()).
 				Msg("task processing failed")
 		}),
+		RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
+			// Give the owner time to top up the account before trying again.
+			if task.Type() == TaskExecuteScheduledTransfer {
+				return time.Duration(n) * time.Hour
+			}
+			return asynq.DefaultRetryDelayFunc(n, err, task)
+		},
 		Logger: NewLogger(),
 	})
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:      server,
+		store:       store,
+		mailer:      mailer,
+		distributor: distributor,
 	}
 }
 
@@ -55,6 +97,14 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskDispatchScheduledTransfers, processor.ProcessTaskDispatchScheduledTransfers)
+	mux.HandleFunc(TaskExecuteScheduledTransfer, processor.ProcessTaskExecuteScheduledTransfer)
+	mux.HandleFunc(TaskSendAccountStatement, processor.ProcessTaskSendAccountStatement)
+	mux.HandleFunc(TaskPurgeRevokedTokens, processor.ProcessTaskPurgeRevokedTokens)
+	mux.HandleFunc(TaskSendAccountLockedEmail, processor.ProcessTaskSendAccountLockedEmail)
+	mux.HandleFunc(TaskSendPasswordResetEmail, processor.ProcessTaskSendPasswordResetEmail)
+	mux.HandleFunc(TaskMarkDormantAccounts, processor.ProcessTaskMarkDormantAccounts)
+	mux.HandleFunc(TaskVerifyLedgerIntegrity, processor.ProcessTaskVerifyLedgerIntegrity)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	dispatchScheduledTransfersSpec = "@every 1m"
+	purgeRevokedTokensSpec         = "@hourly"
+	markDormantAccountsSpec        = "@daily"
+	verifyLedgerIntegritySpec      = "@daily"
+)
+
+type TaskScheduler interface {
+	Start() error
+	ShutDown()
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt) (TaskScheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+	})
+
+	periodicTasks := map[string]string{
+		TaskDispatchScheduledTransfers: dispatchScheduledTransfersSpec,
+		TaskPurgeRevokedTokens:         purgeRevokedTokensSpec,
+		TaskMarkDormantAccounts:        markDormantAccountsSpec,
+		TaskVerifyLedgerIntegrity:      verifyLedgerIntegritySpec,
+	}
+
+	for taskType, spec := range periodicTasks {
+		task := asynq.NewTask(taskType, nil)
+
+		_, err := scheduler.Register(spec, task, asynq.Queue(QueueDefault), asynq.MaxRetry(0))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to register periodic task %s: %w", taskType, err)
+		}
+	}
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+	}, nil
+}
+
+func (scheduler *RedisTaskScheduler) Start() error {
+	return scheduler.scheduler.Start()
+}
+
+func (scheduler *RedisTaskScheduler) ShutDown() {
+	scheduler.scheduler.Shutdown()
+}
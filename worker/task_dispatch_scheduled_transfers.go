@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+)
+
+const (
+	TaskDispatchScheduledTransfers = "task:dispatch_scheduled_transfers"
+
+	dispatchScheduledTransfersBatchSize = 100
+)
+
+func (processor *RedisTaskProcessor) ProcessTaskDispatchScheduledTransfers(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	result, err := processor.store.DispatchScheduledTransfersTx(ctx, db.DispatchScheduledTransfersTxParams{
+		Now:   time.Now(),
+		Limit: dispatchScheduledTransfersBatchSize,
+		AfterClaim: func(scheduledTransfer db.ScheduledTransfer) error {
+			payload := PayloadExecuteScheduledTransfer{
+				ScheduledTransferID: scheduledTransfer.ID,
+				ScheduledAt:         scheduledTransfer.NextRunAt,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(scheduledTransferMaxRetry),
+				asynq.Queue(QueueCritical),
+			}
+
+			return processor.distributor.DistributeTaskExecuteScheduledTransfer(ctx, payload, opts...)
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to dispatch scheduled transfers: %w", err)
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Int("count", len(result.ScheduledTransfers)).
+		Msg("processed dispatch scheduled transfers task")
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+)
+
+type PayloadExecuteScheduledTransfer struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time `json:"scheduled_at"`
+}
+
+const (
+	TaskExecuteScheduledTransfer = "task:execute_scheduled_transfer"
+
+	scheduledTransferMaxRetry = 3
+)
+
+func (distributor *RedisTaskDistributor) DistributeTaskExecuteScheduledTransfer(
+	ctx context.Context,
+	payload PayloadExecuteScheduledTransfer,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskExecuteScheduledTransfer, jsonPayload, opts...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("task enqueued successfully")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskExecuteScheduledTransfer(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadExecuteScheduledTransfer
+
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	scheduledTransfer, err := processor.store.GetScheduledTransfer(ctx, payload.ScheduledTransferID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("scheduled transfer not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+
+	if !scheduledTransfer.IsActive {
+		log.Info().Int64("scheduled_transfer_id", scheduledTransfer.ID).
+			Msg("skipped inactive scheduled transfer")
+		return nil
+	}
+
+	// The key makes every run execute at most once, even when the task is
+	// delivered again after the transfer was committed.
+	result, transferErr := processor.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID:  scheduledTransfer.FromAccountID,
+		ToAccountID:    scheduledTransfer.ToAccountID,
+		Amount:         scheduledTransfer.Amount,
+		Owner:          scheduledTransfer.Owner,
+		IdempotencyKey: fmt.Sprintf("scheduled_transfer:%d:%d", scheduledTransfer.ID, payload.ScheduledAt.Unix()),
+		// The owner set the transfer up, so the runs are made on their behalf.
+		Actor: db.AuditActor{Username: scheduledTransfer.Owner},
+	})
+
+	retryCount, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+	run := db.CreateScheduledTransferRunParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         payload.ScheduledAt,
+		Attempt:             int32(retryCount + 1),
+		Status:              db.ScheduledTransferRunSucceeded,
+	}
+
+	retry := false
+
+	if transferErr != nil {
+		retry = retryCount < maxRetry && !isPermanentTransferError(transferErr)
+
+		run.Status = db.ScheduledTransferRunFailed
+		if retry {
+			run.Status = db.ScheduledTransferRunRetrying
+		}
+
+		run.Error = pgtype.Text{
+			String: transferErr.Error(),
+			Valid:  true,
+		}
+	} else {
+		run.TransferID = pgtype.Int8{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		}
+	}
+
+	if _, err := processor.store.CreateScheduledTransferRun(ctx, run); err != nil {
+		return fmt.Errorf("failed to record scheduled transfer run: %w", err)
+	}
+
+	if retry {
+		return fmt.Errorf("failed to execute scheduled transfer: %w", transferErr)
+	}
+
+	if transferErr != nil {
+		if err := processor.notifyScheduledTransferFailed(ctx, scheduledTransfer, transferErr); err != nil {
+			log.Error().Err(err).Int64("scheduled_transfer_id", scheduledTransfer.ID).
+				Msg("failed to notify owner of failed scheduled transfer")
+		}
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("status", run.Status).
+		Msg("processed execute scheduled transfer task")
+
+	return nil
+}
+
+func isPermanentTransferError(err error) bool {
+	return errors.Is(err, db.ErrSameAccount) ||
+		errors.Is(err, db.ErrInvalidAmount) ||
+		errors.Is(err, db.ErrCurrencyMismatch) ||
+		errors.Is(err, db.ErrAccountOwnerMismatch) ||
+		errors.Is(err, db.ErrAccountUnavailable) ||
+		errors.Is(err, db.ErrTransferLimitExceeded) ||
+		errors.Is(err, db.ErrorRecordNotFound)
+}
+
+func (processor *RedisTaskProcessor) notifyScheduledTransferFailed(ctx context.Context, scheduledTransfer db.ScheduledTransfer, transferErr error) error {
+	user, err := processor.store.GetUser(ctx, scheduledTransfer.Owner)
+
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	reason := "an unexpected error occurred"
+	if errors.Is(transferErr, db.ErrInsufficientFunds) {
+		reason = "your account does not have enough funds"
+	} else if errors.Is(transferErr, db.ErrTransferLimitExceeded) {
+		reason = "it would exceed the transfer limits of your account"
+	} else if isPermanentTransferError(transferErr) {
+		reason = "one of the accounts can no longer be used for this transfer"
+	}
+
+	// Retrying does not help with a permanent error, so the owner has to act
+	// before the next run.
+	next := "We will try again at the next scheduled run."
+	if isPermanentTransferError(transferErr) {
+		next = "Retrying will not help, please check the scheduled transfer and its accounts before the next run."
+	}
+
+	subject := "Your scheduled transfer could not be completed"
+
+	content := fmt.Sprintf(`
+		<h1>Hello %s</h1>
+		<p>We could not transfer %d from account #%d to account #%d because %s.</p>
+		<p>%s</p>
+	`, user.FullName, scheduledTransfer.Amount, scheduledTransfer.FromAccountID, scheduledTransfer.ToAccountID, reason, next)
+
+	to := []string{user.Email}
+
+	return processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+}
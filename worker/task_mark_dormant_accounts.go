@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskMarkDormantAccounts = "task:mark_dormant_accounts"
+
+	// accountDormancyPeriod is how long an active account can go without a
+	// transfer, deposit or withdrawal before it becomes dormant.
+	accountDormancyPeriod = 365 * 24 * time.Hour
+)
+
+// ProcessTaskMarkDormantAccounts marks the accounts without recent customer
+// activity as dormant, so that they must be reactivated by a banker before
+// money can leave them.
+func (processor *RedisTaskProcessor) ProcessTaskMarkDormantAccounts(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	marked, err := processor.store.MarkDormantAccounts(ctx, time.Now().Add(-accountDormancyPeriod))
+
+	if err != nil {
+		return fmt.Errorf("failed to mark dormant accounts: %w", err)
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Int64("count", marked).
+		Msg("processed mark dormant accounts task")
+
+	return nil
+}
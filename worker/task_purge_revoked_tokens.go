@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPurgeRevokedTokens = "task:purge_revoked_tokens"
+
+// ProcessTaskPurgeRevokedTokens deletes revoked tokens that have expired, as
+// they are rejected by the token maker anyway.
+func (processor *RedisTaskProcessor) ProcessTaskPurgeRevokedTokens(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	deleted, err := processor.store.DeleteExpiredRevokedTokens(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete expired revoked tokens: %w", err)
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Int64("count", deleted).
+		Msg("processed purge revoked tokens task")
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+)
+
+type PayloadSendAccountLockedEmail struct {
+	Username    string    `json:"username"`
+	ClientIP    string    `json:"client_ip"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+const TaskSendAccountLockedEmail = "task:send_account_locked_email"
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountLockedEmail(
+	ctx context.Context,
+	payload PayloadSendAccountLockedEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendAccountLockedEmail, jsonPayload, opts...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("task enqueued successfully")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountLockedEmail(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadSendAccountLockedEmail
+
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Your Simple Bank account has been locked"
+
+	content := fmt.Sprintf(`
+		<h1>Hello %s</h1>
+		<p>We locked your account after too many failed login attempts, the last one from %s.</p>
+		<p>You can try to log in again after %s.</p>
+		<p>If this was not you, please change your password as soon as you can log in.</p>
+	`, user.FullName, payload.ClientIP, payload.LockedUntil.Format(time.RFC1123))
+
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return fmt.Errorf("failed to send account locked email: %w", err)
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Str("username", payload.Username).
+		Str("email", user.Email).
+		Msg("processed send account locked email task")
+
+	return nil
+}
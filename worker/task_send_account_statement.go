@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/statement"
+)
+
+type PayloadSendAccountStatement struct {
+	// Username is the owner of the account, who gets the statement.
+	Username string `json:"username"`
+	// RequestedBy is who asked for the statement, the owner or a banker.
+	RequestedBy string    `json:"requested_by"`
+	AccountID   int64     `json:"account_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Format      string    `json:"format"`
+}
+
+const TaskSendAccountStatement = "task:send_account_statement"
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountStatement(
+	ctx context.Context,
+	payload PayloadSendAccountStatement,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendAccountStatement, jsonPayload, opts...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("task enqueued successfully")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountStatement(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadSendAccountStatement
+
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	if !statement.IsSupportedFormat(payload.Format) {
+		return fmt.Errorf("unsupported statement format %q: %w", payload.Format, asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	account, err := processor.store.GetAccount(ctx, payload.AccountID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("account not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if account.Owner != user.Username {
+		return fmt.Errorf("account does not belong to user: %w", asynq.SkipRetry)
+	}
+
+	accountStatement, err := statement.Load(ctx, processor.store, account, payload.StartTime, payload.EndTime)
+
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "statement-")
+
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, statement.FileName(payload.Format, accountStatement))
+
+	if err := writeStatementFile(fileName, payload.Format, accountStatement); err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Your Simple Bank statement for account #%d", account.ID)
+
+	requestedBy := ""
+	if payload.RequestedBy != "" && payload.RequestedBy != user.Username {
+		requestedBy = "<p>It was requested on your behalf by our staff.</p>"
+	}
+
+	content := fmt.Sprintf(`
+		<h1>Hello %s</h1>
+		<p>Your statement for account #%d from %s to %s is attached.</p>
+		%s
+	`, user.FullName, account.ID, payload.StartTime.Format(time.DateOnly), payload.EndTime.Format(time.DateOnly), requestedBy)
+
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, []string{fileName})
+
+	if err != nil {
+		return fmt.Errorf("failed to send statement email: %w", err)
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("requested_by", payload.RequestedBy).
+		Int("entries", len(accountStatement.Entries)).
+		Msg("processed send account statement task")
+
+	return nil
+}
+
+func writeStatementFile(fileName string, format string, accountStatement statement.Statement) error {
+	file, err := os.Create(fileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create statement file: %w", err)
+	}
+
+	if err := statement.Render(file, format, accountStatement); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to render statement: %w", err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+	"github.com/starjardin/simplebank/utils"
+)
+
+type PayloadSendPasswordResetEmail struct {
+	Username string `json:"username"`
+}
+
+const TaskSendPasswordResetEmail = "task:send_password_reset_email"
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPasswordResetEmail(
+	ctx context.Context,
+	payload PayloadSendPasswordResetEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPasswordResetEmail, jsonPayload, opts...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("task enqueued successfully")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordResetEmail(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadSendPasswordResetEmail
+
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secretCode, err := utils.NewSecretCode()
+
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset code: %w", err)
+	}
+
+	// Only the hash is stored, the code itself is only in the email.
+	passwordReset, err := processor.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: utils.HashSecretCode(secretCode),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	subject := "Reset your Simple Bank password"
+
+	content := fmt.Sprintf(`
+		<h1>Hello %s</h1>
+		<p>We received a request to reset the password of your Simple Bank account.</p>
+		<p>Use reset id <b>%d</b> and code <b>%s</b> to choose a new password. The code expires at %s.</p>
+		<p>If you did not ask for this, you can ignore this email.</p>
+	`, user.FullName, passwordReset.ID, secretCode, passwordReset.ExpiredAt.Format("15:04 MST"))
+
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Str("username", payload.Username).
+		Str("email", user.Email).
+		Msg("processed send password reset email task")
+
+	return nil
+}
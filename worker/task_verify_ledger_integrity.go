@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/starjardin/simplebank/db/sqlc"
+)
+
+const TaskVerifyLedgerIntegrity = "task:verify_ledger_integrity"
+
+// ProcessTaskVerifyLedgerIntegrity checks the hash chain of the whole ledger
+// and logs the first broken link. A broken link is not retried since checking
+// again gives the same answer.
+func (processor *RedisTaskProcessor) ProcessTaskVerifyLedgerIntegrity(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	result, err := processor.store.VerifyLedgerIntegrity(ctx, db.VerifyLedgerIntegrityParams{})
+
+	if err != nil {
+		return fmt.Errorf("failed to verify ledger integrity: %w", err)
+	}
+
+	if result.Break != nil {
+		log.Error().Str("task_type", task.Type()).
+			Int64("checked_entries", result.CheckedEntries).
+			Int64("account_id", result.Break.AccountID).
+			Int64("entry_id", result.Break.EntryID).
+			Str("reason", result.Break.Reason).
+			Msg("ledger integrity check found a broken link")
+		return nil
+	}
+
+	log.Info().Str("task_type", task.Type()).
+		Int64("checked_entries", result.CheckedEntries).
+		Msg("processed verify ledger integrity task")
+
+	return nil
+}